@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"todo-grpc/pb"
+	"todo-grpc/utils"
+)
+
+func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersReq) (*pb.ListUsersRes, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseListUsersReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid list users request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	users, err := s.AdminSvc.ListUsers(ctx, actor, filter)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	res := &pb.ListUsersRes{
+		Users: make([]*pb.AdminUser, 0, len(users.Users)),
+		Count: users.Count,
+	}
+	for i := range users.Users {
+		res.Users = append(res.Users, utils.ConvertDbUserToApiAdminUser(&users.Users[i]))
+	}
+	return res, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *pb.GetUserReq) (*pb.AdminUser, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, err := utils.ParseObjectId(req.GetUserId(), "user id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	user, err := s.AdminSvc.GetUser(ctx, actor, userId)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbUserToApiAdminUser(user), nil
+}
+
+func (s *Server) DisableUser(ctx context.Context, req *pb.DisableUserReq) (*pb.AdminUser, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, reason, err := utils.ParseAdminUserChange(req.GetUserId(), req.GetReason())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid disable user request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	user, err := s.AdminSvc.DisableUser(ctx, actor, userId, reason)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbUserToApiAdminUser(user), nil
+}
+
+func (s *Server) EnableUser(ctx context.Context, req *pb.EnableUserReq) (*pb.AdminUser, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, reason, err := utils.ParseAdminUserChange(req.GetUserId(), req.GetReason())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid enable user request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	user, err := s.AdminSvc.EnableUser(ctx, actor, userId, reason)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbUserToApiAdminUser(user), nil
+}
+
+func (s *Server) ForceLogout(ctx context.Context, req *pb.ForceLogoutReq) (*emptypb.Empty, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, reason, err := utils.ParseAdminUserChange(req.GetUserId(), req.GetReason())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid force logout request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	err = s.AdminSvc.ForceLogout(ctx, actor, userId, reason)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) SetPremium(ctx context.Context, req *pb.SetPremiumReq) (*pb.AdminUser, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, reason, expireTime, err := utils.ParseSetPremiumReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid set premium request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	user, err := s.AdminSvc.SetPremium(ctx, actor, userId, req.GetIsPremium(), expireTime, reason)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbUserToApiAdminUser(user), nil
+}
+
+func (s *Server) Impersonate(ctx context.Context, req *pb.ImpersonateReq) (*pb.ImpersonateRes, error) {
+	actor, err := utils.GetAuditActorFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	userId, reason, ttl, err := utils.ParseImpersonateReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid impersonate request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	token, expireTime, err := s.AdminSvc.Impersonate(ctx, actor, userId, reason, ttl)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.ImpersonateRes{
+		Token:     token,
+		ExpiresAt: timestamppb.New(expireTime),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"todo-grpc/pb"
+	"todo-grpc/utils"
+)
+
+func (s *Server) GetDailyActiveUsers(
+	ctx context.Context, req *pb.AnalyticsRangeReq,
+) (*pb.DailyActiveUsersRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseAnalyticsRangeReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     err.Error(),
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	dailyActiveUsers, err := s.AnalyticsSvc.DailyActiveUsers(ctx, scope, filter)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbDailyActiveUsersToApi(dailyActiveUsers), nil
+}
+
+func (s *Server) GetCompletionFunnel(
+	ctx context.Context, req *pb.AnalyticsRangeReq,
+) (*pb.CompletionFunnelRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseAnalyticsRangeReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     err.Error(),
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	funnel, err := s.AnalyticsSvc.CompletionFunnel(ctx, scope, filter)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbCompletionFunnelToApi(funnel), nil
+}
@@ -10,11 +10,24 @@ import (
 type Server struct {
 	pb.UnimplementedUserServiceServer
 	pb.UnimplementedTodoServiceServer
+	pb.UnimplementedWorkspaceServiceServer
+	pb.UnimplementedAnalyticsServiceServer
+	pb.UnimplementedViewServiceServer
+	pb.UnimplementedAdminServiceServer
 
-	TodoSvc       service.TodoService
-	UserSvc       service.UserService
-	AlertSvc      service.AlertService
-	Config        utils.EnvConfig
-	Logger        *utils.Logger
-	KafkaProvider kafkaQueueProvider.Provider
+	TodoSvc        service.TodoService
+	UserSvc        service.UserService
+	AlertSvc       service.AlertService
+	WorkspaceSvc   service.WorkspaceService
+	IdempotencySvc service.IdempotencyService
+	AnalyticsSvc   service.AnalyticsService
+	ViewSvc        service.ViewService
+	RevocationSvc  service.RevocationService
+	AccountSvc     service.AccountService
+	AccessTokenSvc service.AccessTokenService
+	OidcSvc        service.OidcService
+	AdminSvc       service.AdminService
+	Config         utils.EnvConfig
+	Logger         *utils.Logger
+	KafkaProvider  kafkaQueueProvider.Provider
 }
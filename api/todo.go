@@ -2,26 +2,31 @@ package api
 
 import (
 	"context"
-	"time"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"todo-grpc/models"
 	"todo-grpc/pb"
 	"todo-grpc/utils"
 )
 
 func (s *Server) CreateTodo(ctx context.Context, req *pb.CreateTodoReq) (*pb.CreateTodoRes, error) {
-	userId := utils.GetUserNameFromContext(ctx)
-	if userId == "" {
-		customError := &utils.UnAuthenticatedError{
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = utils.ValidateCreateTodoReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
-				Msg: "not authenticated",
+				DevInfo: err.Error(),
+				Msg:     "invalid create todo request",
 			},
 		}
-		return nil, utils.CreateStatusErrorFromError(
-			customError, s.Logger,
-		)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	err := utils.ValidateCreateTodoReq(req)
+	req.Todo.UserId = scope.UserID.Hex()
+	dbTodo, err := utils.ConvertApiTodoDbToto(req.GetTodo())
 	if err != nil {
 		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
@@ -31,10 +36,29 @@ func (s *Server) CreateTodo(ctx context.Context, req *pb.CreateTodoReq) (*pb.Cre
 		}
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
+	dbTodo.WorkspaceID = scope.WorkspaceID
 
-	req.Todo.UserId = userId
-	dbTodo, err := utils.ConvertApiTodoDbToto(req.GetTodo())
+	todo, err := s.TodoSvc.CreateTodo(ctx, dbTodo)
 	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.CreateTodoRes{
+		Todo: utils.ConvertDbTodoApiToto(todo),
+	}, nil
+}
+
+func (s *Server) ListTodo(ctx context.Context, req *pb.ListTodoReq) (*pb.ListTodoRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseListTodoReq(req, s.Logger)
+	if err != nil {
+		if _, ok := err.(*utils.QuerySyntaxError); ok {
+			return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+		}
 		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
@@ -44,36 +68,100 @@ func (s *Server) CreateTodo(ctx context.Context, req *pb.CreateTodoReq) (*pb.Cre
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	todo, err := s.TodoSvc.CreateTodo(ctx, dbTodo)
+	if req.GetViewId() != "" {
+		view, err := s.ViewSvc.FetchView(ctx, scope, req.GetViewId())
+		if err != nil {
+			return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+		}
+		filter.Query = &view.Query
+	}
+
+	todoRes, err := s.TodoSvc.ListTodos(ctx, scope, filter)
 	if err != nil {
 		customErr := &utils.SystemInternalError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "unable to create todo",
+				Msg:     "unable to fetch todos",
 			},
 		}
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	return &pb.CreateTodoRes{
-		Todo: utils.ConvertDbTodoApiToto(todo),
+	apiTodos := make([]*pb.Todo, 0)
+	for _, todo := range todoRes.Todos {
+		apiTodos = append(apiTodos, utils.ConvertDbTodoApiToto(&todo))
+	}
+
+	return &pb.ListTodoRes{
+		Todos: apiTodos,
+		Count: int32(todoRes.Count),
 	}, nil
 }
 
-func (s *Server) ListTodo(ctx context.Context, req *pb.ListTodoReq) (*pb.ListTodoRes, error) {
-	userId := utils.GetUserNameFromContext(ctx)
-	if userId == "" {
-		customError := &utils.UnAuthenticatedError{
+func (s *Server) StreamTodo(req *pb.StreamTodoReq, stream pb.TodoService_StreamTodoServer) error {
+	scope, err := utils.GetScopeFromContext(stream.Context())
+	if err != nil {
+		return utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = s.TodoSvc.WatchTodos(
+		stream.Context(),
+		scope,
+		utils.ParseStreamTodoReq(req),
+		req.GetResumeToken(),
+		func(event *models.TodoEvent) error {
+			return stream.Send(utils.ConvertDbTodoEventToApi(event))
+		},
+	)
+	if err != nil {
+		if _, ok := err.(*utils.ReqInvalidArgumentError); ok {
+			return utils.CreateStatusErrorFromError(err, s.Logger)
+		}
+		customErr := &utils.SystemInternalError{
 			GeneralError: &utils.GeneralError{
-				Msg: "not authenticated",
+				DevInfo: err.Error(),
+				Msg:     "unable to stream todos",
 			},
 		}
-		return nil, utils.CreateStatusErrorFromError(
-			customError, s.Logger,
-		)
+		return utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	filter, err := utils.ParseListTodoReq(req, s.Logger)
+	return nil
+}
+
+func (s *Server) GetTodo(ctx context.Context, req *pb.GetTodoReq) (*pb.Todo, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	todo, err := s.TodoSvc.FetchTodo(ctx, req.GetTodoId(), scope)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbTodoApiToto(todo), nil
+}
+
+func (s *Server) UpdateTodo(ctx context.Context, req *pb.UpdateTodoReq) (*pb.UpdateTodoRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	fieldMaskPaths, err := utils.ValidateUpdateTodoFieldMask(req.GetFieldMask())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "Invalid updatable fields",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	req.Todo.UserId = scope.UserID.Hex()
+	dbTodo, err := utils.ConvertApiTodoDbToto(req.GetTodo())
 	if err != nil {
 		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
@@ -84,18 +172,45 @@ func (s *Server) ListTodo(ctx context.Context, req *pb.ListTodoReq) (*pb.ListTod
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	todoRes, err := s.TodoSvc.ListTodos(ctx, userId, filter)
+	updatedTodo, err := s.TodoSvc.UpdateTodo(ctx, scope, dbTodo, fieldMaskPaths)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.UpdateTodoRes{
+		Todo: utils.ConvertDbTodoApiToto(updatedTodo),
+	}, nil
+}
+
+func (s *Server) ListAssignedTodos(ctx context.Context, req *pb.ListAssignedTodosReq) (*pb.ListTodoRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseListAssignedTodosReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid list assigned todos request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	todoRes, err := s.TodoSvc.ListAssignedTodos(ctx, scope, filter)
 	if err != nil {
 		customErr := &utils.SystemInternalError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "unable to fetch todos",
+				Msg:     "unable to fetch assigned todos",
 			},
 		}
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	apiTodos := make([]*pb.Todo, 0)
+	apiTodos := make([]*pb.Todo, 0, len(todoRes.Todos))
 	for _, todo := range todoRes.Todos {
 		apiTodos = append(apiTodos, utils.ConvertDbTodoApiToto(&todo))
 	}
@@ -106,114 +221,241 @@ func (s *Server) ListTodo(ctx context.Context, req *pb.ListTodoReq) (*pb.ListTod
 	}, nil
 }
 
-func (s *Server) StreamTodo(req *pb.StreamTodoReq, stream pb.TodoService_StreamTodoServer) error {
-	userId := utils.GetUserNameFromContext(stream.Context())
-	if userId == "" {
-		customError := &utils.UnAuthenticatedError{
+func (s *Server) DeleteTodo(ctx context.Context, req *pb.DeleteTodoReq) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = s.TodoSvc.DeleteTodo(ctx, req.GetTodoId(), scope)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) SyncTodos(ctx context.Context, req *pb.SyncTodosReq) (*pb.SyncTodosRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	since, limit, err := utils.ParseSyncTodosReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
-				Msg: "not authenticated",
+				DevInfo: err.Error(),
+				Msg:     "invalid sync todos request",
 			},
 		}
-		return utils.CreateStatusErrorFromError(
-			customError, s.Logger,
-		)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	syncRes, err := s.TodoSvc.SyncTodos(ctx, scope, since, limit)
+	if err != nil {
+		customErr := &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to sync todos",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	filter := &models.ListTodoFilter{
-		Limit: 100,
-		Page:  0,
+	apiTodos := make([]*pb.Todo, 0, len(syncRes.Todos))
+	for _, todo := range syncRes.Todos {
+		apiTodos = append(apiTodos, utils.ConvertDbTodoApiToto(&todo))
+	}
+	apiTombstones := make([]*pb.TodoTombstone, 0, len(syncRes.Tombstones))
+	for _, tombstone := range syncRes.Tombstones {
+		apiTombstones = append(apiTombstones, utils.ConvertDbTombstoneToApi(&tombstone))
 	}
 
-	todoRes, err := s.TodoSvc.ListTodos(stream.Context(), userId, filter)
+	return &pb.SyncTodosRes{
+		Todos:      apiTodos,
+		Tombstones: apiTombstones,
+		SyncToken:  utils.EncodeSyncToken(syncRes.Position),
+		HasMore:    syncRes.HasMore,
+		FullResync: syncRes.FullResync,
+	}, nil
+}
+
+func (s *Server) PushTodoChanges(ctx context.Context, req *pb.PushTodoChangesReq) (*pb.PushTodoChangesRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	changes := make([]models.TodoChange, 0, len(req.GetChanges()))
+	for _, apiChange := range req.GetChanges() {
+		change, err := utils.ConvertApiTodoChangeToDb(apiChange, scope.UserID.Hex())
+		if err != nil {
+			customErr := &utils.ReqInvalidArgumentError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid change " + apiChange.GetClientChangeId(),
+				},
+			}
+			return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+		}
+		change.Todo.WorkspaceID = scope.WorkspaceID
+		changes = append(changes, *change)
+	}
+
+	results, err := s.TodoSvc.PushTodoChanges(ctx, scope, changes)
 	if err != nil {
 		customErr := &utils.SystemInternalError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "unable to list todo",
+				Msg:     "unable to apply changes",
 			},
 		}
-		return utils.CreateStatusErrorFromError(customErr, s.Logger)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	for _, todo := range todoRes.Todos {
-		err = stream.Send(
-			&pb.StreamTodoRes{
-				Todo:  utils.ConvertDbTodoApiToto(&todo),
-				Count: int32(todoRes.Count),
+	apiResults := make([]*pb.TodoChangeResult, 0, len(results))
+	for _, result := range results {
+		apiResults = append(apiResults, utils.ConvertDbTodoChangeResultToApi(&result))
+	}
+
+	return &pb.PushTodoChangesRes{
+		Results: apiResults,
+	}, nil
+}
+
+func (s *Server) GetStats(ctx context.Context, req *pb.GetStatsReq) (*pb.GetStatsRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseGetStatsReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     err.Error(),
 			},
-		)
-		if err != nil {
-			return err
 		}
-		time.Sleep(2 * time.Second)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	return nil
+	stats, err := s.TodoSvc.GetStats(ctx, scope, filter)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbTodoStatsToApi(stats), nil
 }
 
-func (s *Server) GetTodo(ctx context.Context, req *pb.GetTodoReq) (*pb.Todo, error) {
-	userId := utils.GetUserNameFromContext(ctx)
-	if userId == "" {
-		customError := &utils.UnAuthenticatedError{
+func (s *Server) GetAgenda(ctx context.Context, req *pb.GetAgendaReq) (*pb.GetAgendaRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseGetAgendaReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
-				Msg: "not authenticated",
+				DevInfo: err.Error(),
+				Msg:     err.Error(),
 			},
 		}
-		return nil, utils.CreateStatusErrorFromError(
-			customError, s.Logger,
-		)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	todo, err := s.TodoSvc.FetchTodo(ctx, req.GetTodoId(), userId)
+	agenda, err := s.TodoSvc.GetAgenda(ctx, scope, filter)
 	if err != nil {
 		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
 	}
 
-	return utils.ConvertDbTodoApiToto(todo), nil
+	return utils.ConvertDbAgendaToApi(agenda), nil
 }
 
-func (s *Server) UpdateTodo(ctx context.Context, req *pb.UpdateTodoReq) (*pb.UpdateTodoRes, error) {
-	userId := utils.GetUserNameFromContext(ctx)
-	if userId == "" {
-		customError := &utils.UnAuthenticatedError{
+func (s *Server) GetBoard(ctx context.Context, req *pb.GetBoardReq) (*pb.GetBoardRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	filter, err := utils.ParseGetBoardReq(req)
+	if err != nil {
+		if _, ok := err.(*utils.QuerySyntaxError); ok {
+			return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+		}
+		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
-				Msg: "not authenticated",
+				DevInfo: err.Error(),
+				Msg:     err.Error(),
 			},
 		}
-		return nil, utils.CreateStatusErrorFromError(
-			customError, s.Logger,
-		)
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	fieldMaskPaths, err := utils.ValidateUpdateTodoFieldMask(req.GetFieldMask())
+	if req.GetViewId() != "" {
+		view, err := s.ViewSvc.FetchView(ctx, scope, req.GetViewId())
+		if err != nil {
+			return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+		}
+		filter.Todos.Query = &view.Query
+	}
+
+	board, err := s.TodoSvc.GetBoard(ctx, scope, filter)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbBoardToApi(board), nil
+}
+
+func (s *Server) MoveCard(ctx context.Context, req *pb.MoveCardReq) (*pb.Todo, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	move, err := utils.ParseMoveCardReq(req)
 	if err != nil {
 		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "Invalid updatable fields",
+				Msg:     err.Error(),
 			},
 		}
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	req.Todo.UserId = userId
-	dbTodo, err := utils.ConvertApiTodoDbToto(req.GetTodo())
+	todo, err := s.TodoSvc.MoveCard(ctx, scope, move)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbTodoApiToto(todo), nil
+}
+
+func (s *Server) SnoozeTodo(ctx context.Context, req *pb.SnoozeTodoReq) (*pb.Todo, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	todoId, until, err := utils.ParseSnoozeTodoReq(req)
 	if err != nil {
 		customErr := &utils.ReqInvalidArgumentError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "invalid create todo request",
+				Msg:     err.Error(),
 			},
 		}
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 
-	updatedTodo, err := s.TodoSvc.UpdateTodo(ctx, dbTodo, fieldMaskPaths)
+	todo, err := s.TodoSvc.SnoozeTodo(ctx, scope, todoId, until)
 	if err != nil {
 		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
 	}
 
-	return &pb.UpdateTodoRes{
-		Todo: utils.ConvertDbTodoApiToto(updatedTodo),
-	}, nil
+	return utils.ConvertDbTodoApiToto(todo), nil
 }
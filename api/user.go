@@ -2,7 +2,11 @@ package api
 
 import (
 	"context"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"todo-grpc/models"
 	"todo-grpc/pb"
 	"todo-grpc/utils"
 )
@@ -21,13 +25,7 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	user := utils.ConvertApiUserToDbUser(req.GetUser())
 	res, err := s.UserSvc.Register(ctx, user)
 	if err != nil {
-		customErr := &utils.SystemInternalError{
-			GeneralError: &utils.GeneralError{
-				DevInfo: err.Error(),
-				Msg:     "unable to create user",
-			},
-		}
-		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
 	}
 
 	return res, nil
@@ -45,16 +43,459 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
 	}
 	user := utils.ConvertApiUserToDbUser(req.GetUser())
-	token, err := s.UserSvc.Login(ctx, user)
+	tokens, err := s.UserSvc.Login(ctx, user, utils.GetClientIpFromContext(ctx))
 	if err != nil {
 		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
 	}
 
+	return convertAuthTokensToLoginResponse(tokens), nil
+}
+
+func (s *Server) Verify2FA(ctx context.Context, req *pb.Verify2FARequest) (*pb.LoginResponse, error) {
+	if err := utils.ValidateVerify2FAReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid verify 2fa request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	tokens, err := s.UserSvc.Verify2FA(
+		ctx, req.GetChallengeToken(), req.GetCode(), utils.GetClientIpFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return convertAuthTokensToLoginResponse(tokens), nil
+}
+
+func convertAuthTokensToLoginResponse(tokens *models.AuthTokens) *pb.LoginResponse {
+	if tokens.ChallengeToken != "" {
+		return &pb.LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    tokens.ChallengeToken,
+		}
+	}
 	return &pb.LoginResponse{
-		Token: token,
-	}, nil
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpireTime),
+	}
 }
 
 func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
-	return nil, nil
+	md, _ := metadata.FromIncomingContext(ctx)
+	claims, err := utils.ValidateJwtToken(ctx, md, s.Config, s.RevocationSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.UserSvc.Logout(ctx, claims, req.GetRefreshToken(), req.GetEverywhere()); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if req.GetRefreshToken() == "" {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "refresh token can't be empty",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	tokens, err := s.UserSvc.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpireTime),
+	}, nil
+}
+
+func (s *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "verification token can't be empty",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	if err := s.UserSvc.VerifyEmail(ctx, req.GetToken()); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ResendVerification(
+	ctx context.Context, _ *pb.ResendVerificationRequest,
+) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = s.UserSvc.ResendVerification(ctx, scope.UserID); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RequestPasswordReset(
+	ctx context.Context, req *pb.RequestPasswordResetRequest,
+) (*emptypb.Empty, error) {
+	err := utils.ValidateRequestPasswordResetReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid password reset request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	if err = s.UserSvc.RequestPasswordReset(ctx, utils.NormalizeEmail(req.GetEmail())); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*emptypb.Empty, error) {
+	err := utils.ValidateResetPasswordReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid reset password request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	err = s.UserSvc.ResetPassword(ctx, req.GetToken(), strings.TrimSpace(req.GetNewPassword()))
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	readMask, err := utils.ValidateGetProfileReadMask(req.GetReadMask())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "Invalid readable fields",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	user, err := s.UserSvc.FetchUser(ctx, scope.UserID)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ApplyProfileReadMask(utils.ConvertDbUserToApiProfile(user), readMask), nil
+}
+
+func (s *Server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.Profile, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	fieldMaskPaths, err := utils.ValidateUpdateProfileFieldMask(req.GetFieldMask())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "Invalid updatable fields",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	if err = utils.ValidateUpdateProfileReq(req, fieldMaskPaths); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid update profile request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	user := utils.ConvertApiProfileToDbUser(req.GetProfile())
+	updatedUser, err := s.UserSvc.UpdateProfile(
+		ctx,
+		scope.UserID,
+		user,
+		fieldMaskPaths,
+		strings.TrimSpace(req.GetCurrentPassword()),
+		utils.GetAuthTimeFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbUserToApiProfile(updatedUser), nil
+}
+
+func (s *Server) ChangePassword(
+	ctx context.Context, req *pb.ChangePasswordRequest,
+) (*pb.ChangePasswordResponse, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = utils.ValidateChangePasswordReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid change password request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	tokens, err := s.UserSvc.ChangePassword(
+		ctx,
+		scope.UserID,
+		strings.TrimSpace(req.GetCurrentPassword()),
+		strings.TrimSpace(req.GetNewPassword()),
+		utils.GetAuthTimeFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.ChangePasswordResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpireTime),
+	}, nil
+}
+
+func (s *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = s.AccountSvc.DeleteAccount(
+		ctx, scope.UserID, strings.TrimSpace(req.GetPassword()), utils.GetAuthTimeFromContext(ctx),
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Enable2FA(ctx context.Context, _ *pb.Enable2FARequest) (*pb.Enable2FAResponse, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	setup, err := s.UserSvc.Enable2FA(ctx, scope.UserID)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.Enable2FAResponse{
+		Secret:     setup.Secret,
+		OtpauthUri: setup.OtpAuthUri,
+	}, nil
+}
+
+func (s *Server) Confirm2FA(ctx context.Context, req *pb.Confirm2FARequest) (*pb.Confirm2FAResponse, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = utils.ValidateConfirm2FAReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid confirm 2fa request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	backupCodes, err := s.UserSvc.Confirm2FA(ctx, scope.UserID, req.GetCode())
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.Confirm2FAResponse{BackupCodes: backupCodes}, nil
+}
+
+func (s *Server) Disable2FA(ctx context.Context, req *pb.Disable2FARequest) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = utils.ValidateDisable2FAReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid disable 2fa request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	err = s.UserSvc.Disable2FA(ctx, scope.UserID, req.GetCode(), utils.GetClientIpFromContext(ctx))
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) CreateAccessToken(
+	ctx context.Context, req *pb.CreateAccessTokenRequest,
+) (*pb.CreateAccessTokenResponse, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	token, err := utils.ParseCreateAccessTokenReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid create access token request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+	token.UserID = scope.UserID
+
+	token, plainToken, err := s.AccessTokenSvc.CreateAccessToken(ctx, token)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.CreateAccessTokenResponse{
+		AccessToken: utils.ConvertDbAccessTokenToApi(token),
+		Token:       plainToken,
+	}, nil
+}
+
+func (s *Server) ListAccessTokens(
+	ctx context.Context, _ *pb.ListAccessTokensRequest,
+) (*pb.ListAccessTokensResponse, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	tokens, err := s.AccessTokenSvc.ListAccessTokens(ctx, scope.UserID)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	res := &pb.ListAccessTokensResponse{
+		AccessTokens: make([]*pb.PersonalAccessToken, 0, len(tokens)),
+	}
+	for _, token := range tokens {
+		res.AccessTokens = append(res.AccessTokens, utils.ConvertDbAccessTokenToApi(token))
+	}
+	return res, nil
+}
+
+func (s *Server) RevokeAccessToken(ctx context.Context, req *pb.RevokeAccessTokenRequest) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	tokenId, err := utils.ParseObjectId(req.GetId(), "access token id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = s.AccessTokenSvc.RevokeAccessToken(ctx, scope.UserID, tokenId); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListOidcProviders(
+	_ context.Context, _ *pb.ListOidcProvidersRequest,
+) (*pb.ListOidcProvidersResponse, error) {
+	return &pb.ListOidcProvidersResponse{Providers: s.OidcSvc.ListProviders()}, nil
+}
+
+func (s *Server) StartOidcLogin(
+	ctx context.Context, req *pb.StartOidcLoginRequest,
+) (*pb.StartOidcLoginResponse, error) {
+	if err := utils.ValidateStartOidcLoginReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid start oidc login request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	authorizationUrl, state, err := s.OidcSvc.StartLogin(ctx, req.GetProvider())
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.StartOidcLoginResponse{
+		AuthorizationUrl: authorizationUrl,
+		State:            state,
+	}, nil
+}
+
+func (s *Server) CompleteOidcLogin(ctx context.Context, req *pb.CompleteOidcLoginRequest) (*pb.LoginResponse, error) {
+	if err := utils.ValidateCompleteOidcLoginReq(req); err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid complete oidc login request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	tokens, err := s.OidcSvc.CompleteLogin(ctx, req.GetState(), req.GetCode())
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return convertAuthTokensToLoginResponse(tokens), nil
 }
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"todo-grpc/pb"
+	"todo-grpc/utils"
+)
+
+func (s *Server) CreateView(ctx context.Context, req *pb.CreateViewReq) (*pb.SavedView, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	view, err := utils.ParseCreateViewReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid create view request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	view, err = s.ViewSvc.CreateView(ctx, scope, view)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbSavedViewToApi(view), nil
+}
+
+func (s *Server) ListViews(ctx context.Context, req *pb.ListViewsReq) (*pb.ListViewsRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	views, err := s.ViewSvc.ListViews(ctx, scope)
+	if err != nil {
+		customErr := &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch views",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	apiViews := make([]*pb.SavedView, 0, len(views))
+	for _, view := range views {
+		apiViews = append(apiViews, utils.ConvertDbSavedViewToApi(&view))
+	}
+
+	return &pb.ListViewsRes{
+		Views: apiViews,
+	}, nil
+}
+
+func (s *Server) UpdateView(ctx context.Context, req *pb.UpdateViewReq) (*pb.SavedView, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	view, err := utils.ParseUpdateViewReq(req)
+	if err != nil {
+		if _, ok := err.(*utils.ReqInvalidArgumentError); ok {
+			return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+		}
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid update view request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	view, err = s.ViewSvc.UpdateView(ctx, scope, view)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbSavedViewToApi(view), nil
+}
+
+func (s *Server) DeleteView(ctx context.Context, req *pb.DeleteViewReq) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	viewId, err := utils.ParseObjectId(req.GetViewId(), "view id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if err = s.ViewSvc.DeleteView(ctx, scope, viewId); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
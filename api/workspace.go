@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+	"todo-grpc/utils"
+)
+
+func (s *Server) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceReq) (*pb.Workspace, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = utils.ValidateCreateWorkspaceReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid create workspace request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	workspace, err := s.WorkspaceSvc.CreateWorkspace(
+		ctx, &models.Workspace{
+			Name:    strings.TrimSpace(req.GetName()),
+			OwnerID: scope.UserID,
+		},
+	)
+	if err != nil {
+		customErr := &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create workspace",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	return utils.ConvertDbWorkspaceToApi(workspace), nil
+}
+
+func (s *Server) GetWorkspace(ctx context.Context, req *pb.GetWorkspaceReq) (*pb.Workspace, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspaceId, err := utils.ParseObjectId(req.GetWorkspaceId(), "workspace id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspace, err := s.WorkspaceSvc.FetchWorkspace(ctx, workspaceId, scope.UserID)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbWorkspaceToApi(workspace), nil
+}
+
+func (s *Server) ListWorkspaces(ctx context.Context, req *pb.ListWorkspacesReq) (*pb.ListWorkspacesRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspaces, err := s.WorkspaceSvc.ListWorkspaces(ctx, scope.UserID)
+	if err != nil {
+		customErr := &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch workspaces",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	apiWorkspaces := make([]*pb.Workspace, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		apiWorkspaces = append(apiWorkspaces, utils.ConvertDbWorkspaceToApi(&workspace))
+	}
+
+	return &pb.ListWorkspacesRes{
+		Workspaces: apiWorkspaces,
+	}, nil
+}
+
+func (s *Server) ListMembers(ctx context.Context, req *pb.ListMembersReq) (*pb.ListMembersRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspaceId, err := utils.ParseObjectId(req.GetWorkspaceId(), "workspace id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	members, err := s.WorkspaceSvc.ListMembers(ctx, workspaceId, scope.UserID)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	apiMembers := make([]*pb.WorkspaceMember, 0, len(members))
+	for _, member := range members {
+		apiMembers = append(apiMembers, utils.ConvertDbWorkspaceMemberToApi(&member))
+	}
+
+	return &pb.ListMembersRes{
+		Members: apiMembers,
+	}, nil
+}
+
+func (s *Server) InviteMember(ctx context.Context, req *pb.InviteMemberReq) (*pb.InviteMemberRes, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = utils.ValidateInviteMemberReq(req)
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid invite member request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	workspaceId, err := utils.ParseObjectId(req.GetWorkspaceId(), "workspace id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+	role, err := utils.ConvertApiWorkspaceRoleToDb(req.GetRole())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid invite member request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	invitation, err := s.WorkspaceSvc.InviteMember(
+		ctx, &models.WorkspaceInvitation{
+			WorkspaceID: workspaceId,
+			Email:       strings.TrimSpace(req.GetEmail()),
+			Role:        role,
+			InvitedBy:   scope.UserID,
+		},
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &pb.InviteMemberRes{
+		InvitationId: invitation.ID.Hex(),
+		ExpiresAt:    timestamppb.New(invitation.ExpireTime.Time()),
+	}, nil
+}
+
+func (s *Server) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationReq) (*pb.WorkspaceMember, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	if strings.TrimSpace(req.GetToken()) == "" {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "token can't be empty",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	member, err := s.WorkspaceSvc.AcceptInvitation(ctx, scope.UserID, strings.TrimSpace(req.GetToken()))
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbWorkspaceMemberToApi(member), nil
+}
+
+func (s *Server) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleReq) (*pb.WorkspaceMember, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspaceId, err := utils.ParseObjectId(req.GetWorkspaceId(), "workspace id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+	userId, err := utils.ParseObjectId(req.GetUserId(), "user id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+	role, err := utils.ConvertApiWorkspaceRoleToDb(req.GetRole())
+	if err != nil {
+		customErr := &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid update member role request",
+			},
+		}
+		return nil, utils.CreateStatusErrorFromError(customErr, s.Logger)
+	}
+
+	member, err := s.WorkspaceSvc.UpdateMemberRole(ctx, workspaceId, scope.UserID, userId, role)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return utils.ConvertDbWorkspaceMemberToApi(member), nil
+}
+
+func (s *Server) RemoveMember(ctx context.Context, req *pb.RemoveMemberReq) (*emptypb.Empty, error) {
+	scope, err := utils.GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	workspaceId, err := utils.ParseObjectId(req.GetWorkspaceId(), "workspace id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+	userId, err := utils.ParseObjectId(req.GetUserId(), "user id")
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	err = s.WorkspaceSvc.RemoveMember(ctx, workspaceId, scope.UserID, userId)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+	err = s.TodoSvc.UnassignTodos(ctx, userId, []primitive.ObjectID{workspaceId})
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, s.Logger)
+	}
+
+	return &emptypb.Empty{}, nil
+}
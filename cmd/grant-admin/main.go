@@ -0,0 +1,69 @@
+// Command grant-admin grants the admin role to the user with the email, or
+// takes it away with -revoke. Admins can't grant the role through the API,
+// so the first ones are made with this command.
+//
+// The change is written to the audit log without an actor, with the reason
+// given by -reason.
+package main
+
+import (
+	"context"
+	"flag"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"strings"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the user")
+	reason := flag.String("reason", "", "why the role is granted or revoked, for the audit log")
+	revoke := flag.Bool("revoke", false, "take the admin role away instead of granting it")
+	flag.Parse()
+	if *email == "" || strings.TrimSpace(*reason) == "" {
+		log.Fatalf("-email and -reason are required")
+	}
+
+	config, err := utils.NewEnvConfig()
+	if err != nil {
+		log.Fatalf("unable to load env config: %v", err)
+	}
+	db, err := utils.ConnectMongoDB(config.GetMongoURI())
+	if err != nil {
+		log.Fatalf("unable to connect database: %v", err)
+	}
+
+	ctx := context.Background()
+	usersC := utils.GetCollection(db, "users")
+	// the collation of the unique email index
+	opts := options.FindOne().
+		SetCollation(&options.Collation{Locale: "en", Strength: 2}).
+		SetProjection(bson.M{"_id": 1})
+	var user models.User
+	if err = usersC.FindOne(ctx, bson.M{"email": utils.NormalizeEmail(*email)}, opts).Decode(&user); err != nil {
+		log.Fatalf("unable to find user %s: %v", *email, err)
+	}
+
+	action, update := models.AuditGrantAdmin, bson.M{"$addToSet": bson.M{"roles": models.RoleAdmin}}
+	if *revoke {
+		action, update = models.AuditRevokeAdmin, bson.M{"$pull": bson.M{"roles": models.RoleAdmin}}
+	}
+	entry := &models.AuditLogEntry{
+		ID:           primitive.NewObjectID(),
+		Action:       action,
+		TargetUserID: user.ID,
+		Reason:       strings.TrimSpace(*reason),
+		CreateTime:   primitive.NewDateTimeFromTime(time.Now()),
+	}
+	if _, err = utils.GetCollection(db, "audit_log").InsertOne(ctx, entry); err != nil {
+		log.Fatalf("unable to write audit log: %v", err)
+	}
+	if _, err = usersC.UpdateOne(ctx, bson.M{"_id": user.ID}, update); err != nil {
+		log.Fatalf("unable to update user %s: %v", user.ID.Hex(), err)
+	}
+	log.Printf("%s user %s", action, user.ID.Hex())
+}
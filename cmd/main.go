@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"github.com/redis/go-redis/v9"
 	"log"
 	"net"
+	"time"
 	"todo-grpc/internal"
 	kafkaQueueProvider "todo-grpc/providers/kafka"
+	"todo-grpc/providers/mail"
 	"todo-grpc/server"
+	"todo-grpc/service"
+	"todo-grpc/service/alerts"
+	"todo-grpc/service/analytics"
 	"todo-grpc/utils"
 )
 
@@ -25,9 +32,54 @@ func main() {
 		log.Fatal()
 	}
 
-	go internal.SubscribeAllPartitions(logger, config)
+	if err = utils.EnableChangeStreamPreImages(context.Background(), db, "todos"); err != nil {
+		logger.Error(err, "unable to enable change stream pre-images, StreamTodo won't report deletions")
+	}
+
+	// analytics are optional, without a store the events aren't consumed
+	analyticsSvc := analytics.NewNoopAnalyticsService(logger)
+	var analyticsConsumer service.AnalyticsService
+	if config.GetClickHouseDsn() != "" {
+		analyticsDb, err := utils.ConnectClickHouse(config.GetClickHouseDsn())
+		if err != nil {
+			logger.Error(err, "enable to connect analytics database")
+			log.Fatal()
+		}
 
-	grpcServer := server.NewServer(db, logger, config, kafkaProvider)
+		analyticsSvc = analytics.NewAnalyticsService(analyticsDb, logger)
+		if err = analyticsSvc.EnsureSchema(context.Background()); err != nil {
+			logger.Error(err, "unable to create analytics tables")
+			log.Fatal()
+		}
+		analyticsConsumer = analyticsSvc
+	} else {
+		logger.Info("CLICKHOUSE_DSN isn't set, analytics are disabled")
+	}
+
+	var redisClient *redis.Client
+	if config.GetRedisUrl() != "" {
+		redisClient, err = utils.ConnectRedis(config.GetRedisUrl())
+		if err != nil {
+			logger.Error(err, "enable to connect redis")
+			log.Fatal()
+		}
+	}
+
+	go internal.SubscribeAllPartitions(
+		logger, config, alerts.NewAlertService(db, logger, config), analyticsConsumer, mail.NewEmailClient(config),
+	)
+
+	grpcServer := server.NewServer(db, analyticsSvc, redisClient, logger, config, kafkaProvider)
+
+	if keySet := config.GetJwtKeySet(); keySet != nil {
+		go keySet.Watch(context.Background(), time.Minute, logger)
+	}
+	httpServer := server.NewHttpServer(config, logger)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil {
+			log.Fatalf("Failed to serve http: %v", err)
+		}
+	}()
 
 	lis, err := net.Listen("tcp", config.GetServerPort())
 	if err != nil {
@@ -0,0 +1,183 @@
+// Command migrate-emails normalises the emails of existing users and resolves
+// users sharing an email, so that the unique email index can be built.
+//
+// It only reports what it would change unless run with -apply. Of users
+// sharing an email the verified one, or else the oldest one, keeps it. The
+// others get a placeholder email under the reserved .invalid domain to log
+// in with, and keep their original one in original_email. A password reset
+// bound to their account is mailed to the original email, so they can set
+// a password, log in with the placeholder and pick a new email with
+// UpdateProfile.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"html"
+	"log"
+	"net/url"
+	"sort"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/providers/mail"
+	"todo-grpc/service/user"
+	"todo-grpc/utils"
+)
+
+// recoveryResetTTL is how long the duplicate users have to take their
+// account back, longer than a requested reset as they don't expect the email
+const recoveryResetTTL = 14 * 24 * time.Hour
+
+func main() {
+	apply := flag.Bool("apply", false, "write the changes instead of only reporting them")
+	flag.Parse()
+
+	config, err := utils.NewEnvConfig()
+	if err != nil {
+		log.Fatalf("unable to load env config: %v", err)
+	}
+	db, err := utils.ConnectMongoDB(config.GetMongoURI())
+	if err != nil {
+		log.Fatalf("unable to connect database: %v", err)
+	}
+
+	ctx := context.Background()
+	emailClient := mail.NewEmailClient(config)
+	usersC := utils.GetCollection(db, "users")
+	groups, err := groupUsersByEmail(ctx, usersC)
+	if err != nil {
+		log.Fatalf("unable to read users: %v", err)
+	}
+
+	normalised, duplicates := 0, 0
+	for email, users := range groups {
+		keeper, others := users[0], users[1:]
+		if keeper.Email != email {
+			normalised++
+			log.Printf("user %s: email %q becomes %q", keeper.ID.Hex(), keeper.Email, email)
+			if *apply {
+				if err = setEmail(ctx, usersC, keeper.ID, bson.M{"email": email}); err != nil {
+					log.Fatalf("unable to update user %s: %v", keeper.ID.Hex(), err)
+				}
+			}
+		}
+
+		for _, other := range others {
+			duplicates++
+			placeholder := fmt.Sprintf("%s@duplicate.invalid", other.ID.Hex())
+			log.Printf(
+				"user %s: email %q is taken by user %s, it becomes %q",
+				other.ID.Hex(), other.Email, keeper.ID.Hex(), placeholder,
+			)
+			if *apply {
+				token, err := utils.GenerateSecretToken()
+				if err != nil {
+					log.Fatalf("unable to create password reset: %v", err)
+				}
+				now := time.Now()
+				reset := &models.PasswordReset{
+					TokenHash:  utils.HashSecretToken(token),
+					SentAt:     primitive.NewDateTimeFromTime(now),
+					ExpireTime: primitive.NewDateTimeFromTime(now.Add(recoveryResetTTL)),
+				}
+				err = setEmail(
+					ctx, usersC, other.ID, bson.M{
+						"email":          placeholder,
+						"original_email": other.Email,
+						"email_verified": false,
+						"password_reset": reset,
+					},
+				)
+				if err != nil {
+					log.Fatalf("unable to update user %s: %v", other.ID.Hex(), err)
+				}
+				// the user isn't a duplicate anymore on a rerun, so failures
+				// are only logged for the email to be sent by hand
+				if err = sendRecoveryEmail(emailClient, config, other, placeholder, token, reset); err != nil {
+					log.Printf("unable to send recovery email to user %s: %v", other.ID.Hex(), err)
+				}
+			}
+		}
+	}
+	log.Printf("%d emails to normalise, %d duplicate users", normalised, duplicates)
+
+	if !*apply {
+		log.Printf("nothing was written, run with -apply to make the changes")
+		return
+	}
+
+	// only the repo of the service is needed to build the indexes
+	userSvc := user.NewUserService(db, utils.NewLogger(), config, nil, nil, nil, nil, nil, nil)
+	if err = userSvc.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("unable to create user indexes: %v", err)
+	}
+	log.Printf("user indexes are up to date")
+}
+
+// groupUsersByEmail groups users by their normalised email. Every group is
+// ordered by who keeps the email: verified users first, then the oldest.
+func groupUsersByEmail(ctx context.Context, usersC *mongo.Collection) (map[string][]models.User, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"name": 1, "email": 1, "email_verified": 1}).
+		SetSort(bson.M{"_id": 1})
+	cursor, err := usersC.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := make(map[string][]models.User)
+	for cursor.Next(ctx) {
+		var u models.User
+		if err = cursor.Decode(&u); err != nil {
+			return nil, err
+		}
+		email := utils.NormalizeEmail(u.Email)
+		groups[email] = append(groups[email], u)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, users := range groups {
+		// stable, so users stay ordered by id within the same verification
+		sort.SliceStable(users, func(i, j int) bool {
+			return users[i].EmailVerified && !users[j].EmailVerified
+		})
+	}
+	return groups, nil
+}
+
+func setEmail(ctx context.Context, usersC *mongo.Collection, userId primitive.ObjectID, set bson.M) error {
+	_, err := usersC.UpdateOne(ctx, bson.M{"_id": userId}, bson.M{"$set": set})
+	return err
+}
+
+// sendRecoveryEmail tells a duplicate user at their original email how to
+// get back into their account.
+func sendRecoveryEmail(
+	emailClient *mail.EmailClient, config utils.EnvConfig, u models.User, placeholder, token string,
+	reset *models.PasswordReset,
+) error {
+	return emailClient.SendEmail(
+		u.Email,
+		fmt.Sprintf(
+			`<p>Hi %s, another account was registered with your email and now keeps it.</p>`+
+				`<p>Your account logs in with %s instead. `+
+				`<a href="%s/reset-password?token=%s">Choose a password</a>, log in with it `+
+				`and change the email in your profile to one of your own.</p>`+
+				`<p>The link expires on %s.</p>`,
+			html.EscapeString(u.Name),
+			html.EscapeString(placeholder),
+			config.GetAppBaseUrl(),
+			url.QueryEscape(token),
+			reset.ExpireTime.Time().Format(time.RFC1123),
+		),
+		"Your account needs a new email",
+	)
+}
@@ -4,21 +4,52 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/robfig/cron"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 	"todo-grpc/models"
 	kafkaQueueProvider "todo-grpc/providers/kafka"
 	"todo-grpc/service"
+	"todo-grpc/utils"
 )
 
-func InitCron(ts service.TodoService, kfp kafkaQueueProvider.Provider) {
+func InitCron(
+	ts service.TodoService,
+	us service.UserService,
+	kfp kafkaQueueProvider.Provider,
+	config utils.EnvConfig,
+	logger *utils.Logger,
+) {
 	c := cron.New()
 	c.AddFunc(
 		"@every 3m", func() {
 			CheckDeadlineIsNear(ts, kfp)
 		},
 	)
+	c.AddFunc(
+		"@every 1m", func() {
+			ReleaseExpiredSnoozes(ts, kfp, logger)
+		},
+	)
+	c.AddFunc(
+		"@daily", func() {
+			CompactSyncTombstones(ts, config, logger)
+		},
+	)
+	c.AddFunc(
+		"@hourly", func() {
+			NotifyPremiumEnding(us, kfp, config, logger)
+		},
+	)
 	c.Start()
 }
 
+func CompactSyncTombstones(ts service.TodoService, config utils.EnvConfig, logger *utils.Logger) {
+	retention := time.Duration(config.GetSyncTombstoneRetentionDays()) * 24 * time.Hour
+	if _, err := ts.CompactTombstones(context.Background(), retention); err != nil {
+		logger.Error(err, "unable to compact sync tombstones")
+	}
+}
+
 func CheckDeadlineIsNear(ts service.TodoService, kfp kafkaQueueProvider.Provider) {
 	todos, err := ts.FetchTodosWithNearbyDeadline(context.Background())
 	if err != nil {
@@ -33,3 +64,86 @@ func CheckDeadlineIsNear(ts service.TodoService, kfp kafkaQueueProvider.Provider
 		kfp.Publish(models.TopicDeadlineNearby, data)
 	}
 }
+
+func ReleaseExpiredSnoozes(ts service.TodoService, kfp kafkaQueueProvider.Provider, logger *utils.Logger) {
+	ctx := context.Background()
+	// todos released before a failure are announced all the same
+	todos, err := ts.ReleaseExpiredSnoozes(ctx)
+	if err != nil {
+		logger.Error(err, "unable to release expired snoozes")
+	}
+	if len(todos) == 0 {
+		return
+	}
+
+	messages := make([][]byte, 0, len(todos))
+	todoIds := make([]primitive.ObjectID, 0, len(todos))
+	for _, todo := range todos {
+		data, err := json.Marshal(
+			&models.TodoUnsnoozedEvent{
+				TodoID:      todo.ID,
+				TodoName:    todo.Name,
+				UserID:      todo.UserID,
+				AssigneeID:  todo.AssigneeID,
+				WorkspaceID: todo.WorkspaceID,
+				HideUntil:   todo.HideUntil.Time(),
+			},
+		)
+		if err != nil {
+			logger.Error(err, "unable to marshal todo unsnoozed event")
+			continue
+		}
+		messages = append(messages, data)
+		todoIds = append(todoIds, todo.ID)
+	}
+
+	if err = kfp.PublishConfirmed(ctx, models.TopicTodoUnsnoozed, messages...); err != nil {
+		logger.Error(err, "unable to announce released snoozes, they are retried on the next run")
+		if err = ts.RestoreSnoozes(ctx, todoIds); err != nil {
+			logger.Error(err, "unable to restore snoozed todos, their end of snooze isn't announced")
+		}
+	}
+}
+
+// NotifyPremiumEnding announces the premium plans ending within the notice,
+// every instance may run it as each user is claimed once per expire time.
+func NotifyPremiumEnding(
+	us service.UserService, kfp kafkaQueueProvider.Provider, config utils.EnvConfig, logger *utils.Logger,
+) {
+	ctx := context.Background()
+	notice := time.Duration(config.GetPremiumEndingNoticeDays()) * 24 * time.Hour
+	// users claimed before a failure are announced all the same
+	users, err := us.ClaimPremiumEndingUsers(ctx, notice)
+	if err != nil {
+		logger.Error(err, "unable to claim users whose premium ends")
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	messages := make([][]byte, 0, len(users))
+	userIds := make([]primitive.ObjectID, 0, len(users))
+	for _, user := range users {
+		data, err := json.Marshal(
+			&models.PremiumEndingEvent{
+				UserID:     user.ID,
+				Name:       user.Name,
+				Email:      user.Email,
+				ExpireTime: user.PremiumExpireTime.Time(),
+			},
+		)
+		if err != nil {
+			logger.Error(err, "unable to marshal premium ending event")
+			continue
+		}
+		messages = append(messages, data)
+		userIds = append(userIds, user.ID)
+	}
+
+	if err = kfp.PublishConfirmed(ctx, models.TopicPremiumEnding, messages...); err != nil {
+		logger.Error(err, "unable to announce ending premium plans, they are retried on the next run")
+		if err = us.ReleasePremiumEndingClaims(ctx, userIds); err != nil {
+			logger.Error(err, "unable to release premium ending users, they aren't notified")
+		}
+	}
+}
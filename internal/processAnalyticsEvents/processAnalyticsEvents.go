@@ -0,0 +1,142 @@
+package processAnalyticsEvents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const (
+	defaultPartitions        = 1
+	defaultReplicationFactor = 1
+
+	// a batch is written once it is full or its first event waited long enough
+	batchSize     = 500
+	flushInterval = 5 * time.Second
+
+	maxRetryBackoff = 30 * time.Second
+)
+
+// messageReader is the part of kafka.Reader the consumer needs
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+func SubscribeAnalyticsEventsPartitions(
+	logger *utils.Logger, config utils.EnvConfig, controllerConn *kafka.Conn, analyticsSvc service.AnalyticsService,
+) {
+	err := controllerConn.CreateTopics(
+		kafka.TopicConfig{
+			Topic:             string(models.TopicAnalyticsEvents),
+			NumPartitions:     defaultPartitions,
+			ReplicationFactor: defaultReplicationFactor,
+		},
+	)
+	if err != nil {
+		logger.Error(err, "SubscribeAllPartitions: error creating topic %v")
+		return
+	}
+
+	go SubscribeProcessAnalyticsEvents(config, logger, analyticsSvc)
+}
+
+// SubscribeProcessAnalyticsEvents writes the domain events into the analytics
+// store in batches. Offsets are only committed once their batch is stored, so
+// every event is stored at least once.
+func SubscribeProcessAnalyticsEvents(
+	env utils.EnvConfig, logger *utils.Logger, analyticsSvc service.AnalyticsService,
+) {
+	kafkaHost := env.GetKafkaHost()
+	groupID := "processed-analytics-events"
+
+	r := kafka.NewReader(
+		kafka.ReaderConfig{
+			Brokers:     []string{kafkaHost},
+			Topic:       string(models.TopicAnalyticsEvents),
+			GroupID:     groupID,
+			StartOffset: kafka.FirstOffset,
+			Logger:      logger,
+			ErrorLogger: logger,
+		},
+	)
+	consumeAnalyticsEvents(r, logger, analyticsSvc, batchSize, flushInterval)
+}
+
+// consumeAnalyticsEvents reads events until the reader is closed, flushing
+// them once batchSize are read or the first of them waited flushInterval.
+func consumeAnalyticsEvents(
+	r messageReader, logger *utils.Logger, analyticsSvc service.AnalyticsService,
+	batchSize int, flushInterval time.Duration,
+) {
+	batch := make([]kafka.Message, 0, batchSize)
+	var flushTime time.Time
+	for {
+		ctx := context.Background()
+		cancel := func() {}
+		if len(batch) > 0 {
+			ctx, cancel = context.WithDeadline(ctx, flushTime)
+		}
+		m, err := r.FetchMessage(ctx)
+		cancel()
+
+		switch {
+		case err == nil:
+			if len(batch) == 0 {
+				flushTime = time.Now().Add(flushInterval)
+			}
+			batch = append(batch, m)
+			if len(batch) < batchSize {
+				continue
+			}
+		case errors.Is(err, context.DeadlineExceeded):
+		case errors.Is(err, io.EOF):
+			// the uncommitted batch is delivered again
+			return
+		default:
+			logger.Error(err, "error reading message %v\n")
+			continue
+		}
+
+		flushAnalyticsEvents(r, logger, analyticsSvc, batch)
+		batch = batch[:0]
+	}
+}
+
+// flushAnalyticsEvents stores the events of the batch, retrying until the
+// store accepts them, and commits the batch afterwards.
+func flushAnalyticsEvents(
+	r messageReader, logger *utils.Logger, analyticsSvc service.AnalyticsService, batch []kafka.Message,
+) {
+	events := make([]models.AnalyticsEvent, 0, len(batch))
+	for _, m := range batch {
+		var event models.AnalyticsEvent
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			logger.Error(err, "error unmarshalling message %v\n")
+			continue
+		}
+		events = append(events, event)
+	}
+
+	backoff := time.Second
+	for {
+		err := analyticsSvc.RecordEvents(context.Background(), events)
+		if err == nil {
+			break
+		}
+		logger.Error(err, "error storing analytics events, retrying", "events", len(events))
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+
+	if err := r.CommitMessages(context.Background(), batch...); err != nil {
+		// the batch gets redelivered, the analytics store drops the copies
+		logger.Error(err, "error committing analytics events")
+	}
+}
@@ -0,0 +1,205 @@
+package processAnalyticsEvents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"sync"
+	"testing"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+// recorder keeps what the fake reader and sink were asked to do, in order
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// fakeReader hands out the messages sent on its channel and reports io.EOF
+// once the channel is closed, like a closed kafka.Reader.
+type fakeReader struct {
+	messages chan kafka.Message
+	recorder *recorder
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case m, ok := <-r.messages:
+		if !ok {
+			return kafka.Message{}, io.EOF
+		}
+		return m, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.recorder.record("commit %d-%d", msgs[0].Offset, msgs[len(msgs)-1].Offset)
+	return nil
+}
+
+// fakeSink is an analytics store whose first failures writes fail
+type fakeSink struct {
+	recorder *recorder
+	failures int
+}
+
+func (s *fakeSink) EnsureSchema(ctx context.Context) error {
+	return nil
+}
+
+func (s *fakeSink) RecordEvents(ctx context.Context, events []models.AnalyticsEvent) error {
+	if s.failures > 0 {
+		s.failures--
+		s.recorder.record("store failed")
+		return errors.New("store is down")
+	}
+	s.recorder.record("store %s-%s", events[0].EventID, events[len(events)-1].EventID)
+	return nil
+}
+
+func (s *fakeSink) DailyActiveUsers(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) ([]models.DailyActiveUsers, error) {
+	return nil, nil
+}
+
+func (s *fakeSink) CompletionFunnel(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) (*models.CompletionFunnel, error) {
+	return nil, nil
+}
+
+func eventMessage(t *testing.T, offset int64) kafka.Message {
+	t.Helper()
+	value, err := json.Marshal(&models.AnalyticsEvent{
+		EventID: fmt.Sprint(offset),
+		Type:    models.AnalyticsEventTodoCreated,
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal event: %v", err)
+	}
+	return kafka.Message{Offset: offset, Value: value}
+}
+
+// startConsumer consumes from a fake reader into a fake sink until the
+// returned stop function closes the reader.
+func startConsumer(
+	t *testing.T, sink *fakeSink, batchSize int, flushInterval time.Duration,
+) (*fakeReader, func()) {
+	t.Helper()
+	reader := &fakeReader{messages: make(chan kafka.Message), recorder: sink.recorder}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumeAnalyticsEvents(reader, utils.NewLogger(), sink, batchSize, flushInterval)
+	}()
+
+	stop := func() {
+		close(reader.messages)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("consumer didn't stop after the reader was closed")
+		}
+	}
+	return reader, stop
+}
+
+func waitForCalls(t *testing.T, recorder *recorder, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(recorder.snapshot()) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("got calls %v, want at least %d", recorder.snapshot(), count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func assertCalls(t *testing.T, recorder *recorder, want ...string) {
+	t.Helper()
+	got := recorder.snapshot()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got calls %v, want %v", got, want)
+	}
+}
+
+func TestConsumeAnalyticsEventsFlushesFullBatches(t *testing.T) {
+	sink := &fakeSink{recorder: &recorder{}}
+	reader, stop := startConsumer(t, sink, 3, time.Hour)
+
+	for offset := int64(0); offset < 7; offset++ {
+		reader.messages <- eventMessage(t, offset)
+	}
+	// the last event is still waiting for its batch to fill up
+	stop()
+
+	assertCalls(t, sink.recorder, "store 0-2", "commit 0-2", "store 3-5", "commit 3-5")
+}
+
+func TestConsumeAnalyticsEventsFlushesOnDeadline(t *testing.T) {
+	sink := &fakeSink{recorder: &recorder{}}
+	reader, stop := startConsumer(t, sink, 100, 50*time.Millisecond)
+
+	start := time.Now()
+	reader.messages <- eventMessage(t, 0)
+	reader.messages <- eventMessage(t, 1)
+	waitForCalls(t, sink.recorder, 2)
+	if waited := time.Since(start); waited < 50*time.Millisecond {
+		t.Errorf("batch was flushed after %s, before its deadline", waited)
+	}
+
+	// the next batch has a deadline of its own
+	reader.messages <- eventMessage(t, 2)
+	waitForCalls(t, sink.recorder, 4)
+	stop()
+
+	assertCalls(t, sink.recorder, "store 0-1", "commit 0-1", "store 2-2", "commit 2-2")
+}
+
+func TestConsumeAnalyticsEventsCommitsAfterStoring(t *testing.T) {
+	sink := &fakeSink{recorder: &recorder{}, failures: 1}
+	reader, stop := startConsumer(t, sink, 2, time.Hour)
+
+	reader.messages <- eventMessage(t, 0)
+	reader.messages <- eventMessage(t, 1)
+	// the failed write is retried after a second
+	waitForCalls(t, sink.recorder, 3)
+	stop()
+
+	assertCalls(t, sink.recorder, "store failed", "store 0-1", "commit 0-1")
+}
+
+func TestConsumeAnalyticsEventsCommitsUnreadableEvents(t *testing.T) {
+	sink := &fakeSink{recorder: &recorder{}}
+	reader, stop := startConsumer(t, sink, 3, time.Hour)
+
+	reader.messages <- eventMessage(t, 0)
+	reader.messages <- kafka.Message{Offset: 1, Value: []byte("not json")}
+	reader.messages <- eventMessage(t, 2)
+	waitForCalls(t, sink.recorder, 2)
+	stop()
+
+	// the unreadable event is skipped, but committed with its batch so it
+	// doesn't block the partition
+	assertCalls(t, sink.recorder, "store 0-2", "commit 0-2")
+}
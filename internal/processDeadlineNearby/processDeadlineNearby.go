@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"todo-grpc/models"
+	"todo-grpc/service"
 	"todo-grpc/utils"
 )
 
@@ -14,7 +16,9 @@ const (
 	defaultReplicationFactor = 1
 )
 
-func SubscribeDeadlineNearbyPartitions(logger *utils.Logger, config utils.EnvConfig, controllerConn *kafka.Conn) {
+func SubscribeDeadlineNearbyPartitions(
+	logger *utils.Logger, config utils.EnvConfig, controllerConn *kafka.Conn, alertSvc service.AlertService,
+) {
 	err := controllerConn.CreateTopics(
 		kafka.TopicConfig{
 			Topic:             string(models.TopicDeadlineNearby),
@@ -27,10 +31,10 @@ func SubscribeDeadlineNearbyPartitions(logger *utils.Logger, config utils.EnvCon
 		return
 	}
 
-	go SubscribeProcessPaymentStatus(config, logger)
+	go SubscribeProcessPaymentStatus(config, logger, alertSvc)
 }
 
-func SubscribeProcessPaymentStatus(env utils.EnvConfig, logger *utils.Logger) {
+func SubscribeProcessPaymentStatus(env utils.EnvConfig, logger *utils.Logger, alertSvc service.AlertService) {
 	kafkaHost := env.GetKafkaHost()
 	groupID := fmt.Sprintf("processed-dealine-nearby")
 
@@ -62,11 +66,30 @@ func SubscribeProcessPaymentStatus(env utils.EnvConfig, logger *utils.Logger) {
 		// get ctx with request id same as that of original request
 		ctx := context.WithValue(context.Background(), utils.RequestIdKey, kafkaMsg.ID.Hex())
 
+		// both the owner and the assignee of the todo get alerted
+		recipients := map[string]primitive.ObjectID{
+			models.AlertTypeOwner: kafkaMsg.UserID,
+		}
+		if !kafkaMsg.AssigneeID.IsZero() && kafkaMsg.AssigneeID != kafkaMsg.UserID {
+			recipients[models.AlertTypeAssignee] = kafkaMsg.AssigneeID
+		}
+		for alertType, userId := range recipients {
+			_, err = alertSvc.CreateAlertOnce(
+				ctx, &models.Alert{
+					KafkaTopic: models.TopicDeadlineNearby,
+					Type:       alertType,
+					UserID:     userId,
+					TodoID:     kafkaMsg.ID,
+				},
+			)
+			if err != nil {
+				logger.Error(err, "error creating deadline nearby alert")
+			}
+		}
+
 		err = r.CommitMessages(ctx, m)
 		if err != nil {
 			fmt.Println("Failed while committing message", err)
 		}
-
-		fmt.Println("New message:", kafkaMsg)
 	}
 }
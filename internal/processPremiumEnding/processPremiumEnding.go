@@ -0,0 +1,112 @@
+package processPremiumEnding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"html"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/providers/mail"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const (
+	defaultPartitions        = 1
+	defaultReplicationFactor = 1
+)
+
+func SubscribePremiumEndingPartitions(
+	logger *utils.Logger,
+	config utils.EnvConfig,
+	controllerConn *kafka.Conn,
+	alertSvc service.AlertService,
+	emailClient *mail.EmailClient,
+) {
+	err := controllerConn.CreateTopics(
+		kafka.TopicConfig{
+			Topic:             string(models.TopicPremiumEnding),
+			NumPartitions:     defaultPartitions,
+			ReplicationFactor: defaultReplicationFactor,
+		},
+	)
+	if err != nil {
+		logger.Error(err, "SubscribeAllPartitions: error creating topic %v")
+		return
+	}
+
+	go SubscribeProcessPremiumEnding(config, logger, alertSvc, emailClient)
+}
+
+// SubscribeProcessPremiumEnding alerts and emails users whose premium plan
+// ends soon.
+func SubscribeProcessPremiumEnding(
+	env utils.EnvConfig, logger *utils.Logger, alertSvc service.AlertService, emailClient *mail.EmailClient,
+) {
+	kafkaHost := env.GetKafkaHost()
+	groupID := "processed-premium-ending"
+
+	r := kafka.NewReader(
+		kafka.ReaderConfig{
+			Brokers:     []string{kafkaHost},
+			Topic:       string(models.TopicPremiumEnding),
+			GroupID:     groupID,
+			Logger:      logger,
+			ErrorLogger: logger,
+		},
+	)
+	_ = r.SetOffset(kafka.LastOffset)
+
+	for {
+		m, err := r.ReadMessage(context.Background())
+		if err != nil {
+			logger.Error(err, "error reading message %v\n")
+			continue
+		}
+
+		var kafkaMsg models.PremiumEndingEvent
+		err = json.Unmarshal(m.Value, &kafkaMsg)
+		if err != nil {
+			logger.Error(err, "error unmarshalling message %v\n")
+			continue
+		}
+
+		ctx := context.WithValue(context.Background(), utils.RequestIdKey, kafkaMsg.UserID.Hex())
+
+		_, err = alertSvc.CreateAlert(
+			ctx, &models.Alert{
+				KafkaTopic: models.TopicPremiumEnding,
+				Type:       models.AlertTypeAccount,
+				UserID:     kafkaMsg.UserID,
+			},
+		)
+		if err != nil {
+			logger.Error(err, "error creating premium ending alert")
+		}
+		if err = sendPremiumEndingEmail(env, emailClient, &kafkaMsg); err != nil {
+			logger.Error(err, "error sending premium ending email")
+		}
+
+		err = r.CommitMessages(ctx, m)
+		if err != nil {
+			logger.Error(err, "error committing premium ending message")
+		}
+	}
+}
+
+func sendPremiumEndingEmail(env utils.EnvConfig, emailClient *mail.EmailClient, event *models.PremiumEndingEvent) error {
+	return emailClient.SendEmail(
+		event.Email,
+		fmt.Sprintf(
+			`<p>Hi %s, your premium plan ends on %s.</p>`+
+				`<p>After that the quotas of the free plan apply. `+
+				`<a href="%s/billing">Renew your plan</a> to keep premium.</p>`,
+			html.EscapeString(event.Name),
+			event.ExpireTime.UTC().Format(time.RFC1123),
+			env.GetAppBaseUrl(),
+		),
+		"Your premium plan ends soon",
+	)
+}
@@ -0,0 +1,86 @@
+package processTodoAssigned
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const (
+	defaultPartitions        = 1
+	defaultReplicationFactor = 1
+)
+
+func SubscribeTodoAssignedPartitions(
+	logger *utils.Logger, config utils.EnvConfig, controllerConn *kafka.Conn, alertSvc service.AlertService,
+) {
+	err := controllerConn.CreateTopics(
+		kafka.TopicConfig{
+			Topic:             string(models.TopicTodoAssigned),
+			NumPartitions:     defaultPartitions,
+			ReplicationFactor: defaultReplicationFactor,
+		},
+	)
+	if err != nil {
+		logger.Error(err, "SubscribeAllPartitions: error creating topic %v")
+		return
+	}
+
+	go SubscribeProcessTodoAssigned(config, logger, alertSvc)
+}
+
+// SubscribeProcessTodoAssigned turns every assignment into an alert for the
+// new assignee.
+func SubscribeProcessTodoAssigned(env utils.EnvConfig, logger *utils.Logger, alertSvc service.AlertService) {
+	kafkaHost := env.GetKafkaHost()
+	groupID := fmt.Sprintf("processed-todo-assigned")
+
+	r := kafka.NewReader(
+		kafka.ReaderConfig{
+			Brokers:     []string{kafkaHost},
+			Topic:       string(models.TopicTodoAssigned),
+			GroupID:     groupID,
+			Logger:      logger,
+			ErrorLogger: logger,
+		},
+	)
+	_ = r.SetOffset(kafka.LastOffset)
+
+	for {
+		m, err := r.ReadMessage(context.Background())
+		if err != nil {
+			logger.Error(err, "error reading message %v\n")
+			continue
+		}
+
+		var kafkaMsg models.TodoAssignedEvent
+		err = json.Unmarshal(m.Value, &kafkaMsg)
+		if err != nil {
+			logger.Error(err, "error unmarshalling message %v\n")
+			continue
+		}
+
+		ctx := context.WithValue(context.Background(), utils.RequestIdKey, kafkaMsg.TodoID.Hex())
+
+		_, err = alertSvc.CreateAlert(
+			ctx, &models.Alert{
+				KafkaTopic: models.TopicTodoAssigned,
+				Type:       models.AlertTypeAssignee,
+				UserID:     kafkaMsg.AssigneeID,
+				TodoID:     kafkaMsg.TodoID,
+			},
+		)
+		if err != nil {
+			logger.Error(err, "error creating todo assigned alert")
+		}
+
+		err = r.CommitMessages(ctx, m)
+		if err != nil {
+			logger.Error(err, "error committing todo assigned message")
+		}
+	}
+}
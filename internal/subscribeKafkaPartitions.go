@@ -4,11 +4,22 @@ import (
 	"github.com/segmentio/kafka-go"
 	"net"
 	"strconv"
+	"todo-grpc/internal/processAnalyticsEvents"
 	"todo-grpc/internal/processDeadlineNearby"
+	"todo-grpc/internal/processPremiumEnding"
+	"todo-grpc/internal/processTodoAssigned"
+	"todo-grpc/providers/mail"
+	"todo-grpc/service"
 	"todo-grpc/utils"
 )
 
-func SubscribeAllPartitions(logger *utils.Logger, config utils.EnvConfig) {
+func SubscribeAllPartitions(
+	logger *utils.Logger,
+	config utils.EnvConfig,
+	alertSvc service.AlertService,
+	analyticsSvc service.AnalyticsService,
+	emailClient *mail.EmailClient,
+) {
 	kafkaHost := config.GetKafkaHost()
 
 	conn, err := kafka.Dial("tcp", kafkaHost)
@@ -35,5 +46,11 @@ func SubscribeAllPartitions(logger *utils.Logger, config utils.EnvConfig) {
 		panic(err.Error())
 	}
 
-	processDeadlineNearby.SubscribeDeadlineNearbyPartitions(logger, config, controllerConn)
+	processDeadlineNearby.SubscribeDeadlineNearbyPartitions(logger, config, controllerConn, alertSvc)
+	processTodoAssigned.SubscribeTodoAssignedPartitions(logger, config, controllerConn, alertSvc)
+	processPremiumEnding.SubscribePremiumEndingPartitions(logger, config, controllerConn, alertSvc, emailClient)
+	// analyticsSvc is nil when analytics are disabled
+	if analyticsSvc != nil {
+		processAnalyticsEvents.SubscribeAnalyticsEventsPartitions(logger, config, controllerConn, analyticsSvc)
+	}
 }
@@ -2,22 +2,84 @@ package middleware
 
 import (
 	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"strconv"
+	"strings"
 	"todo-grpc/api"
+	"todo-grpc/models"
 	"todo-grpc/utils"
 )
 
 var exemptedMethods = map[string]bool{
 	"/pb.UserService/Register": true,
 	"/pb.UserService/Login":    true,
+	// the access token may have expired already when it is refreshed
+	"/pb.UserService/RefreshToken": true,
+	// the link of the verification email may be opened without a session
+	"/pb.UserService/VerifyEmail": true,
+	// users who forgot their password have no session
+	"/pb.UserService/RequestPasswordReset": true,
+	"/pb.UserService/ResetPassword":        true,
+	// the challenge token of the login stands in for the session
+	"/pb.UserService/Verify2FA": true,
+	// logins with an OpenID Connect provider
+	"/pb.UserService/ListOidcProviders": true,
+	"/pb.UserService/StartOidcLogin":    true,
+	"/pb.UserService/CompleteOidcLogin": true,
 }
 
 var streamAllowedMethods = map[string]bool{
 	"/pb.TodoService/StreamTodo": true,
 }
 
+// accessTokenScopes is the scope a personal access token needs for each
+// method, methods missing here can only be called with a session. That keeps
+// tokens away from managing the account and other tokens.
+var accessTokenScopes = map[string]string{
+	"/pb.TodoService/GetTodo":           models.ScopeTodosRead,
+	"/pb.TodoService/ListTodo":          models.ScopeTodosRead,
+	"/pb.TodoService/StreamTodo":        models.ScopeTodosRead,
+	"/pb.TodoService/ListAssignedTodos": models.ScopeTodosRead,
+	"/pb.TodoService/SyncTodos":         models.ScopeTodosRead,
+	"/pb.TodoService/GetStats":          models.ScopeTodosRead,
+	"/pb.TodoService/GetAgenda":         models.ScopeTodosRead,
+	"/pb.TodoService/GetBoard":          models.ScopeTodosRead,
+	"/pb.TodoService/CreateTodo":        models.ScopeTodosWrite,
+	"/pb.TodoService/UpdateTodo":        models.ScopeTodosWrite,
+	"/pb.TodoService/DeleteTodo":        models.ScopeTodosWrite,
+	"/pb.TodoService/PushTodoChanges":   models.ScopeTodosWrite,
+	"/pb.TodoService/MoveCard":          models.ScopeTodosWrite,
+	"/pb.TodoService/SnoozeTodo":        models.ScopeTodosWrite,
+
+	"/pb.ViewService/ListViews":  models.ScopeViewsRead,
+	"/pb.ViewService/CreateView": models.ScopeViewsWrite,
+	"/pb.ViewService/UpdateView": models.ScopeViewsWrite,
+	"/pb.ViewService/DeleteView": models.ScopeViewsWrite,
+
+	"/pb.WorkspaceService/GetWorkspace":     models.ScopeWorkspacesRead,
+	"/pb.WorkspaceService/ListWorkspaces":   models.ScopeWorkspacesRead,
+	"/pb.WorkspaceService/ListMembers":      models.ScopeWorkspacesRead,
+	"/pb.WorkspaceService/CreateWorkspace":  models.ScopeWorkspacesWrite,
+	"/pb.WorkspaceService/InviteMember":     models.ScopeWorkspacesWrite,
+	"/pb.WorkspaceService/AcceptInvitation": models.ScopeWorkspacesWrite,
+	"/pb.WorkspaceService/UpdateMemberRole": models.ScopeWorkspacesWrite,
+	"/pb.WorkspaceService/RemoveMember":     models.ScopeWorkspacesWrite,
+}
+
+// adminMethodPrefix is the prefix of the methods only admins may call, with
+// their own session
+const adminMethodPrefix = "/pb.AdminService/"
+
+// impersonatedUserMethods are the methods of the user service admins may
+// call as the user they impersonate, the others manage the account and its
+// credentials. Admins can't call the admin service while impersonating.
+var impersonatedUserMethods = map[string]bool{
+	"/pb.UserService/GetProfile": true,
+}
+
 func AuthMiddleware(
 	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 ) (interface{}, error) {
@@ -33,13 +95,11 @@ func AuthMiddleware(
 			return nil, grpc.Errorf(codes.Internal, "server not found")
 		}
 
-		userClaims, err := utils.ValidateJwtToken(md, server.Config)
+		md, err := authenticate(ctx, md, server, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
 
-		md.Append(string(utils.AuthedUserIdHex), userClaims.UserID)
-
 		newCtx = metadata.NewIncomingContext(newCtx, md)
 	}
 	return handler(newCtx, req)
@@ -60,19 +120,139 @@ func AuthStreamInterceptor(
 			return grpc.Errorf(codes.Internal, "server not found")
 		}
 
-		userClaims, err := utils.ValidateJwtToken(md, server.Config)
+		md, err := authenticate(newCtx, md, server, info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		md.Append(string(utils.AuthedUserIdHex), userClaims.UserID)
-
 		newCtx = metadata.NewIncomingContext(newCtx, md)
 	}
 
 	return handler(srv, &wrappedServerStream{stream, newCtx})
 }
 
+// authenticate validates the bearer token, a JWT or a personal access token,
+// and, when the caller selected an active workspace through the workspace
+// header, checks that they are a member of it. The resolved ids are set on a
+// copy of the metadata so that clients can't smuggle them in themselves.
+func authenticate(ctx context.Context, md metadata.MD, server *api.Server, method string) (metadata.MD, error) {
+	userIdHex, impersonatorIdHex, authTime, err := authenticateBearer(ctx, md, server, method)
+	if err != nil {
+		return nil, err
+	}
+	userId, err := primitive.ObjectIDFromHex(userIdHex)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	if impersonatorIdHex != "" {
+		if err = authorizeImpersonation(ctx, server, method, userId, impersonatorIdHex); err != nil {
+			return nil, err
+		}
+	} else if strings.HasPrefix(method, adminMethodPrefix) {
+		if err = server.AdminSvc.CheckAdmin(ctx, userId); err != nil {
+			return nil, utils.CreateStatusErrorFromError(err, server.Logger)
+		}
+	}
+
+	md = md.Copy()
+	md.Set(string(utils.AuthedUserIdHex), userIdHex)
+	md.Delete(string(utils.AuthedWorkspaceIdHex))
+	md.Delete(string(utils.AuthedImpersonatorIdHex))
+	md.Delete(string(utils.AuthedAuthTime))
+	if impersonatorIdHex != "" {
+		md.Set(string(utils.AuthedImpersonatorIdHex), impersonatorIdHex)
+	}
+	if authTime != 0 {
+		md.Set(string(utils.AuthedAuthTime), strconv.FormatInt(authTime, 10))
+	}
+
+	workspaceIds := md.Get(utils.WorkspaceKey)
+	if len(workspaceIds) == 0 || workspaceIds[0] == "" {
+		return md, nil
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(workspaceIds[0])
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid workspace id")
+	}
+
+	if _, err = server.WorkspaceSvc.FetchMember(ctx, workspaceId, userId); err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, server.Logger)
+	}
+	md.Set(string(utils.AuthedWorkspaceIdHex), workspaceId.Hex())
+
+	return md, nil
+}
+
+// authenticateBearer returns the id of the user of the bearer token, of the
+// admin impersonating them, if any, and when the user authenticated, zero
+// unless the token belongs to a new session. Personal access tokens also need
+// the scope of the method and are rejected once their user is disabled.
+func authenticateBearer(
+	ctx context.Context, md metadata.MD, server *api.Server, method string,
+) (string, string, int64, error) {
+	token, err := utils.GetBearerToken(md)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if !utils.IsAccessToken(token) {
+		userClaims, err := utils.ValidateJwtToken(ctx, md, server.Config, server.RevocationSvc)
+		if err != nil {
+			return "", "", 0, err
+		}
+		return userClaims.UserID, userClaims.ImpersonatorID, userClaims.AuthTime, nil
+	}
+
+	accessToken, err := server.AccessTokenSvc.AuthenticateAccessToken(ctx, token)
+	if err != nil {
+		return "", "", 0, utils.CreateStatusErrorFromError(err, server.Logger)
+	}
+	scope, ok := accessTokenScopes[method]
+	if !ok {
+		return "", "", 0, grpc.Errorf(codes.PermissionDenied, "method can't be called with an access token")
+	}
+	if !accessToken.HasScope(scope) {
+		return "", "", 0, grpc.Errorf(codes.PermissionDenied, "access token lacks the %s scope", scope)
+	}
+	disabled, err := server.RevocationSvc.IsUserDisabled(ctx, accessToken.UserID)
+	if err != nil {
+		return "", "", 0, grpc.Errorf(codes.Unavailable, "unable to check token")
+	}
+	if disabled {
+		return "", "", 0, grpc.Errorf(codes.Unauthenticated, "token is revoked")
+	}
+	return accessToken.UserID.Hex(), "", 0, nil
+}
+
+// authorizeImpersonation lets an admin impersonating the user call the
+// method as long as they are still an admin, every call is audited.
+func authorizeImpersonation(
+	ctx context.Context, server *api.Server, method string, userId primitive.ObjectID, impersonatorIdHex string,
+) error {
+	if strings.HasPrefix(method, adminMethodPrefix) ||
+		(strings.HasPrefix(method, "/pb.UserService/") && !impersonatedUserMethods[method]) {
+		return grpc.Errorf(codes.PermissionDenied, "method can't be called while impersonating")
+	}
+
+	impersonatorId, err := primitive.ObjectIDFromHex(impersonatorIdHex)
+	if err != nil {
+		return grpc.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	if err = server.AdminSvc.CheckAdmin(ctx, impersonatorId); err != nil {
+		return utils.CreateStatusErrorFromError(err, server.Logger)
+	}
+
+	actor := &models.AuditActor{
+		UserID:   impersonatorId,
+		ClientIp: utils.GetClientIpFromContext(ctx),
+	}
+	if err = server.AdminSvc.RecordImpersonatedCall(ctx, actor, userId, method); err != nil {
+		return utils.CreateStatusErrorFromError(err, server.Logger)
+	}
+	return nil
+}
+
 type wrappedServerStream struct {
 	grpc.ServerStream
 	ctx context.Context
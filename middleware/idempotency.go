@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"todo-grpc/api"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotentMethods lists the mutating methods that honour the idempotency
+// key header. Register isn't part of it as keys are scoped to the authed user.
+// Logout, ChangePassword and DeleteAccount are left out too: they revoke the
+// session a retry would come from, and their responses may hold fresh tokens
+// that shouldn't sit in the cache.
+var idempotentMethods = map[string]bool{
+	"/pb.UserService/UpdateProfile":         true,
+	"/pb.UserService/CreateAccessToken":     true,
+	"/pb.UserService/RevokeAccessToken":     true,
+	"/pb.TodoService/CreateTodo":            true,
+	"/pb.TodoService/UpdateTodo":            true,
+	"/pb.TodoService/DeleteTodo":            true,
+	"/pb.TodoService/PushTodoChanges":       true,
+	"/pb.TodoService/MoveCard":              true,
+	"/pb.TodoService/SnoozeTodo":            true,
+	"/pb.WorkspaceService/CreateWorkspace":  true,
+	"/pb.WorkspaceService/InviteMember":     true,
+	"/pb.WorkspaceService/AcceptInvitation": true,
+	"/pb.WorkspaceService/UpdateMemberRole": true,
+	"/pb.WorkspaceService/RemoveMember":     true,
+	"/pb.ViewService/CreateView":            true,
+	"/pb.ViewService/UpdateView":            true,
+	"/pb.ViewService/DeleteView":            true,
+	"/pb.AdminService/DisableUser":          true,
+	"/pb.AdminService/EnableUser":           true,
+	"/pb.AdminService/ForceLogout":          true,
+	"/pb.AdminService/SetPremium":           true,
+	"/pb.AdminService/Impersonate":          true,
+}
+
+// IdempotencyMiddleware answers retries of a mutating request carrying the
+// same idempotency key with the response of the first request instead of
+// running it again. It has to run after AuthMiddleware.
+func IdempotencyMiddleware(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !idempotentMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(utils.IdempotencyKey)
+	if len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+	if len(keys[0]) > maxIdempotencyKeyLength {
+		return nil, grpc.Errorf(codes.InvalidArgument, "idempotency key is too long")
+	}
+
+	server, ok := info.Server.(*api.Server)
+	if !ok {
+		return nil, grpc.Errorf(codes.Internal, "server not found")
+	}
+	userId, err := primitive.ObjectIDFromHex(utils.GetUserNameFromContext(ctx))
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "not authenticated")
+	}
+	requestHash, err := hashRequest(ctx, req)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unable to read request")
+	}
+
+	record, err := server.IdempotencySvc.Begin(
+		ctx, &models.IdempotencyRecord{
+			UserID:      userId,
+			Method:      info.FullMethod,
+			Key:         keys[0],
+			RequestHash: requestHash,
+		},
+	)
+	if err != nil {
+		return nil, utils.CreateStatusErrorFromError(err, server.Logger)
+	}
+	if record.State == models.IdempotencyStateCompleted {
+		res, err := unmarshalResponse(record.Response)
+		if err != nil {
+			server.Logger.Error(err, "unable to replay idempotent response", "method", info.FullMethod)
+			return nil, grpc.Errorf(codes.Internal, "unable to replay response")
+		}
+		return res, nil
+	}
+
+	res, err := handler(ctx, req)
+	if err != nil {
+		// failed requests may be retried with the same key
+		if releaseErr := server.IdempotencySvc.Release(context.Background(), record); releaseErr != nil {
+			server.Logger.Error(releaseErr, "unable to release idempotency key", "method", info.FullMethod)
+		}
+		return nil, err
+	}
+
+	response, err := marshalResponse(res)
+	if err == nil {
+		err = server.IdempotencySvc.Complete(context.Background(), record, response)
+	}
+	if err != nil {
+		// the request went through, only its retries won't be deduplicated
+		server.Logger.Error(err, "unable to store idempotent response", "method", info.FullMethod)
+	}
+	return res, nil
+}
+
+// hashRequest fingerprints the request together with the workspace it was
+// made in, so a key can't be reused for a different request.
+func hashRequest(ctx context.Context, req interface{}) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "", grpc.Errorf(codes.Internal, "request is not a proto message")
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(utils.GetWorkspaceIdFromContext(ctx)))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func marshalResponse(res interface{}) ([]byte, error) {
+	msg, ok := res.(proto.Message)
+	if !ok {
+		return nil, grpc.Errorf(codes.Internal, "response is not a proto message")
+	}
+	anyRes, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(anyRes)
+}
+
+func unmarshalResponse(response []byte) (interface{}, error) {
+	anyRes := &anypb.Any{}
+	if err := proto.Unmarshal(response, anyRes); err != nil {
+		return nil, err
+	}
+	return anyRes.UnmarshalNew()
+}
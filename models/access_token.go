@@ -0,0 +1,50 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Scopes of personal access tokens, each method a token may call needs one
+// of them.
+const (
+	ScopeTodosRead       = "todos:read"
+	ScopeTodosWrite      = "todos:write"
+	ScopeViewsRead       = "views:read"
+	ScopeViewsWrite      = "views:write"
+	ScopeWorkspacesRead  = "workspaces:read"
+	ScopeWorkspacesWrite = "workspaces:write"
+)
+
+var AccessTokenScopes = []string{
+	ScopeTodosRead,
+	ScopeTodosWrite,
+	ScopeViewsRead,
+	ScopeViewsWrite,
+	ScopeWorkspacesRead,
+	ScopeWorkspacesWrite,
+}
+
+// AccessToken is a personal access token of a user for scripts and
+// integrations, only the hash of the opaque token is kept. Revoked tokens
+// are deleted.
+type AccessToken struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	UserID       primitive.ObjectID `bson:"user_id"`
+	Name         string             `bson:"name"`
+	TokenHash    string             `bson:"token_hash"`
+	Scopes       []string           `bson:"scopes"`
+	CreateTime   primitive.DateTime `bson:"create_time"`
+	LastUsedTime primitive.DateTime `bson:"last_used_time,omitempty"`
+	// ExpireTime is picked up by the TTL index of the collection, tokens
+	// without it don't expire
+	ExpireTime primitive.DateTime `bson:"expire_time,omitempty"`
+}
+
+func (t *AccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
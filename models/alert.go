@@ -8,4 +8,12 @@ type Alert struct {
 	Type       string             `bson:"sub_type"`
 	UserID     primitive.ObjectID `bson:"user_id"`
 	TodoID     primitive.ObjectID `bson:"todo_id"`
+	CreateTime primitive.DateTime `bson:"create_time,omitempty"`
 }
+
+const (
+	AlertTypeOwner    = "owner"
+	AlertTypeAssignee = "assignee"
+	// AlertTypeAccount alerts are about the account rather than a todo
+	AlertTypeAccount = "account"
+)
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type AnalyticsEventType string
+
+const (
+	AnalyticsEventTodoCreated    AnalyticsEventType = "todo_created"
+	AnalyticsEventTodoUpdated    AnalyticsEventType = "todo_updated"
+	AnalyticsEventTodoCompleted  AnalyticsEventType = "todo_completed"
+	AnalyticsEventTodoDeleted    AnalyticsEventType = "todo_deleted"
+	AnalyticsEventUserRegistered AnalyticsEventType = "user_registered"
+	AnalyticsEventUserLoggedIn   AnalyticsEventType = "user_logged_in"
+)
+
+// AnalyticsEvent is a domain event as published on the analytics topic and
+// stored in ClickHouse. Ids of absent entities are empty.
+type AnalyticsEvent struct {
+	EventID     string             `gorm:"column:event_id"`
+	Type        AnalyticsEventType `gorm:"column:event_type"`
+	UserID      string             `gorm:"column:user_id"`
+	WorkspaceID string             `gorm:"column:workspace_id"`
+	TodoID      string             `gorm:"column:todo_id"`
+	Priority    string             `gorm:"column:priority"`
+	OccurTime   time.Time          `gorm:"column:occur_time"`
+}
+
+func (AnalyticsEvent) TableName() string {
+	return "analytics_events"
+}
+
+func NewTodoAnalyticsEvent(eventType AnalyticsEventType, todo *Todo, actorId primitive.ObjectID) *AnalyticsEvent {
+	event := &AnalyticsEvent{
+		EventID:   primitive.NewObjectID().Hex(),
+		Type:      eventType,
+		UserID:    actorId.Hex(),
+		TodoID:    todo.ID.Hex(),
+		Priority:  todo.Priority,
+		OccurTime: time.Now(),
+	}
+	if !todo.WorkspaceID.IsZero() {
+		event.WorkspaceID = todo.WorkspaceID.Hex()
+	}
+	return event
+}
+
+func NewUserAnalyticsEvent(eventType AnalyticsEventType, userId primitive.ObjectID) *AnalyticsEvent {
+	return &AnalyticsEvent{
+		EventID:   primitive.NewObjectID().Hex(),
+		Type:      eventType,
+		UserID:    userId.Hex(),
+		OccurTime: time.Now(),
+	}
+}
+
+type AnalyticsFilter struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+}
+
+type DailyActiveUsers struct {
+	Date  string `gorm:"column:date"`
+	Users int64  `gorm:"column:users"`
+}
+
+// CompletionFunnel follows the todos created in a range through being worked
+// on and being completed.
+type CompletionFunnel struct {
+	Created   int64 `gorm:"column:created"`
+	Updated   int64 `gorm:"column:updated"`
+	Completed int64 `gorm:"column:completed"`
+}
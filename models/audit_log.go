@@ -0,0 +1,39 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type AuditAction string
+
+const (
+	AuditListUsers        AuditAction = "list_users"
+	AuditGetUser          AuditAction = "get_user"
+	AuditDisableUser      AuditAction = "disable_user"
+	AuditEnableUser       AuditAction = "enable_user"
+	AuditForceLogout      AuditAction = "force_logout"
+	AuditSetPremium       AuditAction = "set_premium"
+	AuditImpersonate      AuditAction = "impersonate"
+	AuditImpersonatedCall AuditAction = "impersonated_call"
+	// roles are only granted and revoked with cmd/grant-admin
+	AuditGrantAdmin  AuditAction = "grant_admin"
+	AuditRevokeAdmin AuditAction = "revoke_admin"
+)
+
+// AuditActor is the admin taking an action
+type AuditActor struct {
+	UserID   primitive.ObjectID
+	ClientIp string
+}
+
+// AuditLogEntry records an action of an admin. Entries are only ever
+// inserted, and before the action is taken, so no action goes unrecorded.
+type AuditLogEntry struct {
+	ID primitive.ObjectID `bson:"_id"`
+	// ActorID is missing for changes made with cmd/grant-admin
+	ActorID      primitive.ObjectID     `bson:"actor_id,omitempty"`
+	ClientIp     string                 `bson:"client_ip,omitempty"`
+	Action       AuditAction            `bson:"action"`
+	TargetUserID primitive.ObjectID     `bson:"target_user_id,omitempty"`
+	Reason       string                 `bson:"reason,omitempty"`
+	Details      map[string]interface{} `bson:"details,omitempty"`
+	CreateTime   primitive.DateTime     `bson:"create_time"`
+}
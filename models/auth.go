@@ -2,8 +2,43 @@ package models
 
 import "github.com/golang-jwt/jwt"
 
+// UserClaims are the claims of an access token. The embedded Id is the jti
+// identifying the token for revocation.
 type UserClaims struct {
 	UserID string `json:"adminId"`
+	// Generation is the token generation of the user at issue time, logging
+	// out everywhere revokes the tokens of all generations before the next
+	Generation int64 `json:"gen,omitempty"`
+	// ImpersonatorID is the admin who acts as the user with this token
+	ImpersonatorID string `json:"impersonatorId,omitempty"`
+	// AuthTime is when the user authenticated, only the tokens issued for a
+	// new session carry it and refreshed ones don't
+	AuthTime int64 `json:"auth_time,omitempty"`
+
+	jwt.StandardClaims
+}
+
+// EmailVerificationClaims are the claims of the token of a verification link.
+// It only verifies the email it was sent to.
+type EmailVerificationClaims struct {
+	UserID string `json:"verifyUserId"`
+	Email  string `json:"email"`
+
+	jwt.StandardClaims
+}
+
+// TwoFactorChallengeClaims are the claims of the token of a login waiting
+// for the second factor.
+type TwoFactorChallengeClaims struct {
+	UserID string `json:"challengeUserId"`
+
+	jwt.StandardClaims
+}
+
+type InvitationClaims struct {
+	InvitationID string `json:"invitationId"`
+	WorkspaceID  string `json:"workspaceId"`
+	Email        string `json:"email"`
 
 	jwt.StandardClaims
 }
@@ -0,0 +1,28 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type IdempotencyState string
+
+const (
+	IdempotencyStateInProgress IdempotencyState = "in_progress"
+	IdempotencyStateCompleted  IdempotencyState = "completed"
+)
+
+// IdempotencyRecord remembers the response of a mutating request made with an
+// idempotency key, so that retries of the request can be answered with it.
+type IdempotencyRecord struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	Method      string             `bson:"method"`
+	Key         string             `bson:"key"`
+	RequestHash string             `bson:"request_hash"`
+	State       IdempotencyState   `bson:"state"`
+	Response    []byte             `bson:"response,omitempty"`
+	CreateTime  primitive.DateTime `bson:"create_time"`
+	// LockExpireTime is when an in progress request is considered abandoned
+	// and another request with the same key may take over
+	LockExpireTime primitive.DateTime `bson:"lock_expire_time"`
+	// ExpireTime is picked up by the TTL index of the collection
+	ExpireTime primitive.DateTime `bson:"expire_time"`
+}
@@ -5,4 +5,8 @@ type QueueTopic string
 const (
 	TopicDeadlineNearby QueueTopic = "deadline_nearby"
 	TopicPremiumEnding  QueueTopic = "premium_ending"
+	TopicTodoAssigned   QueueTopic = "todo_assigned"
+	TopicTodoUnsnoozed  QueueTopic = "todo_unsnoozed"
+	// TopicAnalyticsEvents carries the domain events feeding analytics
+	TopicAnalyticsEvents QueueTopic = "analytics_events"
 )
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// LoginAttempts counts the recent failed logins of an account or of a client
+// ip, ID is the key of either.
+type LoginAttempts struct {
+	ID           string             `bson:"_id"`
+	Failures     int                `bson:"failures"`
+	BlockedUntil primitive.DateTime `bson:"blocked_until,omitempty"`
+	// ExpireTime is picked up by the TTL index of the collection, the
+	// failures are forgotten then
+	ExpireTime primitive.DateTime `bson:"expire_time"`
+}
+
+// LoginThrottlePolicy tells how long logins are refused after a number of
+// failures.
+type LoginThrottlePolicy struct {
+	BackoffThreshold int
+	BackoffBase      time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+}
+
+// Delay returns how long to wait after the given number of failures and
+// whether that is a lockout. The delay doubles with every failure past the
+// backoff threshold, up to the lockout duration.
+func (p LoginThrottlePolicy) Delay(failures int) (time.Duration, bool) {
+	if failures >= p.LockoutThreshold {
+		return p.LockoutDuration, true
+	}
+	if failures < p.BackoffThreshold {
+		return 0, false
+	}
+
+	delay := p.BackoffBase
+	for i := p.BackoffThreshold; i < failures && delay < p.LockoutDuration; i++ {
+		delay *= 2
+	}
+	return min(delay, p.LockoutDuration), false
+}
@@ -0,0 +1,33 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// UserIdentity links a user to their account at an OpenID Connect provider,
+// Subject is the id of the account at the Issuer.
+type UserIdentity struct {
+	Issuer   string             `bson:"issuer"`
+	Subject  string             `bson:"subject"`
+	LinkTime primitive.DateTime `bson:"link_time"`
+}
+
+// OidcIdentity is the user an OpenID Connect provider vouched for with a
+// verified ID token.
+type OidcIdentity struct {
+	Provider      string
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OidcLogin is a login started with a provider, found by the hash of its
+// state. It is used once to exchange the code the provider sends back.
+type OidcLogin struct {
+	ID           string `bson:"_id"`
+	Provider     string `bson:"provider"`
+	CodeVerifier string `bson:"code_verifier"`
+	Nonce        string `bson:"nonce"`
+	// ExpireTime is picked up by the TTL index of the collection
+	ExpireTime primitive.DateTime `bson:"expire_time"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Plan is what a user pays for, it sets their quota
+type Plan string
+
+const (
+	PlanFree    Plan = "free"
+	PlanPremium Plan = "premium"
+)
+
+// PlanQuota limits what the users of a plan may store, a negative limit is
+// unlimited
+type PlanQuota struct {
+	MaxTodos int64
+}
+
+// PremiumEndingEvent announces that the premium plan of a user ends soon
+type PremiumEndingEvent struct {
+	UserID     primitive.ObjectID
+	Name       string
+	Email      string
+	ExpireTime time.Time
+}
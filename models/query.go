@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+type QueryNodeKind string
+
+const (
+	QueryNodeAnd  QueryNodeKind = "and"
+	QueryNodeOr   QueryNodeKind = "or"
+	QueryNodeNot  QueryNodeKind = "not"
+	QueryNodeTerm QueryNodeKind = "term"
+)
+
+// QueryNode is a node of a parsed todo query. And and Or nodes have two or
+// more children, Not nodes exactly one and term nodes only a Term.
+type QueryNode struct {
+	Kind     QueryNodeKind
+	Children []*QueryNode
+	Term     *QueryTerm
+}
+
+type QueryField string
+
+const (
+	QueryFieldStatus   QueryField = "status"
+	QueryFieldPriority QueryField = "priority"
+	QueryFieldLabel    QueryField = "label"
+	QueryFieldAssignee QueryField = "assignee"
+	QueryFieldName     QueryField = "name"
+	QueryFieldDue      QueryField = "due"
+	QueryFieldCreated  QueryField = "created"
+	QueryFieldUpdated  QueryField = "updated"
+)
+
+type QueryOperator string
+
+const (
+	QueryOpEqual        QueryOperator = ":"
+	QueryOpLess         QueryOperator = "<"
+	QueryOpLessEqual    QueryOperator = "<="
+	QueryOpGreater      QueryOperator = ">"
+	QueryOpGreaterEqual QueryOperator = ">="
+)
+
+// Values of the status, assignee and time fields with a special meaning
+const (
+	QueryValueOpen = "open"
+	QueryValueDone = "done"
+	QueryValueMe   = "me"
+	QueryValueNone = "none"
+)
+
+// QueryTerm compares a field with a value. Values are normalized, e.g.
+// priorities are upper case, and time fields other than none have Time set.
+// Negated comparisons are parsed into not nodes.
+type QueryTerm struct {
+	Field    QueryField
+	Operator QueryOperator
+	Value    string
+	Time     *QueryTime
+	// Position is where the term starts in the query, counted in characters
+	// from 1
+	Position int
+}
+
+// QueryTime is either an instant at Offset from now, or a calendar day.
+// Days are Date when set and otherwise Days days from today.
+type QueryTime struct {
+	Offset time.Duration
+	IsDay  bool
+	Date   time.Time
+	Days   int
+}
+
+// Range resolves the time against now, an instant resolves to itself and a
+// day to its start and the start of the next day.
+func (t *QueryTime) Range(now time.Time, location *time.Location) (time.Time, time.Time) {
+	if !t.IsDay {
+		instant := now.Add(t.Offset)
+		return instant, instant
+	}
+
+	var day time.Time
+	if !t.Date.IsZero() {
+		day = time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, location)
+	} else {
+		localNow := now.In(location)
+		day = time.Date(localNow.Year(), localNow.Month(), localNow.Day()+t.Days, 0, 0, 0, 0, location)
+	}
+	return day, day.AddDate(0, 0, 1)
+}
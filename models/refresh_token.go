@@ -0,0 +1,38 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type RefreshTokenState string
+
+const (
+	RefreshTokenActive  RefreshTokenState = "active"
+	RefreshTokenRotated RefreshTokenState = "rotated"
+	RefreshTokenRevoked RefreshTokenState = "revoked"
+)
+
+// RefreshToken is a stored refresh token, only the hash of the opaque token
+// is kept. Every use rotates it into a new token of the same family, the
+// rotated ones are kept to detect their reuse until they expire.
+type RefreshToken struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	UserID     primitive.ObjectID `bson:"user_id"`
+	FamilyID   primitive.ObjectID `bson:"family_id"`
+	TokenHash  string             `bson:"token_hash"`
+	State      RefreshTokenState  `bson:"state"`
+	CreateTime primitive.DateTime `bson:"create_time"`
+	RotateTime primitive.DateTime `bson:"rotate_time,omitempty"`
+	ExpireTime primitive.DateTime `bson:"expire_time"`
+}
+
+// AuthTokens are the tokens handed out on login. Logins of users with two
+// factor authentication only get a ChallengeToken, which is exchanged for
+// the other tokens together with a code.
+type AuthTokens struct {
+	AccessToken      string
+	AccessExpireTime time.Time
+	RefreshToken     string
+	ChallengeToken   string
+}
@@ -0,0 +1,18 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// RevokedToken is a logged out token, kept until the token expires anyway.
+type RevokedToken struct {
+	ID         string             `bson:"_id"`
+	UserID     primitive.ObjectID `bson:"user_id"`
+	ExpireTime primitive.DateTime `bson:"expire_time"`
+}
+
+// TokenGeneration counts how often a user logged out everywhere. Tokens of
+// disabled users are rejected whatever their generation.
+type TokenGeneration struct {
+	UserID     primitive.ObjectID `bson:"_id"`
+	Generation int64              `bson:"generation"`
+	Disabled   bool               `bson:"disabled,omitempty"`
+}
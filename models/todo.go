@@ -1,10 +1,16 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"time"
+)
 
 type Todo struct {
 	ID          primitive.ObjectID `bson:"_id"`
 	UserID      primitive.ObjectID `bson:"user_id"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
+	AssigneeID  primitive.ObjectID `bson:"assignee_id,omitempty"`
 	Name        string             `bson:"name"`
 	Description string             `bson:"description,omitempty"`
 	Status      bool               `bson:"status,omitempty"`
@@ -12,14 +18,295 @@ type Todo struct {
 	CreateTime  primitive.DateTime `bson:"create_time,omitempty"`
 	UpdateTime  primitive.DateTime `bson:"update_time,omitempty"`
 	DeadLine    primitive.DateTime `bson:"deadline,omitempty"`
+	// CompleteTime is when the todo was last marked as done, todos completed
+	// before it was introduced don't have it
+	CompleteTime primitive.DateTime `bson:"complete_time,omitempty"`
+	Recurrence   *Recurrence        `bson:"recurrence,omitempty"`
+	Labels       []string           `bson:"labels,omitempty"`
+	// Rank orders the todos of a scope on boards
+	Rank string `bson:"rank,omitempty"`
+	// HideUntil hides the todo from listings and deadline alerts until then.
+	// Snoozed is set while the end of a snooze wasn't announced yet.
+	HideUntil primitive.DateTime `bson:"hide_until,omitempty"`
+	Snoozed   bool               `bson:"snoozed,omitempty"`
+	SyncSeq   int64              `bson:"sync_seq"`
+}
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+)
+
+// Recurrence repeats a todo every Interval days, weeks or months, starting at
+// its deadline. A zero Until repeats it forever.
+type Recurrence struct {
+	Frequency RecurrenceFrequency `bson:"frequency"`
+	Interval  int32               `bson:"interval"`
+	Until     primitive.DateTime  `bson:"until,omitempty"`
+}
+
+// Occurrence returns the n-th occurrence after the first one at start.
+// Months are added to the calendar date in the zone of start.
+func (r *Recurrence) Occurrence(start time.Time, n int) time.Time {
+	step := n * max(int(r.Interval), 1)
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case RecurrenceMonthly:
+		return start.AddDate(0, step, 0)
+	default:
+		return start.AddDate(0, 0, step)
+	}
+}
+
+type TodoEventType string
+
+const (
+	TodoEventSnapshot         TodoEventType = "snapshot"
+	TodoEventSnapshotComplete TodoEventType = "snapshot_complete"
+	TodoEventCreated          TodoEventType = "created"
+	TodoEventUpdated          TodoEventType = "updated"
+	TodoEventDeleted          TodoEventType = "deleted"
+)
+
+// TodoEvent is a single message of a todo watch. Todo is nil for deletions
+// and for the end-of-snapshot marker.
+type TodoEvent struct {
+	Type        TodoEventType
+	TodoID      primitive.ObjectID
+	Todo        *Todo
+	ResumeToken string
+	// Count is the number of todos sent in the snapshot, only set on the
+	// end-of-snapshot marker
+	Count int64
+}
+
+// WatchTodoFilter narrows down the todos of a watch. A nil Status matches
+// both open and completed todos, an empty Priorities matches all priorities.
+type WatchTodoFilter struct {
+	Status     *bool
+	Priorities []string
+}
+
+// Matches reports whether the todo passes the filter.
+func (f *WatchTodoFilter) Matches(todo *Todo) bool {
+	if f.Status != nil && *f.Status != todo.Status {
+		return false
+	}
+	return len(f.Priorities) == 0 || slices.Contains(f.Priorities, todo.Priority)
 }
 
 type ListTodoFilter struct {
 	Limit int32
 	Page  int32
+	// Query narrows down and sorts the todos, relative deadlines are resolved
+	// in Location
+	Query *TodoQuery
+	// Expr is a parsed query string, AND-ed with Query
+	Expr     *QueryNode
+	Location *time.Location
+	// IncludeSnoozed also lists the todos hidden until a later time
+	IncludeSnoozed bool
 }
 
 type ListTodoRes struct {
 	Todos []Todo
 	Count int64
 }
+
+type TodoAssignedEvent struct {
+	TodoID      primitive.ObjectID
+	TodoName    string
+	AssigneeID  primitive.ObjectID
+	AssignerID  primitive.ObjectID
+	WorkspaceID primitive.ObjectID
+}
+
+// TodoUnsnoozedEvent announces that a snoozed todo shows up again
+type TodoUnsnoozedEvent struct {
+	TodoID      primitive.ObjectID
+	TodoName    string
+	UserID      primitive.ObjectID
+	AssigneeID  primitive.ObjectID
+	WorkspaceID primitive.ObjectID
+	HideUntil   time.Time
+}
+
+// TodoTombstone remembers a deleted todo so that syncing clients learn about
+// the deletion.
+type TodoTombstone struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
+	SyncKey     string             `bson:"sync_key"`
+	SyncSeq     int64              `bson:"sync_seq"`
+	DeleteTime  primitive.DateTime `bson:"delete_time"`
+}
+
+// SyncCounter is the change sequence of a scope. Every change of a todo of
+// the scope takes the next value. CompactedSeq is the highest sequence of
+// the tombstones that were already compacted away.
+type SyncCounter struct {
+	ID           string `bson:"_id"`
+	Seq          int64  `bson:"seq"`
+	CompactedSeq int64  `bson:"compacted_seq"`
+	// RanksBackfilled is set once the todos of the scope created before
+	// ranks were introduced got ranked
+	RanksBackfilled bool `bson:"ranks_backfilled,omitempty"`
+}
+
+// SyncPosition is what a sync token stands for: everything up to Seq has
+// been synced, and for Seq itself every todo up to AfterID.
+type SyncPosition struct {
+	Seq     int64
+	AfterID primitive.ObjectID
+	// Resync is set while a client pages through a full sync
+	Resync bool
+}
+
+type SyncTodosRes struct {
+	Todos      []Todo
+	Tombstones []TodoTombstone
+	Position   SyncPosition
+	HasMore    bool
+	FullResync bool
+}
+
+type TodoChangeStatus string
+
+const (
+	TodoChangeApplied  TodoChangeStatus = "applied"
+	TodoChangeConflict TodoChangeStatus = "conflict"
+	TodoChangeNotFound TodoChangeStatus = "not_found"
+	TodoChangeInvalid  TodoChangeStatus = "invalid"
+)
+
+// TodoChange is a change made by an offline client. BaseSyncSeq is the sync
+// sequence of the todo the change was made on.
+type TodoChange struct {
+	ClientChangeID string
+	Delete         bool
+	Todo           *Todo
+	FieldMasks     []string
+	BaseSyncSeq    int64
+}
+
+type TodoChangeResult struct {
+	ClientChangeID string
+	Status         TodoChangeStatus
+	Todo           *Todo
+	Msg            string
+}
+
+// StatsFilter selects the todos GetStats looks at, days are counted in
+// Location.
+type StatsFilter struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+}
+
+type DailyTodoStats struct {
+	Date      string
+	Created   int64
+	Completed int64
+	Overdue   int64
+}
+
+type PriorityTodoStats struct {
+	Priority  string
+	Created   int64
+	Completed int64
+}
+
+type TodoStats struct {
+	Days                  []DailyTodoStats
+	Created               int64
+	Completed             int64
+	CompletionRate        float64
+	AverageTimeToComplete time.Duration
+	CurrentStreak         int
+	LongestStreak         int
+	Priorities            []PriorityTodoStats
+}
+
+// AgendaFilter selects the todos of GetAgenda, days are bucketed in Location.
+type AgendaFilter struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+}
+
+type AgendaItem struct {
+	Todo      *Todo
+	OccursAt  time.Time
+	Recurring bool
+}
+
+type AgendaBucket struct {
+	Date           string
+	Items          []AgendaItem
+	PriorityTotals map[string]int64
+}
+
+func (b *AgendaBucket) Add(item AgendaItem) {
+	if b.PriorityTotals == nil {
+		b.PriorityTotals = make(map[string]int64)
+	}
+	b.Items = append(b.Items, item)
+	b.PriorityTotals[item.Todo.Priority]++
+}
+
+type Agenda struct {
+	Days    []AgendaBucket
+	Overdue AgendaBucket
+}
+
+type BoardGroupBy string
+
+const (
+	BoardGroupByStatus   BoardGroupBy = "status"
+	BoardGroupByPriority BoardGroupBy = "priority"
+	BoardGroupByAssignee BoardGroupBy = "assignee"
+	BoardGroupByLabel    BoardGroupBy = "label"
+)
+
+// Column keys of boards grouped by status
+const (
+	BoardColumnOpen = "open"
+	BoardColumnDone = "done"
+)
+
+// BoardFilter selects the columns of a board and the page of cards shown in
+// each of them. An empty Column shows all columns. Todos narrows down the
+// cards like ListTodo does, its Limit and Page paginate every column.
+type BoardFilter struct {
+	GroupBy BoardGroupBy
+	Column  *string
+	Todos   *ListTodoFilter
+}
+
+type BoardColumn struct {
+	Key   string
+	Count int64
+	Cards []Todo
+}
+
+type Board struct {
+	GroupBy BoardGroupBy
+	Columns []BoardColumn
+}
+
+// CardMove moves a todo to the ToColumn of the board, between the todos
+// PreviousID and NextID.
+type CardMove struct {
+	TodoID     primitive.ObjectID
+	GroupBy    BoardGroupBy
+	FromColumn string
+	ToColumn   string
+	PreviousID primitive.ObjectID
+	NextID     primitive.ObjectID
+}
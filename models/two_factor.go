@@ -0,0 +1,22 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// TwoFactor is the TOTP second factor of a user. It is pending until the
+// user confirms it with a first code.
+type TwoFactor struct {
+	// EncryptedSecret is the TOTP secret, encrypted with the two factor key
+	EncryptedSecret string `bson:"encrypted_secret"`
+	Enabled         bool   `bson:"enabled"`
+	// BackupCodeHashes are the hashes of the backup codes not used yet
+	BackupCodeHashes []string           `bson:"backup_code_hashes,omitempty"`
+	LastUsedStep     int64              `bson:"last_used_step"`
+	CreateTime       primitive.DateTime `bson:"create_time"`
+	ConfirmTime      primitive.DateTime `bson:"confirm_time,omitempty"`
+}
+
+// TwoFactorSetup is what users add to their authenticator app
+type TwoFactorSetup struct {
+	Secret     string
+	OtpAuthUri string
+}
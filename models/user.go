@@ -1,15 +1,76 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
 
 type User struct {
-	ID        primitive.ObjectID   `bson:"_id"`
-	Name      string               `bson:"name"`
-	Email     string               `bson:"email"`
-	IsPremium bool                 `bson:"is_premium,omitempty"`
-	Todos     []primitive.ObjectID `bson:"todos,omitempty"`
-	Password  string               `bson:"password"`
-	CreatedAt primitive.DateTime   `bson:"created_at,omitempty"`
+	ID        primitive.ObjectID `bson:"_id"`
+	Name      string             `bson:"name"`
+	Email     string             `bson:"email"`
+	IsPremium bool               `bson:"is_premium,omitempty"`
+	// PremiumExpireTime ends the premium plan, it doesn't end without one.
+	// PremiumEndingNotified is set once the user was told it ends soon.
+	PremiumExpireTime     primitive.DateTime   `bson:"premium_expire_time,omitempty"`
+	PremiumEndingNotified bool                 `bson:"premium_ending_notified,omitempty"`
+	Todos                 []primitive.ObjectID `bson:"todos,omitempty"`
+	Password              string               `bson:"password"`
+	CreatedAt             primitive.DateTime   `bson:"created_at,omitempty"`
+	// EmailVerified is set once the user followed the link of the
+	// verification email, unverified users can't invite or share
+	EmailVerified      bool               `bson:"email_verified"`
+	VerificationSentAt primitive.DateTime `bson:"verification_sent_at,omitempty"`
+	PasswordReset      *PasswordReset     `bson:"password_reset,omitempty"`
+	TwoFactor          *TwoFactor         `bson:"two_factor,omitempty"`
+	// Identities are the accounts at OpenID Connect providers the user logs
+	// in with, users who only have those have no password
+	Identities []UserIdentity `bson:"identities,omitempty"`
+	Roles      []string       `bson:"roles,omitempty"`
+	// Disabled users can't log in and their tokens are rejected
+	Disabled    bool               `bson:"disabled,omitempty"`
+	DisableTime primitive.DateTime `bson:"disable_time,omitempty"`
+}
+
+// Plan is the plan of the user at the time, premium ends on its own once it
+// expired
+func (u *User) Plan(now time.Time) Plan {
+	if u.IsPremium && (u.PremiumExpireTime == 0 || now.Before(u.PremiumExpireTime.Time())) {
+		return PlanPremium
+	}
+	return PlanFree
+}
+
+// RoleAdmin may call the admin service
+const RoleAdmin = "admin"
+
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ListUsersFilter searches users by name or email
+type ListUsersFilter struct {
+	Query string
+	Limit int32
+	Page  int32
+}
+
+type ListUsersRes struct {
+	Users []User
+	Count int64
+}
+
+// PasswordReset is the pending password reset of a user. Only the hash of
+// the emailed token is kept, it is removed when the token is used.
+type PasswordReset struct {
+	TokenHash  string             `bson:"token_hash"`
+	SentAt     primitive.DateTime `bson:"sent_at"`
+	ExpireTime primitive.DateTime `bson:"expire_time"`
 }
 
 type RegisterResponse struct {
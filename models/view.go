@@ -0,0 +1,110 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type AssigneeFilter string
+
+const (
+	AssigneeAny        AssigneeFilter = ""
+	AssigneeMe         AssigneeFilter = "me"
+	AssigneeOthers     AssigneeFilter = "others"
+	AssigneeUnassigned AssigneeFilter = "unassigned"
+)
+
+// DeadlineWindow selects todos by a deadline relative to the time the todos
+// are listed, days are counted in the zone of the request.
+type DeadlineWindow string
+
+const (
+	DeadlineAny      DeadlineWindow = ""
+	DeadlineToday    DeadlineWindow = "today"
+	DeadlineThisWeek DeadlineWindow = "this_week"
+	DeadlineUpcoming DeadlineWindow = "upcoming"
+	DeadlineOverdue  DeadlineWindow = "overdue"
+	DeadlineNone     DeadlineWindow = "none"
+)
+
+type TodoSortField string
+
+const (
+	SortByCreateTime TodoSortField = "create_time"
+	SortByUpdateTime TodoSortField = "update_time"
+	SortByDeadline   TodoSortField = "deadline"
+	SortByName       TodoSortField = "name"
+	SortByRank       TodoSortField = "rank"
+)
+
+type SortOrder string
+
+const (
+	// SortDefault sorts times newest first and everything else ascending
+	SortDefault    SortOrder = ""
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// TodoQuery is a filter and sort definition of todos. Zero fields don't
+// filter, a nil Status matches both open and completed todos.
+type TodoQuery struct {
+	Status     *bool          `bson:"status,omitempty"`
+	Priorities []string       `bson:"priorities,omitempty"`
+	Labels     []string       `bson:"labels,omitempty"`
+	Assignee   AssigneeFilter `bson:"assignee,omitempty"`
+	Deadline   DeadlineWindow `bson:"deadline,omitempty"`
+	SortBy     TodoSortField  `bson:"sort_by,omitempty"`
+	SortOrder  SortOrder      `bson:"sort_order,omitempty"`
+}
+
+// SavedView is a named TodoQuery. Views made in a workspace can be shared
+// with its members, only the owner can change them.
+type SavedView struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
+	Name        string             `bson:"name"`
+	Query       TodoQuery          `bson:"query"`
+	Shared      bool               `bson:"shared,omitempty"`
+	// SystemID identifies the built in views, they aren't stored and can't
+	// be changed
+	SystemID   string             `bson:"-"`
+	CreateTime primitive.DateTime `bson:"create_time,omitempty"`
+	UpdateTime primitive.DateTime `bson:"update_time,omitempty"`
+}
+
+// SystemViewPrefix starts the ids of the system views
+const SystemViewPrefix = "system:"
+
+var openStatus = false
+
+// SystemViews are offered to every user next to their own views.
+var SystemViews = []SavedView{
+	{
+		SystemID: SystemViewPrefix + "today",
+		Name:     "Today",
+		Query:    TodoQuery{Status: &openStatus, Deadline: DeadlineToday, SortBy: SortByDeadline},
+	},
+	{
+		SystemID: SystemViewPrefix + "upcoming",
+		Name:     "Upcoming",
+		Query:    TodoQuery{Status: &openStatus, Deadline: DeadlineUpcoming, SortBy: SortByDeadline},
+	},
+	{
+		SystemID: SystemViewPrefix + "overdue",
+		Name:     "Overdue",
+		Query:    TodoQuery{Status: &openStatus, Deadline: DeadlineOverdue, SortBy: SortByDeadline},
+	},
+	{
+		SystemID: SystemViewPrefix + "no_deadline",
+		Name:     "No deadline",
+		Query:    TodoQuery{Status: &openStatus, Deadline: DeadlineNone},
+	},
+}
+
+func FindSystemView(systemId string) (*SavedView, bool) {
+	for _, view := range SystemViews {
+		if view.SystemID == systemId {
+			return &view, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,66 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleMember WorkspaceRole = "member"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+type Workspace struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"name"`
+	OwnerID    primitive.ObjectID `bson:"owner_id"`
+	CreateTime primitive.DateTime `bson:"create_time,omitempty"`
+	UpdateTime primitive.DateTime `bson:"update_time,omitempty"`
+}
+
+type WorkspaceMember struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	Role        WorkspaceRole      `bson:"role"`
+	JoinTime    primitive.DateTime `bson:"join_time,omitempty"`
+}
+
+type WorkspaceInvitation struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id"`
+	Email       string             `bson:"email"`
+	Role        WorkspaceRole      `bson:"role"`
+	InvitedBy   primitive.ObjectID `bson:"invited_by"`
+	Status      InvitationStatus   `bson:"status"`
+	CreateTime  primitive.DateTime `bson:"create_time,omitempty"`
+	ExpireTime  primitive.DateTime `bson:"expire_time"`
+}
+
+// Scope identifies whose data a request may touch. When WorkspaceID is set
+// the request operates on the workspace's data, otherwise on the personal
+// data of UserID.
+type Scope struct {
+	UserID      primitive.ObjectID
+	WorkspaceID primitive.ObjectID
+}
+
+func (s *Scope) IsWorkspace() bool {
+	return s != nil && !s.WorkspaceID.IsZero()
+}
+
+// Key identifies the scope, e.g. for its sync counter.
+func (s *Scope) Key() string {
+	if s.IsWorkspace() {
+		return "workspace:" + s.WorkspaceID.Hex()
+	}
+	return "user:" + s.UserID.Hex()
+}
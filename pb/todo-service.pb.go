@@ -7,6 +7,7 @@
 package pb
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	field_mask "google.golang.org/genproto/protobuf/field_mask"
@@ -23,6 +24,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BoardGroupBy int32
+
+const (
+	BoardGroupBy_STATUS   BoardGroupBy = 0
+	BoardGroupBy_PRIORITY BoardGroupBy = 1
+	BoardGroupBy_ASSIGNEE BoardGroupBy = 2
+	BoardGroupBy_LABEL    BoardGroupBy = 3
+)
+
+// Enum value maps for BoardGroupBy.
+var (
+	BoardGroupBy_name = map[int32]string{
+		0: "STATUS",
+		1: "PRIORITY",
+		2: "ASSIGNEE",
+		3: "LABEL",
+	}
+	BoardGroupBy_value = map[string]int32{
+		"STATUS":   0,
+		"PRIORITY": 1,
+		"ASSIGNEE": 2,
+		"LABEL":    3,
+	}
+)
+
+func (x BoardGroupBy) Enum() *BoardGroupBy {
+	p := new(BoardGroupBy)
+	*p = x
+	return p
+}
+
+func (x BoardGroupBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoardGroupBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BoardGroupBy) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[0]
+}
+
+func (x BoardGroupBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoardGroupBy.Descriptor instead.
+func (BoardGroupBy) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{0}
+}
+
 type Todo_Priority int32
 
 const (
@@ -56,11 +109,11 @@ func (x Todo_Priority) String() string {
 }
 
 func (Todo_Priority) Descriptor() protoreflect.EnumDescriptor {
-	return file_todo_service_proto_enumTypes[0].Descriptor()
+	return file_todo_service_proto_enumTypes[1].Descriptor()
 }
 
 func (Todo_Priority) Type() protoreflect.EnumType {
-	return &file_todo_service_proto_enumTypes[0]
+	return &file_todo_service_proto_enumTypes[1]
 }
 
 func (x Todo_Priority) Number() protoreflect.EnumNumber {
@@ -72,6 +125,261 @@ func (Todo_Priority) EnumDescriptor() ([]byte, []int) {
 	return file_todo_service_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type Recurrence_Frequency int32
+
+const (
+	Recurrence_NONE    Recurrence_Frequency = 0
+	Recurrence_DAILY   Recurrence_Frequency = 1
+	Recurrence_WEEKLY  Recurrence_Frequency = 2
+	Recurrence_MONTHLY Recurrence_Frequency = 3
+)
+
+// Enum value maps for Recurrence_Frequency.
+var (
+	Recurrence_Frequency_name = map[int32]string{
+		0: "NONE",
+		1: "DAILY",
+		2: "WEEKLY",
+		3: "MONTHLY",
+	}
+	Recurrence_Frequency_value = map[string]int32{
+		"NONE":    0,
+		"DAILY":   1,
+		"WEEKLY":  2,
+		"MONTHLY": 3,
+	}
+)
+
+func (x Recurrence_Frequency) Enum() *Recurrence_Frequency {
+	p := new(Recurrence_Frequency)
+	*p = x
+	return p
+}
+
+func (x Recurrence_Frequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Recurrence_Frequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[2].Descriptor()
+}
+
+func (Recurrence_Frequency) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[2]
+}
+
+func (x Recurrence_Frequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Recurrence_Frequency.Descriptor instead.
+func (Recurrence_Frequency) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type StreamTodoReq_StatusFilter int32
+
+const (
+	StreamTodoReq_ALL  StreamTodoReq_StatusFilter = 0
+	StreamTodoReq_OPEN StreamTodoReq_StatusFilter = 1
+	StreamTodoReq_DONE StreamTodoReq_StatusFilter = 2
+)
+
+// Enum value maps for StreamTodoReq_StatusFilter.
+var (
+	StreamTodoReq_StatusFilter_name = map[int32]string{
+		0: "ALL",
+		1: "OPEN",
+		2: "DONE",
+	}
+	StreamTodoReq_StatusFilter_value = map[string]int32{
+		"ALL":  0,
+		"OPEN": 1,
+		"DONE": 2,
+	}
+)
+
+func (x StreamTodoReq_StatusFilter) Enum() *StreamTodoReq_StatusFilter {
+	p := new(StreamTodoReq_StatusFilter)
+	*p = x
+	return p
+}
+
+func (x StreamTodoReq_StatusFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamTodoReq_StatusFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[3].Descriptor()
+}
+
+func (StreamTodoReq_StatusFilter) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[3]
+}
+
+func (x StreamTodoReq_StatusFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamTodoReq_StatusFilter.Descriptor instead.
+func (StreamTodoReq_StatusFilter) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type StreamTodoRes_EventType int32
+
+const (
+	StreamTodoRes_SNAPSHOT          StreamTodoRes_EventType = 0
+	StreamTodoRes_SNAPSHOT_COMPLETE StreamTodoRes_EventType = 1
+	StreamTodoRes_CREATED           StreamTodoRes_EventType = 2
+	StreamTodoRes_UPDATED           StreamTodoRes_EventType = 3
+	// DELETED is also sent for todos that no longer match the filter
+	StreamTodoRes_DELETED StreamTodoRes_EventType = 4
+)
+
+// Enum value maps for StreamTodoRes_EventType.
+var (
+	StreamTodoRes_EventType_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "SNAPSHOT_COMPLETE",
+		2: "CREATED",
+		3: "UPDATED",
+		4: "DELETED",
+	}
+	StreamTodoRes_EventType_value = map[string]int32{
+		"SNAPSHOT":          0,
+		"SNAPSHOT_COMPLETE": 1,
+		"CREATED":           2,
+		"UPDATED":           3,
+		"DELETED":           4,
+	}
+)
+
+func (x StreamTodoRes_EventType) Enum() *StreamTodoRes_EventType {
+	p := new(StreamTodoRes_EventType)
+	*p = x
+	return p
+}
+
+func (x StreamTodoRes_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamTodoRes_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[4].Descriptor()
+}
+
+func (StreamTodoRes_EventType) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[4]
+}
+
+func (x StreamTodoRes_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamTodoRes_EventType.Descriptor instead.
+func (StreamTodoRes_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type TodoChange_Operation int32
+
+const (
+	TodoChange_UPSERT TodoChange_Operation = 0
+	TodoChange_DELETE TodoChange_Operation = 1
+)
+
+// Enum value maps for TodoChange_Operation.
+var (
+	TodoChange_Operation_name = map[int32]string{
+		0: "UPSERT",
+		1: "DELETE",
+	}
+	TodoChange_Operation_value = map[string]int32{
+		"UPSERT": 0,
+		"DELETE": 1,
+	}
+)
+
+func (x TodoChange_Operation) Enum() *TodoChange_Operation {
+	p := new(TodoChange_Operation)
+	*p = x
+	return p
+}
+
+func (x TodoChange_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoChange_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[5].Descriptor()
+}
+
+func (TodoChange_Operation) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[5]
+}
+
+func (x TodoChange_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoChange_Operation.Descriptor instead.
+func (TodoChange_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{16, 0}
+}
+
+type TodoChangeResult_Status int32
+
+const (
+	TodoChangeResult_APPLIED   TodoChangeResult_Status = 0
+	TodoChangeResult_CONFLICT  TodoChangeResult_Status = 1
+	TodoChangeResult_NOT_FOUND TodoChangeResult_Status = 2
+	TodoChangeResult_INVALID   TodoChangeResult_Status = 3
+)
+
+// Enum value maps for TodoChangeResult_Status.
+var (
+	TodoChangeResult_Status_name = map[int32]string{
+		0: "APPLIED",
+		1: "CONFLICT",
+		2: "NOT_FOUND",
+		3: "INVALID",
+	}
+	TodoChangeResult_Status_value = map[string]int32{
+		"APPLIED":   0,
+		"CONFLICT":  1,
+		"NOT_FOUND": 2,
+		"INVALID":   3,
+	}
+)
+
+func (x TodoChangeResult_Status) Enum() *TodoChangeResult_Status {
+	p := new(TodoChangeResult_Status)
+	*p = x
+	return p
+}
+
+func (x TodoChangeResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoChangeResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_service_proto_enumTypes[6].Descriptor()
+}
+
+func (TodoChangeResult_Status) Type() protoreflect.EnumType {
+	return &file_todo_service_proto_enumTypes[6]
+}
+
+func (x TodoChangeResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoChangeResult_Status.Descriptor instead.
+func (TodoChangeResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{18, 0}
+}
+
 type Todo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -86,6 +394,21 @@ type Todo struct {
 	Deadline    *timestamp.Timestamp `protobuf:"bytes,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	UpdatedAt   *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	UserId      string               `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WorkspaceId string               `protobuf:"bytes,10,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// assignee_id is a member of the workspace of the todo, personal todos can
+	// only be assigned to their owner
+	AssigneeId string `protobuf:"bytes,11,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	// sync_seq changes on every write of the todo, offline clients send it
+	// back as the base of their changes
+	SyncSeq     int64                `protobuf:"varint,12,opt,name=sync_seq,json=syncSeq,proto3" json:"sync_seq,omitempty"`
+	CompletedAt *timestamp.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// recurrence repeats the todo starting at its deadline
+	Recurrence *Recurrence `protobuf:"bytes,14,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Labels     []string    `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty"`
+	// rank orders the todos on boards, todos sort by it ascending
+	Rank string `protobuf:"bytes,16,opt,name=rank,proto3" json:"rank,omitempty"`
+	// hide_until hides the todo from listings and deadline alerts until then
+	HideUntil *timestamp.Timestamp `protobuf:"bytes,17,opt,name=hide_until,json=hideUntil,proto3" json:"hide_until,omitempty"`
 }
 
 func (x *Todo) Reset() {
@@ -183,16 +506,76 @@ func (x *Todo) GetUserId() string {
 	return ""
 }
 
-type CreateTodoReq struct {
+func (x *Todo) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *Todo) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *Todo) GetSyncSeq() int64 {
+	if x != nil {
+		return x.SyncSeq
+	}
+	return 0
+}
+
+func (x *Todo) GetCompletedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Todo) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *Todo) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Todo) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *Todo) GetHideUntil() *timestamp.Timestamp {
+	if x != nil {
+		return x.HideUntil
+	}
+	return nil
+}
+
+type Recurrence struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	Frequency Recurrence_Frequency `protobuf:"varint,1,opt,name=frequency,proto3,enum=pb.Recurrence_Frequency" json:"frequency,omitempty"`
+	// interval is the number of days, weeks or months between occurrences
+	Interval int32 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// until is the time after which there are no more occurrences
+	Until *timestamp.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
 }
 
-func (x *CreateTodoReq) Reset() {
-	*x = CreateTodoReq{}
+func (x *Recurrence) Reset() {
+	*x = Recurrence{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_todo_service_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -200,13 +583,13 @@ func (x *CreateTodoReq) Reset() {
 	}
 }
 
-func (x *CreateTodoReq) String() string {
+func (x *Recurrence) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTodoReq) ProtoMessage() {}
+func (*Recurrence) ProtoMessage() {}
 
-func (x *CreateTodoReq) ProtoReflect() protoreflect.Message {
+func (x *Recurrence) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_service_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -218,19 +601,33 @@ func (x *CreateTodoReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTodoReq.ProtoReflect.Descriptor instead.
-func (*CreateTodoReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use Recurrence.ProtoReflect.Descriptor instead.
+func (*Recurrence) Descriptor() ([]byte, []int) {
 	return file_todo_service_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateTodoReq) GetTodo() *Todo {
+func (x *Recurrence) GetFrequency() Recurrence_Frequency {
 	if x != nil {
-		return x.Todo
+		return x.Frequency
+	}
+	return Recurrence_NONE
+}
+
+func (x *Recurrence) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Recurrence) GetUntil() *timestamp.Timestamp {
+	if x != nil {
+		return x.Until
 	}
 	return nil
 }
 
-type CreateTodoRes struct {
+type CreateTodoReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -238,8 +635,8 @@ type CreateTodoRes struct {
 	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
 }
 
-func (x *CreateTodoRes) Reset() {
-	*x = CreateTodoRes{}
+func (x *CreateTodoReq) Reset() {
+	*x = CreateTodoReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_todo_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -247,13 +644,13 @@ func (x *CreateTodoRes) Reset() {
 	}
 }
 
-func (x *CreateTodoRes) String() string {
+func (x *CreateTodoReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTodoRes) ProtoMessage() {}
+func (*CreateTodoReq) ProtoMessage() {}
 
-func (x *CreateTodoRes) ProtoReflect() protoreflect.Message {
+func (x *CreateTodoReq) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -265,29 +662,28 @@ func (x *CreateTodoRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTodoRes.ProtoReflect.Descriptor instead.
-func (*CreateTodoRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTodoReq.ProtoReflect.Descriptor instead.
+func (*CreateTodoReq) Descriptor() ([]byte, []int) {
 	return file_todo_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateTodoRes) GetTodo() *Todo {
+func (x *CreateTodoReq) GetTodo() *Todo {
 	if x != nil {
 		return x.Todo
 	}
 	return nil
 }
 
-type UpdateTodoReq struct {
+type CreateTodoRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Todo      *Todo                 `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
-	FieldMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
 }
 
-func (x *UpdateTodoReq) Reset() {
-	*x = UpdateTodoReq{}
+func (x *CreateTodoRes) Reset() {
+	*x = CreateTodoRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_todo_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -295,13 +691,13 @@ func (x *UpdateTodoReq) Reset() {
 	}
 }
 
-func (x *UpdateTodoReq) String() string {
+func (x *CreateTodoRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTodoReq) ProtoMessage() {}
+func (*CreateTodoRes) ProtoMessage() {}
 
-func (x *UpdateTodoReq) ProtoReflect() protoreflect.Message {
+func (x *CreateTodoRes) ProtoReflect() protoreflect.Message {
 	mi := &file_todo_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -313,21 +709,70 @@ func (x *UpdateTodoReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTodoReq.ProtoReflect.Descriptor instead.
-func (*UpdateTodoReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTodoRes.ProtoReflect.Descriptor instead.
+func (*CreateTodoRes) Descriptor() ([]byte, []int) {
 	return file_todo_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdateTodoReq) GetTodo() *Todo {
+func (x *CreateTodoRes) GetTodo() *Todo {
 	if x != nil {
 		return x.Todo
 	}
 	return nil
 }
 
-func (x *UpdateTodoReq) GetFieldMask() *field_mask.FieldMask {
-	if x != nil {
-		return x.FieldMask
+type UpdateTodoReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	// field_mask defaults to name, description, status and priority
+	FieldMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+}
+
+func (x *UpdateTodoReq) Reset() {
+	*x = UpdateTodoReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTodoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTodoReq) ProtoMessage() {}
+
+func (x *UpdateTodoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTodoReq.ProtoReflect.Descriptor instead.
+func (*UpdateTodoReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateTodoReq) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *UpdateTodoReq) GetFieldMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.FieldMask
 	}
 	return nil
 }
@@ -343,7 +788,7 @@ type UpdateTodoRes struct {
 func (x *UpdateTodoRes) Reset() {
 	*x = UpdateTodoRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[4]
+		mi := &file_todo_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -356,7 +801,7 @@ func (x *UpdateTodoRes) String() string {
 func (*UpdateTodoRes) ProtoMessage() {}
 
 func (x *UpdateTodoRes) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[4]
+	mi := &file_todo_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +814,7 @@ func (x *UpdateTodoRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTodoRes.ProtoReflect.Descriptor instead.
 func (*UpdateTodoRes) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{4}
+	return file_todo_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateTodoRes) GetTodo() *Todo {
@@ -391,7 +836,7 @@ type DeleteTodoReq struct {
 func (x *DeleteTodoReq) Reset() {
 	*x = DeleteTodoReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[5]
+		mi := &file_todo_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -404,7 +849,7 @@ func (x *DeleteTodoReq) String() string {
 func (*DeleteTodoReq) ProtoMessage() {}
 
 func (x *DeleteTodoReq) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[5]
+	mi := &file_todo_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +862,7 @@ func (x *DeleteTodoReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTodoReq.ProtoReflect.Descriptor instead.
 func (*DeleteTodoReq) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{5}
+	return file_todo_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteTodoReq) GetTodoId() string {
@@ -445,7 +890,7 @@ type GetTodoReq struct {
 func (x *GetTodoReq) Reset() {
 	*x = GetTodoReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[6]
+		mi := &file_todo_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -458,7 +903,7 @@ func (x *GetTodoReq) String() string {
 func (*GetTodoReq) ProtoMessage() {}
 
 func (x *GetTodoReq) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[6]
+	mi := &file_todo_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +916,7 @@ func (x *GetTodoReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTodoReq.ProtoReflect.Descriptor instead.
 func (*GetTodoReq) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{6}
+	return file_todo_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetTodoReq) GetTodoId() string {
@@ -485,12 +930,18 @@ type StreamTodoReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Status     StreamTodoReq_StatusFilter `protobuf:"varint,1,opt,name=status,proto3,enum=pb.StreamTodoReq_StatusFilter" json:"status,omitempty"`
+	Priorities []Todo_Priority            `protobuf:"varint,2,rep,packed,name=priorities,proto3,enum=pb.Todo_Priority" json:"priorities,omitempty"`
+	// resume_token continues a previous stream after the last event received,
+	// no snapshot is sent when it is set
+	ResumeToken string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (x *StreamTodoReq) Reset() {
 	*x = StreamTodoReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[7]
+		mi := &file_todo_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +954,7 @@ func (x *StreamTodoReq) String() string {
 func (*StreamTodoReq) ProtoMessage() {}
 
 func (x *StreamTodoReq) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[7]
+	mi := &file_todo_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +967,28 @@ func (x *StreamTodoReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamTodoReq.ProtoReflect.Descriptor instead.
 func (*StreamTodoReq) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{7}
+	return file_todo_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamTodoReq) GetStatus() StreamTodoReq_StatusFilter {
+	if x != nil {
+		return x.Status
+	}
+	return StreamTodoReq_ALL
+}
+
+func (x *StreamTodoReq) GetPriorities() []Todo_Priority {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *StreamTodoReq) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
 }
 
 type ListTodoReq struct {
@@ -525,13 +997,26 @@ type ListTodoReq struct {
 	unknownFields protoimpl.UnknownFields
 
 	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Page  int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// page is 1-based, page n starts after the first (n - 1) * limit todos
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// view_id lists the todos matching a saved or system view
+	ViewId string `protobuf:"bytes,3,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	// time_zone resolves the relative deadlines of the view and the query,
+	// defaults to UTC
+	TimeZone string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// query filters with fields compared to values combined by AND, OR, NOT
+	// and parentheses, e.g. priority:high AND due<7d AND NOT label:someday.
+	// Fields are status, priority, label, assignee, name, due, created and
+	// updated.
+	Query string `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	// include_snoozed also lists the todos hidden until a later time
+	IncludeSnoozed bool `protobuf:"varint,6,opt,name=include_snoozed,json=includeSnoozed,proto3" json:"include_snoozed,omitempty"`
 }
 
 func (x *ListTodoReq) Reset() {
 	*x = ListTodoReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[8]
+		mi := &file_todo_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -544,7 +1029,7 @@ func (x *ListTodoReq) String() string {
 func (*ListTodoReq) ProtoMessage() {}
 
 func (x *ListTodoReq) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[8]
+	mi := &file_todo_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +1042,7 @@ func (x *ListTodoReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTodoReq.ProtoReflect.Descriptor instead.
 func (*ListTodoReq) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{8}
+	return file_todo_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListTodoReq) GetLimit() int32 {
@@ -574,6 +1059,89 @@ func (x *ListTodoReq) GetPage() int32 {
 	return 0
 }
 
+func (x *ListTodoReq) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *ListTodoReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ListTodoReq) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTodoReq) GetIncludeSnoozed() bool {
+	if x != nil {
+		return x.IncludeSnoozed
+	}
+	return false
+}
+
+type ListAssignedTodosReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page  int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListAssignedTodosReq) Reset() {
+	*x = ListAssignedTodosReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAssignedTodosReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignedTodosReq) ProtoMessage() {}
+
+func (x *ListAssignedTodosReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignedTodosReq.ProtoReflect.Descriptor instead.
+func (*ListAssignedTodosReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListAssignedTodosReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAssignedTodosReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
 type ListTodoRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -586,7 +1154,7 @@ type ListTodoRes struct {
 func (x *ListTodoRes) Reset() {
 	*x = ListTodoRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[9]
+		mi := &file_todo_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -599,7 +1167,7 @@ func (x *ListTodoRes) String() string {
 func (*ListTodoRes) ProtoMessage() {}
 
 func (x *ListTodoRes) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[9]
+	mi := &file_todo_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +1180,7 @@ func (x *ListTodoRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTodoRes.ProtoReflect.Descriptor instead.
 func (*ListTodoRes) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{9}
+	return file_todo_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListTodoRes) GetTodos() []*Todo {
@@ -634,14 +1202,18 @@ type StreamTodoRes struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Todo  *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
-	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	// count is the number of todos in the snapshot, set on SNAPSHOT_COMPLETE
+	Count       int32                   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Type        StreamTodoRes_EventType `protobuf:"varint,3,opt,name=type,proto3,enum=pb.StreamTodoRes_EventType" json:"type,omitempty"`
+	TodoId      string                  `protobuf:"bytes,4,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	ResumeToken string                  `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (x *StreamTodoRes) Reset() {
 	*x = StreamTodoRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_todo_service_proto_msgTypes[10]
+		mi := &file_todo_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -654,7 +1226,7 @@ func (x *StreamTodoRes) String() string {
 func (*StreamTodoRes) ProtoMessage() {}
 
 func (x *StreamTodoRes) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_service_proto_msgTypes[10]
+	mi := &file_todo_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +1239,7 @@ func (x *StreamTodoRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamTodoRes.ProtoReflect.Descriptor instead.
 func (*StreamTodoRes) Descriptor() ([]byte, []int) {
-	return file_todo_service_proto_rawDescGZIP(), []int{10}
+	return file_todo_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *StreamTodoRes) GetTodo() *Todo {
@@ -684,183 +1256,2015 @@ func (x *StreamTodoRes) GetCount() int32 {
 	return 0
 }
 
-var File_todo_service_proto protoreflect.FileDescriptor
+func (x *StreamTodoRes) GetType() StreamTodoRes_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return StreamTodoRes_SNAPSHOT
+}
 
-var file_todo_service_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x74, 0x6f, 0x64, 0x6f, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
-	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x03, 0x0a, 0x04, 0x54, 0x6f, 0x64,
-	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x2d, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6f,
-	0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x39,
-	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61,
-	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
-	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
-	0x79, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d,
-	0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x02,
-	0x22, 0x2d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65,
-	0x71, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x22,
-	0x2d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73,
-	0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
-	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x22, 0x68,
-	0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12,
-	0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
-	0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x12, 0x39, 0x0a,
-	0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x2d, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64,
-	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64,
-	0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x22, 0x40, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x64, 0x6f,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x64, 0x6f, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x25, 0x0a, 0x0a, 0x47, 0x65, 0x74,
-	0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x64, 0x6f, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x64, 0x6f, 0x49, 0x64,
-	0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65,
-	0x71, 0x22, 0x37, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x43, 0x0a, 0x0b, 0x4c, 0x69,
-	0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x74, 0x6f, 0x64,
-	0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f,
-	0x64, 0x6f, 0x52, 0x05, 0x74, 0x6f, 0x64, 0x6f, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x43, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73,
-	0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
-	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x12, 0x14,
-	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x32, 0xc3, 0x02, 0x0a, 0x0b, 0x54, 0x6f, 0x64, 0x6f, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f,
-	0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f,
-	0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0a, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11,
-	0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65,
-	0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x25, 0x0a, 0x07, 0x47,
-	0x65, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54,
-	0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f,
-	0x22, 0x00, 0x12, 0x2e, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x0f,
-	0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a,
-	0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73,
-	0x22, 0x00, 0x12, 0x36, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f,
-	0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f,
-	0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
-	0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00, 0x30, 0x01, 0x42, 0x0e, 0x5a, 0x0c, 0x74, 0x6f,
-	0x64, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *StreamTodoRes) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
 }
 
-var (
-	file_todo_service_proto_rawDescOnce sync.Once
-	file_todo_service_proto_rawDescData = file_todo_service_proto_rawDesc
-)
+func (x *StreamTodoRes) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
 
-func file_todo_service_proto_rawDescGZIP() []byte {
-	file_todo_service_proto_rawDescOnce.Do(func() {
-		file_todo_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_todo_service_proto_rawDescData)
-	})
-	return file_todo_service_proto_rawDescData
+type SyncTodosReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sync_token is the token of the previous sync, empty for the first one
+	SyncToken string `protobuf:"bytes,1,opt,name=sync_token,json=syncToken,proto3" json:"sync_token,omitempty"`
+	Limit     int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-var file_todo_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_todo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
-var file_todo_service_proto_goTypes = []interface{}{
-	(Todo_Priority)(0),           // 0: pb.Todo.Priority
-	(*Todo)(nil),                 // 1: pb.Todo
-	(*CreateTodoReq)(nil),        // 2: pb.CreateTodoReq
-	(*CreateTodoRes)(nil),        // 3: pb.CreateTodoRes
-	(*UpdateTodoReq)(nil),        // 4: pb.UpdateTodoReq
-	(*UpdateTodoRes)(nil),        // 5: pb.UpdateTodoRes
-	(*DeleteTodoReq)(nil),        // 6: pb.DeleteTodoReq
-	(*GetTodoReq)(nil),           // 7: pb.GetTodoReq
-	(*StreamTodoReq)(nil),        // 8: pb.StreamTodoReq
-	(*ListTodoReq)(nil),          // 9: pb.ListTodoReq
-	(*ListTodoRes)(nil),          // 10: pb.ListTodoRes
-	(*StreamTodoRes)(nil),        // 11: pb.StreamTodoRes
-	(*timestamp.Timestamp)(nil),  // 12: google.protobuf.Timestamp
-	(*field_mask.FieldMask)(nil), // 13: google.protobuf.FieldMask
-	(*empty.Empty)(nil),          // 14: google.protobuf.Empty
+func (x *SyncTodosReq) Reset() {
+	*x = SyncTodosReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_todo_service_proto_depIdxs = []int32{
-	0,  // 0: pb.Todo.priority:type_name -> pb.Todo.Priority
-	12, // 1: pb.Todo.created_at:type_name -> google.protobuf.Timestamp
-	12, // 2: pb.Todo.deadline:type_name -> google.protobuf.Timestamp
-	12, // 3: pb.Todo.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 4: pb.CreateTodoReq.todo:type_name -> pb.Todo
-	1,  // 5: pb.CreateTodoRes.todo:type_name -> pb.Todo
-	1,  // 6: pb.UpdateTodoReq.todo:type_name -> pb.Todo
-	13, // 7: pb.UpdateTodoReq.field_mask:type_name -> google.protobuf.FieldMask
-	1,  // 8: pb.UpdateTodoRes.todo:type_name -> pb.Todo
-	1,  // 9: pb.ListTodoRes.todos:type_name -> pb.Todo
-	1,  // 10: pb.StreamTodoRes.todo:type_name -> pb.Todo
-	2,  // 11: pb.TodoService.CreateTodo:input_type -> pb.CreateTodoReq
-	4,  // 12: pb.TodoService.UpdateTodo:input_type -> pb.UpdateTodoReq
-	6,  // 13: pb.TodoService.DeleteTodo:input_type -> pb.DeleteTodoReq
-	7,  // 14: pb.TodoService.GetTodo:input_type -> pb.GetTodoReq
-	9,  // 15: pb.TodoService.ListTodo:input_type -> pb.ListTodoReq
-	8,  // 16: pb.TodoService.StreamTodo:input_type -> pb.StreamTodoReq
-	3,  // 17: pb.TodoService.CreateTodo:output_type -> pb.CreateTodoRes
-	5,  // 18: pb.TodoService.UpdateTodo:output_type -> pb.UpdateTodoRes
-	14, // 19: pb.TodoService.DeleteTodo:output_type -> google.protobuf.Empty
-	1,  // 20: pb.TodoService.GetTodo:output_type -> pb.Todo
-	10, // 21: pb.TodoService.ListTodo:output_type -> pb.ListTodoRes
-	11, // 22: pb.TodoService.StreamTodo:output_type -> pb.StreamTodoRes
-	17, // [17:23] is the sub-list for method output_type
-	11, // [11:17] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+func (x *SyncTodosReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_todo_service_proto_init() }
-func file_todo_service_proto_init() {
-	if File_todo_service_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_todo_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Todo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*SyncTodosReq) ProtoMessage() {}
+
+func (x *SyncTodosReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_todo_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTodoReq); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTodosReq.ProtoReflect.Descriptor instead.
+func (*SyncTodosReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SyncTodosReq) GetSyncToken() string {
+	if x != nil {
+		return x.SyncToken
+	}
+	return ""
+}
+
+func (x *SyncTodosReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TodoTombstone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TodoId    string               `protobuf:"bytes,1,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+}
+
+func (x *TodoTombstone) Reset() {
+	*x = TodoTombstone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TodoTombstone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoTombstone) ProtoMessage() {}
+
+func (x *TodoTombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoTombstone.ProtoReflect.Descriptor instead.
+func (*TodoTombstone) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TodoTombstone) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *TodoTombstone) GetDeletedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type SyncTodosRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Todos      []*Todo          `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	Tombstones []*TodoTombstone `protobuf:"bytes,2,rep,name=tombstones,proto3" json:"tombstones,omitempty"`
+	SyncToken  string           `protobuf:"bytes,3,opt,name=sync_token,json=syncToken,proto3" json:"sync_token,omitempty"`
+	// has_more is set when the changes didn't fit in one response, sync again
+	// with the returned token to get the rest
+	HasMore bool `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// full_resync is set when the token is older than the retained tombstones,
+	// the client has to drop its local todos and take the returned ones
+	FullResync bool `protobuf:"varint,5,opt,name=full_resync,json=fullResync,proto3" json:"full_resync,omitempty"`
+}
+
+func (x *SyncTodosRes) Reset() {
+	*x = SyncTodosRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncTodosRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTodosRes) ProtoMessage() {}
+
+func (x *SyncTodosRes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTodosRes.ProtoReflect.Descriptor instead.
+func (*SyncTodosRes) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SyncTodosRes) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *SyncTodosRes) GetTombstones() []*TodoTombstone {
+	if x != nil {
+		return x.Tombstones
+	}
+	return nil
+}
+
+func (x *SyncTodosRes) GetSyncToken() string {
+	if x != nil {
+		return x.SyncToken
+	}
+	return ""
+}
+
+func (x *SyncTodosRes) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *SyncTodosRes) GetFullResync() bool {
+	if x != nil {
+		return x.FullResync
+	}
+	return false
+}
+
+type TodoChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientChangeId string               `protobuf:"bytes,1,opt,name=client_change_id,json=clientChangeId,proto3" json:"client_change_id,omitempty"`
+	Operation      TodoChange_Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.TodoChange_Operation" json:"operation,omitempty"`
+	// todo without an id is created, otherwise the fields in field_mask are
+	// overwritten, with the same default as UpdateTodo
+	Todo        *Todo                 `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	BaseSyncSeq int64                 `protobuf:"varint,4,opt,name=base_sync_seq,json=baseSyncSeq,proto3" json:"base_sync_seq,omitempty"`
+	FieldMask   *field_mask.FieldMask `protobuf:"bytes,5,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+}
+
+func (x *TodoChange) Reset() {
+	*x = TodoChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TodoChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoChange) ProtoMessage() {}
+
+func (x *TodoChange) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoChange.ProtoReflect.Descriptor instead.
+func (*TodoChange) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TodoChange) GetClientChangeId() string {
+	if x != nil {
+		return x.ClientChangeId
+	}
+	return ""
+}
+
+func (x *TodoChange) GetOperation() TodoChange_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return TodoChange_UPSERT
+}
+
+func (x *TodoChange) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *TodoChange) GetBaseSyncSeq() int64 {
+	if x != nil {
+		return x.BaseSyncSeq
+	}
+	return 0
+}
+
+func (x *TodoChange) GetFieldMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+type PushTodoChangesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*TodoChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *PushTodoChangesReq) Reset() {
+	*x = PushTodoChangesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTodoChangesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTodoChangesReq) ProtoMessage() {}
+
+func (x *PushTodoChangesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTodoChangesReq.ProtoReflect.Descriptor instead.
+func (*PushTodoChangesReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PushTodoChangesReq) GetChanges() []*TodoChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type TodoChangeResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientChangeId string                  `protobuf:"bytes,1,opt,name=client_change_id,json=clientChangeId,proto3" json:"client_change_id,omitempty"`
+	Status         TodoChangeResult_Status `protobuf:"varint,2,opt,name=status,proto3,enum=pb.TodoChangeResult_Status" json:"status,omitempty"`
+	// todo is the current server version of the todo
+	Todo    *Todo  `protobuf:"bytes,3,opt,name=todo,proto3" json:"todo,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TodoChangeResult) Reset() {
+	*x = TodoChangeResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TodoChangeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoChangeResult) ProtoMessage() {}
+
+func (x *TodoChangeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoChangeResult.ProtoReflect.Descriptor instead.
+func (*TodoChangeResult) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TodoChangeResult) GetClientChangeId() string {
+	if x != nil {
+		return x.ClientChangeId
+	}
+	return ""
+}
+
+func (x *TodoChangeResult) GetStatus() TodoChangeResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return TodoChangeResult_APPLIED
+}
+
+func (x *TodoChangeResult) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *TodoChangeResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PushTodoChangesRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*TodoChangeResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *PushTodoChangesRes) Reset() {
+	*x = PushTodoChangesRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTodoChangesRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTodoChangesRes) ProtoMessage() {}
+
+func (x *PushTodoChangesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTodoChangesRes.ProtoReflect.Descriptor instead.
+func (*PushTodoChangesRes) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PushTodoChangesRes) GetResults() []*TodoChangeResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the range defaults to the last 30 days and can span at most 366 days
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// time_zone is the IANA name of the zone days are counted in, UTC when empty
+	TimeZone string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+}
+
+func (x *GetStatsReq) Reset() {
+	*x = GetStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsReq) ProtoMessage() {}
+
+func (x *GetStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsReq.ProtoReflect.Descriptor instead.
+func (*GetStatsReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetStatsReq) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetStatsReq) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetStatsReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type DailyStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// date is formatted as YYYY-MM-DD
+	Date      string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Created   int64  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Completed int64  `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	// overdue counts the todos due on the date that weren't done in time
+	Overdue int64 `protobuf:"varint,4,opt,name=overdue,proto3" json:"overdue,omitempty"`
+}
+
+func (x *DailyStats) Reset() {
+	*x = DailyStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyStats) ProtoMessage() {}
+
+func (x *DailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyStats.ProtoReflect.Descriptor instead.
+func (*DailyStats) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DailyStats) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyStats) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *DailyStats) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *DailyStats) GetOverdue() int64 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+type PriorityStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority  Todo_Priority `protobuf:"varint,1,opt,name=priority,proto3,enum=pb.Todo_Priority" json:"priority,omitempty"`
+	Created   int64         `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Completed int64         `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+}
+
+func (x *PriorityStats) Reset() {
+	*x = PriorityStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriorityStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityStats) ProtoMessage() {}
+
+func (x *PriorityStats) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityStats.ProtoReflect.Descriptor instead.
+func (*PriorityStats) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PriorityStats) GetPriority() Todo_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Todo_HIGH
+}
+
+func (x *PriorityStats) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *PriorityStats) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+type GetStatsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days      []*DailyStats `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	Created   int64         `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Completed int64         `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	// completion_rate is the share of the todos created in the range that are
+	// done by now
+	CompletionRate        float64            `protobuf:"fixed64,4,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	AverageTimeToComplete *duration.Duration `protobuf:"bytes,5,opt,name=average_time_to_complete,json=averageTimeToComplete,proto3" json:"average_time_to_complete,omitempty"`
+	// streaks count consecutive days with at least one completed todo
+	CurrentStreak int32            `protobuf:"varint,6,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	LongestStreak int32            `protobuf:"varint,7,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	Priorities    []*PriorityStats `protobuf:"bytes,8,rep,name=priorities,proto3" json:"priorities,omitempty"`
+}
+
+func (x *GetStatsRes) Reset() {
+	*x = GetStatsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRes) ProtoMessage() {}
+
+func (x *GetStatsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRes.ProtoReflect.Descriptor instead.
+func (*GetStatsRes) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetStatsRes) GetDays() []*DailyStats {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetStatsRes) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *GetStatsRes) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetStatsRes) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *GetStatsRes) GetAverageTimeToComplete() *duration.Duration {
+	if x != nil {
+		return x.AverageTimeToComplete
+	}
+	return nil
+}
+
+func (x *GetStatsRes) GetCurrentStreak() int32 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *GetStatsRes) GetLongestStreak() int32 {
+	if x != nil {
+		return x.LongestStreak
+	}
+	return 0
+}
+
+func (x *GetStatsRes) GetPriorities() []*PriorityStats {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+type GetAgendaReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the range defaults to the 7 days starting today and can span at most
+	// 92 days
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// time_zone is the IANA name of the zone days are bucketed in, UTC when
+	// empty
+	TimeZone string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+}
+
+func (x *GetAgendaReq) Reset() {
+	*x = GetAgendaReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAgendaReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgendaReq) ProtoMessage() {}
+
+func (x *GetAgendaReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgendaReq.ProtoReflect.Descriptor instead.
+func (*GetAgendaReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetAgendaReq) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetAgendaReq) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetAgendaReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type AgendaItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Todo *Todo `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	// occurs_at is the deadline of the todo, or of the occurrence for
+	// recurring todos
+	OccursAt  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=occurs_at,json=occursAt,proto3" json:"occurs_at,omitempty"`
+	Recurring bool                 `protobuf:"varint,3,opt,name=recurring,proto3" json:"recurring,omitempty"`
+}
+
+func (x *AgendaItem) Reset() {
+	*x = AgendaItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgendaItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgendaItem) ProtoMessage() {}
+
+func (x *AgendaItem) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgendaItem.ProtoReflect.Descriptor instead.
+func (*AgendaItem) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AgendaItem) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *AgendaItem) GetOccursAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.OccursAt
+	}
+	return nil
+}
+
+func (x *AgendaItem) GetRecurring() bool {
+	if x != nil {
+		return x.Recurring
+	}
+	return false
+}
+
+type PriorityCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority Todo_Priority `protobuf:"varint,1,opt,name=priority,proto3,enum=pb.Todo_Priority" json:"priority,omitempty"`
+	Count    int64         `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *PriorityCount) Reset() {
+	*x = PriorityCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriorityCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityCount) ProtoMessage() {}
+
+func (x *PriorityCount) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityCount.ProtoReflect.Descriptor instead.
+func (*PriorityCount) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PriorityCount) GetPriority() Todo_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Todo_HIGH
+}
+
+func (x *PriorityCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AgendaBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// date is formatted as YYYY-MM-DD, empty for the overdue bucket
+	Date   string           `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Items  []*AgendaItem    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Totals []*PriorityCount `protobuf:"bytes,3,rep,name=totals,proto3" json:"totals,omitempty"`
+}
+
+func (x *AgendaBucket) Reset() {
+	*x = AgendaBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgendaBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgendaBucket) ProtoMessage() {}
+
+func (x *AgendaBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgendaBucket.ProtoReflect.Descriptor instead.
+func (*AgendaBucket) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AgendaBucket) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AgendaBucket) GetItems() []*AgendaItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *AgendaBucket) GetTotals() []*PriorityCount {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+type GetAgendaRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days []*AgendaBucket `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	// overdue holds the open todos whose deadline has passed, recurring todos
+	// once their last occurrence has passed
+	Overdue *AgendaBucket `protobuf:"bytes,2,opt,name=overdue,proto3" json:"overdue,omitempty"`
+}
+
+func (x *GetAgendaRes) Reset() {
+	*x = GetAgendaRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAgendaRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgendaRes) ProtoMessage() {}
+
+func (x *GetAgendaRes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgendaRes.ProtoReflect.Descriptor instead.
+func (*GetAgendaRes) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAgendaRes) GetDays() []*AgendaBucket {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetAgendaRes) GetOverdue() *AgendaBucket {
+	if x != nil {
+		return x.Overdue
+	}
+	return nil
+}
+
+type GetBoardReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupBy BoardGroupBy `protobuf:"varint,1,opt,name=group_by,json=groupBy,proto3,enum=pb.BoardGroupBy" json:"group_by,omitempty"`
+	// limit and page paginate the cards of every column
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page  int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	// column restricts the board to a single column, e.g. to page through it
+	Column *string `protobuf:"bytes,4,opt,name=column,proto3,oneof" json:"column,omitempty"`
+	// view_id, time_zone, query and include_snoozed narrow down the cards as
+	// they do for ListTodo
+	ViewId         string `protobuf:"bytes,5,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	TimeZone       string `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Query          string `protobuf:"bytes,7,opt,name=query,proto3" json:"query,omitempty"`
+	IncludeSnoozed bool   `protobuf:"varint,8,opt,name=include_snoozed,json=includeSnoozed,proto3" json:"include_snoozed,omitempty"`
+}
+
+func (x *GetBoardReq) Reset() {
+	*x = GetBoardReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBoardReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoardReq) ProtoMessage() {}
+
+func (x *GetBoardReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoardReq.ProtoReflect.Descriptor instead.
+func (*GetBoardReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetBoardReq) GetGroupBy() BoardGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return BoardGroupBy_STATUS
+}
+
+func (x *GetBoardReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetBoardReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetBoardReq) GetColumn() string {
+	if x != nil && x.Column != nil {
+		return *x.Column
+	}
+	return ""
+}
+
+func (x *GetBoardReq) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *GetBoardReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *GetBoardReq) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetBoardReq) GetIncludeSnoozed() bool {
+	if x != nil {
+		return x.IncludeSnoozed
+	}
+	return false
+}
+
+type BoardColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key identifies the column: "open" or "done" by status, the priority name
+	// by priority, the user id by assignee and the label by label. The column
+	// of unassigned or unlabeled todos has an empty key.
+	Key   string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count int64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Cards []*Todo `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
+}
+
+func (x *BoardColumn) Reset() {
+	*x = BoardColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoardColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardColumn) ProtoMessage() {}
+
+func (x *BoardColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardColumn.ProtoReflect.Descriptor instead.
+func (*BoardColumn) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BoardColumn) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BoardColumn) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BoardColumn) GetCards() []*Todo {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type GetBoardRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupBy BoardGroupBy   `protobuf:"varint,1,opt,name=group_by,json=groupBy,proto3,enum=pb.BoardGroupBy" json:"group_by,omitempty"`
+	Columns []*BoardColumn `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (x *GetBoardRes) Reset() {
+	*x = GetBoardRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBoardRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoardRes) ProtoMessage() {}
+
+func (x *GetBoardRes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoardRes.ProtoReflect.Descriptor instead.
+func (*GetBoardRes) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetBoardRes) GetGroupBy() BoardGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return BoardGroupBy_STATUS
+}
+
+func (x *GetBoardRes) GetColumns() []*BoardColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type MoveCardReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TodoId  string       `protobuf:"bytes,1,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	GroupBy BoardGroupBy `protobuf:"varint,2,opt,name=group_by,json=groupBy,proto3,enum=pb.BoardGroupBy" json:"group_by,omitempty"`
+	// from_column is only needed by label, as a todo can be in several label
+	// columns
+	FromColumn string `protobuf:"bytes,3,opt,name=from_column,json=fromColumn,proto3" json:"from_column,omitempty"`
+	ToColumn   string `protobuf:"bytes,4,opt,name=to_column,json=toColumn,proto3" json:"to_column,omitempty"`
+	// the card is put between previous_todo_id and next_todo_id, leave one out
+	// to move it to the top or bottom of the column
+	PreviousTodoId string `protobuf:"bytes,5,opt,name=previous_todo_id,json=previousTodoId,proto3" json:"previous_todo_id,omitempty"`
+	NextTodoId     string `protobuf:"bytes,6,opt,name=next_todo_id,json=nextTodoId,proto3" json:"next_todo_id,omitempty"`
+}
+
+func (x *MoveCardReq) Reset() {
+	*x = MoveCardReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveCardReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCardReq) ProtoMessage() {}
+
+func (x *MoveCardReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCardReq.ProtoReflect.Descriptor instead.
+func (*MoveCardReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MoveCardReq) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *MoveCardReq) GetGroupBy() BoardGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return BoardGroupBy_STATUS
+}
+
+func (x *MoveCardReq) GetFromColumn() string {
+	if x != nil {
+		return x.FromColumn
+	}
+	return ""
+}
+
+func (x *MoveCardReq) GetToColumn() string {
+	if x != nil {
+		return x.ToColumn
+	}
+	return ""
+}
+
+func (x *MoveCardReq) GetPreviousTodoId() string {
+	if x != nil {
+		return x.PreviousTodoId
+	}
+	return ""
+}
+
+func (x *MoveCardReq) GetNextTodoId() string {
+	if x != nil {
+		return x.NextTodoId
+	}
+	return ""
+}
+
+type SnoozeTodoReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TodoId string `protobuf:"bytes,1,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	// until has to be in the future, a missing until wakes the todo up
+	Until *timestamp.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *SnoozeTodoReq) Reset() {
+	*x = SnoozeTodoReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_todo_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnoozeTodoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeTodoReq) ProtoMessage() {}
+
+func (x *SnoozeTodoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeTodoReq.ProtoReflect.Descriptor instead.
+func (*SnoozeTodoReq) Descriptor() ([]byte, []int) {
+	return file_todo_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SnoozeTodoReq) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *SnoozeTodoReq) GetUntil() *timestamp.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+var File_todo_service_proto protoreflect.FileDescriptor
+
+var file_todo_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x74, 0x6f, 0x64, 0x6f, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xba, 0x05, 0x0a, 0x04, 0x54, 0x6f, 0x64,
+	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2d, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x79, 0x6e,
+	0x63, 0x53, 0x65, 0x71, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x2e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12,
+	0x39, 0x0a, 0x0a, 0x68, 0x69, 0x64, 0x65, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x68, 0x69, 0x64, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x29, 0x0a, 0x08, 0x50, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x00,
+	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
+	0x4c, 0x4f, 0x57, 0x10, 0x02, 0x22, 0xcd, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x79, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x30, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x39, 0x0a, 0x09, 0x46, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x41, 0x49, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x57, 0x45, 0x45, 0x4b, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x4f, 0x4e, 0x54,
+	0x48, 0x4c, 0x59, 0x10, 0x03, 0x22, 0x2d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04,
+	0x74, 0x6f, 0x64, 0x6f, 0x22, 0x2d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f,
+	0x64, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74,
+	0x6f, 0x64, 0x6f, 0x22, 0x68, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64,
+	0x6f, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f,
+	0x64, 0x6f, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61,
+	0x73, 0x6b, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x2d, 0x0a,
+	0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x1c,
+	0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x22, 0x40, 0x0a, 0x0d,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a,
+	0x07, 0x74, 0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x6f, 0x64, 0x6f, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x25,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07,
+	0x74, 0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x6f, 0x64, 0x6f, 0x49, 0x64, 0x22, 0xca, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x31, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2b, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45,
+	0x10, 0x02, 0x22, 0xac, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52,
+	0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f,
+	0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f,
+	0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x73, 0x6e, 0x6f, 0x6f, 0x7a, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x6e, 0x6f, 0x6f, 0x7a, 0x65,
+	0x64, 0x22, 0x40, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x54, 0x6f, 0x64, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x22, 0x43, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52,
+	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x74, 0x6f, 0x64, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x05, 0x74, 0x6f, 0x64,
+	0x6f, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x0d, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f,
+	0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f,
+	0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2f,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x74, 0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x6f, 0x64, 0x6f, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x57, 0x0a, 0x09, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4e, 0x41, 0x50,
+	0x53, 0x48, 0x4f, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48,
+	0x4f, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x4c, 0x45, 0x54,
+	0x45, 0x44, 0x10, 0x04, 0x22, 0x43, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x64, 0x6f,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x63, 0x0a, 0x0d, 0x54, 0x6f, 0x64,
+	0x6f, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f,
+	0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x64,
+	0x6f, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xbc,
+	0x01, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x64, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x12,
+	0x1e, 0x0a, 0x05, 0x74, 0x6f, 0x64, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x05, 0x74, 0x6f, 0x64, 0x6f, 0x73, 0x12,
+	0x31, 0x0a, 0x0a, 0x74, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x54, 0x6f, 0x6d,
+	0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x0a, 0x74, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x22, 0x90, 0x02,
+	0x0a, 0x0a, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x54,
+	0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x12, 0x22, 0x0a, 0x0d,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x65, 0x71,
+	0x12, 0x39, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
+	0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x23, 0x0a, 0x09, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45,
+	0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01,
+	0x22, 0x3e, 0x0a, 0x12, 0x50, 0x75, 0x73, 0x68, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64,
+	0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x22, 0xea, 0x01, 0x0a, 0x10, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12,
+	0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x04, 0x74, 0x6f,
+	0x64, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3f, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x50, 0x50, 0x4c, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02,
+	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x03, 0x22, 0x44, 0x0a,
+	0x12, 0x50, 0x75, 0x73, 0x68, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f,
+	0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f,
+	0x6e, 0x65, 0x22, 0x72, 0x0a, 0x0a, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6f, 0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f,
+	0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x22, 0x76, 0x0a, 0x0d, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54,
+	0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0xe7,
+	0x02, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
+	0x62, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04, 0x64, 0x61,
+	0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x61, 0x74, 0x65, 0x12, 0x52, 0x0a, 0x18, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x15, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x25,
+	0x0a, 0x0e, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x31, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x52, 0x65, 0x71, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x0a, 0x41, 0x67, 0x65,
+	0x6e, 0x64, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x6f, 0x64, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52,
+	0x04, 0x74, 0x6f, 0x64, 0x6f, 0x12, 0x37, 0x0a, 0x09, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x73, 0x41, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x54, 0x0a, 0x0d,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x73, 0x0a, 0x0c, 0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x64,
+	0x61, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x06,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x62, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x22, 0x60, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67,
+	0x65, 0x6e, 0x64, 0x61, 0x52, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x64,
+	0x61, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x2a, 0x0a,
+	0x07, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x52, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x22, 0x81, 0x02, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x07, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x12, 0x1b, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a,
+	0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a,
+	0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x6e, 0x6f, 0x6f, 0x7a, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x6e, 0x6f, 0x6f, 0x7a,
+	0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0x55, 0x0a,
+	0x0b, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x05, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x22, 0x65, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x52, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79,
+	0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x0b,
+	0x4d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x74,
+	0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f,
+	0x64, 0x6f, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x61, 0x72,
+	0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42,
+	0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x28, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x74, 0x6f, 0x64, 0x6f,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x54, 0x6f, 0x64, 0x6f, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x74, 0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x0d, 0x53,
+	0x6e, 0x6f, 0x6f, 0x7a, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07,
+	0x74, 0x6f, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x6f, 0x64, 0x6f, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x2a, 0x41, 0x0a, 0x0c, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x53, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x45, 0x10, 0x02, 0x12,
+	0x09, 0x0a, 0x05, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x03, 0x32, 0xe6, 0x05, 0x0a, 0x0b, 0x54,
+	0x6f, 0x64, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11,
+	0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65,
+	0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x64,
+	0x6f, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x25, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x0e, 0x2e, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x08, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x6f, 0x64, 0x6f, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f,
+	0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x40, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x54, 0x6f, 0x64, 0x6f, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x6f, 0x64, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x73,
+	0x22, 0x00, 0x12, 0x31, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x64, 0x6f, 0x73, 0x12,
+	0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x64, 0x6f, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x64, 0x6f, 0x73,
+	0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x50, 0x75, 0x73, 0x68, 0x54, 0x6f, 0x64,
+	0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75,
+	0x73, 0x68, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x6f, 0x64, 0x6f, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x2e, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x27, 0x0a,
+	0x08, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x72, 0x64, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x08, 0x2e, 0x70, 0x62, 0x2e,
+	0x54, 0x6f, 0x64, 0x6f, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0a, 0x53, 0x6e, 0x6f, 0x6f, 0x7a, 0x65,
+	0x54, 0x6f, 0x64, 0x6f, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x6e, 0x6f, 0x6f, 0x7a, 0x65,
+	0x54, 0x6f, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x08, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64,
+	0x6f, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c, 0x74, 0x6f, 0x64, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63,
+	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_todo_service_proto_rawDescOnce sync.Once
+	file_todo_service_proto_rawDescData = file_todo_service_proto_rawDesc
+)
+
+func file_todo_service_proto_rawDescGZIP() []byte {
+	file_todo_service_proto_rawDescOnce.Do(func() {
+		file_todo_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_todo_service_proto_rawDescData)
+	})
+	return file_todo_service_proto_rawDescData
+}
+
+var file_todo_service_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_todo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_todo_service_proto_goTypes = []interface{}{
+	(BoardGroupBy)(0),               // 0: pb.BoardGroupBy
+	(Todo_Priority)(0),              // 1: pb.Todo.Priority
+	(Recurrence_Frequency)(0),       // 2: pb.Recurrence.Frequency
+	(StreamTodoReq_StatusFilter)(0), // 3: pb.StreamTodoReq.StatusFilter
+	(StreamTodoRes_EventType)(0),    // 4: pb.StreamTodoRes.EventType
+	(TodoChange_Operation)(0),       // 5: pb.TodoChange.Operation
+	(TodoChangeResult_Status)(0),    // 6: pb.TodoChangeResult.Status
+	(*Todo)(nil),                    // 7: pb.Todo
+	(*Recurrence)(nil),              // 8: pb.Recurrence
+	(*CreateTodoReq)(nil),           // 9: pb.CreateTodoReq
+	(*CreateTodoRes)(nil),           // 10: pb.CreateTodoRes
+	(*UpdateTodoReq)(nil),           // 11: pb.UpdateTodoReq
+	(*UpdateTodoRes)(nil),           // 12: pb.UpdateTodoRes
+	(*DeleteTodoReq)(nil),           // 13: pb.DeleteTodoReq
+	(*GetTodoReq)(nil),              // 14: pb.GetTodoReq
+	(*StreamTodoReq)(nil),           // 15: pb.StreamTodoReq
+	(*ListTodoReq)(nil),             // 16: pb.ListTodoReq
+	(*ListAssignedTodosReq)(nil),    // 17: pb.ListAssignedTodosReq
+	(*ListTodoRes)(nil),             // 18: pb.ListTodoRes
+	(*StreamTodoRes)(nil),           // 19: pb.StreamTodoRes
+	(*SyncTodosReq)(nil),            // 20: pb.SyncTodosReq
+	(*TodoTombstone)(nil),           // 21: pb.TodoTombstone
+	(*SyncTodosRes)(nil),            // 22: pb.SyncTodosRes
+	(*TodoChange)(nil),              // 23: pb.TodoChange
+	(*PushTodoChangesReq)(nil),      // 24: pb.PushTodoChangesReq
+	(*TodoChangeResult)(nil),        // 25: pb.TodoChangeResult
+	(*PushTodoChangesRes)(nil),      // 26: pb.PushTodoChangesRes
+	(*GetStatsReq)(nil),             // 27: pb.GetStatsReq
+	(*DailyStats)(nil),              // 28: pb.DailyStats
+	(*PriorityStats)(nil),           // 29: pb.PriorityStats
+	(*GetStatsRes)(nil),             // 30: pb.GetStatsRes
+	(*GetAgendaReq)(nil),            // 31: pb.GetAgendaReq
+	(*AgendaItem)(nil),              // 32: pb.AgendaItem
+	(*PriorityCount)(nil),           // 33: pb.PriorityCount
+	(*AgendaBucket)(nil),            // 34: pb.AgendaBucket
+	(*GetAgendaRes)(nil),            // 35: pb.GetAgendaRes
+	(*GetBoardReq)(nil),             // 36: pb.GetBoardReq
+	(*BoardColumn)(nil),             // 37: pb.BoardColumn
+	(*GetBoardRes)(nil),             // 38: pb.GetBoardRes
+	(*MoveCardReq)(nil),             // 39: pb.MoveCardReq
+	(*SnoozeTodoReq)(nil),           // 40: pb.SnoozeTodoReq
+	(*timestamp.Timestamp)(nil),     // 41: google.protobuf.Timestamp
+	(*field_mask.FieldMask)(nil),    // 42: google.protobuf.FieldMask
+	(*duration.Duration)(nil),       // 43: google.protobuf.Duration
+	(*empty.Empty)(nil),             // 44: google.protobuf.Empty
+}
+var file_todo_service_proto_depIdxs = []int32{
+	1,  // 0: pb.Todo.priority:type_name -> pb.Todo.Priority
+	41, // 1: pb.Todo.created_at:type_name -> google.protobuf.Timestamp
+	41, // 2: pb.Todo.deadline:type_name -> google.protobuf.Timestamp
+	41, // 3: pb.Todo.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 4: pb.Todo.completed_at:type_name -> google.protobuf.Timestamp
+	8,  // 5: pb.Todo.recurrence:type_name -> pb.Recurrence
+	41, // 6: pb.Todo.hide_until:type_name -> google.protobuf.Timestamp
+	2,  // 7: pb.Recurrence.frequency:type_name -> pb.Recurrence.Frequency
+	41, // 8: pb.Recurrence.until:type_name -> google.protobuf.Timestamp
+	7,  // 9: pb.CreateTodoReq.todo:type_name -> pb.Todo
+	7,  // 10: pb.CreateTodoRes.todo:type_name -> pb.Todo
+	7,  // 11: pb.UpdateTodoReq.todo:type_name -> pb.Todo
+	42, // 12: pb.UpdateTodoReq.field_mask:type_name -> google.protobuf.FieldMask
+	7,  // 13: pb.UpdateTodoRes.todo:type_name -> pb.Todo
+	3,  // 14: pb.StreamTodoReq.status:type_name -> pb.StreamTodoReq.StatusFilter
+	1,  // 15: pb.StreamTodoReq.priorities:type_name -> pb.Todo.Priority
+	7,  // 16: pb.ListTodoRes.todos:type_name -> pb.Todo
+	7,  // 17: pb.StreamTodoRes.todo:type_name -> pb.Todo
+	4,  // 18: pb.StreamTodoRes.type:type_name -> pb.StreamTodoRes.EventType
+	41, // 19: pb.TodoTombstone.deleted_at:type_name -> google.protobuf.Timestamp
+	7,  // 20: pb.SyncTodosRes.todos:type_name -> pb.Todo
+	21, // 21: pb.SyncTodosRes.tombstones:type_name -> pb.TodoTombstone
+	5,  // 22: pb.TodoChange.operation:type_name -> pb.TodoChange.Operation
+	7,  // 23: pb.TodoChange.todo:type_name -> pb.Todo
+	42, // 24: pb.TodoChange.field_mask:type_name -> google.protobuf.FieldMask
+	23, // 25: pb.PushTodoChangesReq.changes:type_name -> pb.TodoChange
+	6,  // 26: pb.TodoChangeResult.status:type_name -> pb.TodoChangeResult.Status
+	7,  // 27: pb.TodoChangeResult.todo:type_name -> pb.Todo
+	25, // 28: pb.PushTodoChangesRes.results:type_name -> pb.TodoChangeResult
+	41, // 29: pb.GetStatsReq.start_time:type_name -> google.protobuf.Timestamp
+	41, // 30: pb.GetStatsReq.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 31: pb.PriorityStats.priority:type_name -> pb.Todo.Priority
+	28, // 32: pb.GetStatsRes.days:type_name -> pb.DailyStats
+	43, // 33: pb.GetStatsRes.average_time_to_complete:type_name -> google.protobuf.Duration
+	29, // 34: pb.GetStatsRes.priorities:type_name -> pb.PriorityStats
+	41, // 35: pb.GetAgendaReq.start_time:type_name -> google.protobuf.Timestamp
+	41, // 36: pb.GetAgendaReq.end_time:type_name -> google.protobuf.Timestamp
+	7,  // 37: pb.AgendaItem.todo:type_name -> pb.Todo
+	41, // 38: pb.AgendaItem.occurs_at:type_name -> google.protobuf.Timestamp
+	1,  // 39: pb.PriorityCount.priority:type_name -> pb.Todo.Priority
+	32, // 40: pb.AgendaBucket.items:type_name -> pb.AgendaItem
+	33, // 41: pb.AgendaBucket.totals:type_name -> pb.PriorityCount
+	34, // 42: pb.GetAgendaRes.days:type_name -> pb.AgendaBucket
+	34, // 43: pb.GetAgendaRes.overdue:type_name -> pb.AgendaBucket
+	0,  // 44: pb.GetBoardReq.group_by:type_name -> pb.BoardGroupBy
+	7,  // 45: pb.BoardColumn.cards:type_name -> pb.Todo
+	0,  // 46: pb.GetBoardRes.group_by:type_name -> pb.BoardGroupBy
+	37, // 47: pb.GetBoardRes.columns:type_name -> pb.BoardColumn
+	0,  // 48: pb.MoveCardReq.group_by:type_name -> pb.BoardGroupBy
+	41, // 49: pb.SnoozeTodoReq.until:type_name -> google.protobuf.Timestamp
+	9,  // 50: pb.TodoService.CreateTodo:input_type -> pb.CreateTodoReq
+	11, // 51: pb.TodoService.UpdateTodo:input_type -> pb.UpdateTodoReq
+	13, // 52: pb.TodoService.DeleteTodo:input_type -> pb.DeleteTodoReq
+	14, // 53: pb.TodoService.GetTodo:input_type -> pb.GetTodoReq
+	16, // 54: pb.TodoService.ListTodo:input_type -> pb.ListTodoReq
+	15, // 55: pb.TodoService.StreamTodo:input_type -> pb.StreamTodoReq
+	17, // 56: pb.TodoService.ListAssignedTodos:input_type -> pb.ListAssignedTodosReq
+	20, // 57: pb.TodoService.SyncTodos:input_type -> pb.SyncTodosReq
+	24, // 58: pb.TodoService.PushTodoChanges:input_type -> pb.PushTodoChangesReq
+	27, // 59: pb.TodoService.GetStats:input_type -> pb.GetStatsReq
+	31, // 60: pb.TodoService.GetAgenda:input_type -> pb.GetAgendaReq
+	36, // 61: pb.TodoService.GetBoard:input_type -> pb.GetBoardReq
+	39, // 62: pb.TodoService.MoveCard:input_type -> pb.MoveCardReq
+	40, // 63: pb.TodoService.SnoozeTodo:input_type -> pb.SnoozeTodoReq
+	10, // 64: pb.TodoService.CreateTodo:output_type -> pb.CreateTodoRes
+	12, // 65: pb.TodoService.UpdateTodo:output_type -> pb.UpdateTodoRes
+	44, // 66: pb.TodoService.DeleteTodo:output_type -> google.protobuf.Empty
+	7,  // 67: pb.TodoService.GetTodo:output_type -> pb.Todo
+	18, // 68: pb.TodoService.ListTodo:output_type -> pb.ListTodoRes
+	19, // 69: pb.TodoService.StreamTodo:output_type -> pb.StreamTodoRes
+	18, // 70: pb.TodoService.ListAssignedTodos:output_type -> pb.ListTodoRes
+	22, // 71: pb.TodoService.SyncTodos:output_type -> pb.SyncTodosRes
+	26, // 72: pb.TodoService.PushTodoChanges:output_type -> pb.PushTodoChangesRes
+	30, // 73: pb.TodoService.GetStats:output_type -> pb.GetStatsRes
+	35, // 74: pb.TodoService.GetAgenda:output_type -> pb.GetAgendaRes
+	38, // 75: pb.TodoService.GetBoard:output_type -> pb.GetBoardRes
+	7,  // 76: pb.TodoService.MoveCard:output_type -> pb.Todo
+	7,  // 77: pb.TodoService.SnoozeTodo:output_type -> pb.Todo
+	64, // [64:78] is the sub-list for method output_type
+	50, // [50:64] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
+}
+
+func init() { file_todo_service_proto_init() }
+func file_todo_service_proto_init() {
+	if File_todo_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_todo_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Todo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recurrence); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -872,7 +3276,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTodoRes); i {
+			switch v := v.(*CreateTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -884,7 +3288,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateTodoReq); i {
+			switch v := v.(*CreateTodoRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -896,7 +3300,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateTodoRes); i {
+			switch v := v.(*UpdateTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -908,7 +3312,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteTodoReq); i {
+			switch v := v.(*UpdateTodoRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -920,7 +3324,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTodoReq); i {
+			switch v := v.(*DeleteTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -932,7 +3336,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamTodoReq); i {
+			switch v := v.(*GetTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -944,7 +3348,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListTodoReq); i {
+			switch v := v.(*StreamTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -956,7 +3360,7 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListTodoRes); i {
+			switch v := v.(*ListTodoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -968,6 +3372,30 @@ func file_todo_service_proto_init() {
 			}
 		}
 		file_todo_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAssignedTodosReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTodoRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamTodoRes); i {
 			case 0:
 				return &v.state
@@ -979,14 +3407,267 @@ func file_todo_service_proto_init() {
 				return nil
 			}
 		}
+		file_todo_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncTodosReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TodoTombstone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncTodosRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TodoChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushTodoChangesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TodoChangeResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushTodoChangesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DailyStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriorityStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAgendaReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgendaItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriorityCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgendaBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAgendaRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBoardReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoardColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBoardRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveCardReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_todo_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnoozeTodoReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_todo_service_proto_msgTypes[29].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_todo_service_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      7,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,14 @@ type TodoServiceClient interface {
 	GetTodo(ctx context.Context, in *GetTodoReq, opts ...grpc.CallOption) (*Todo, error)
 	ListTodo(ctx context.Context, in *ListTodoReq, opts ...grpc.CallOption) (*ListTodoRes, error)
 	StreamTodo(ctx context.Context, in *StreamTodoReq, opts ...grpc.CallOption) (TodoService_StreamTodoClient, error)
+	ListAssignedTodos(ctx context.Context, in *ListAssignedTodosReq, opts ...grpc.CallOption) (*ListTodoRes, error)
+	SyncTodos(ctx context.Context, in *SyncTodosReq, opts ...grpc.CallOption) (*SyncTodosRes, error)
+	PushTodoChanges(ctx context.Context, in *PushTodoChangesReq, opts ...grpc.CallOption) (*PushTodoChangesRes, error)
+	GetStats(ctx context.Context, in *GetStatsReq, opts ...grpc.CallOption) (*GetStatsRes, error)
+	GetAgenda(ctx context.Context, in *GetAgendaReq, opts ...grpc.CallOption) (*GetAgendaRes, error)
+	GetBoard(ctx context.Context, in *GetBoardReq, opts ...grpc.CallOption) (*GetBoardRes, error)
+	MoveCard(ctx context.Context, in *MoveCardReq, opts ...grpc.CallOption) (*Todo, error)
+	SnoozeTodo(ctx context.Context, in *SnoozeTodoReq, opts ...grpc.CallOption) (*Todo, error)
 }
 
 type todoServiceClient struct {
@@ -116,6 +124,78 @@ func (x *todoServiceStreamTodoClient) Recv() (*StreamTodoRes, error) {
 	return m, nil
 }
 
+func (c *todoServiceClient) ListAssignedTodos(ctx context.Context, in *ListAssignedTodosReq, opts ...grpc.CallOption) (*ListTodoRes, error) {
+	out := new(ListTodoRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/ListAssignedTodos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) SyncTodos(ctx context.Context, in *SyncTodosReq, opts ...grpc.CallOption) (*SyncTodosRes, error) {
+	out := new(SyncTodosRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/SyncTodos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) PushTodoChanges(ctx context.Context, in *PushTodoChangesReq, opts ...grpc.CallOption) (*PushTodoChangesRes, error) {
+	out := new(PushTodoChangesRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/PushTodoChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetStats(ctx context.Context, in *GetStatsReq, opts ...grpc.CallOption) (*GetStatsRes, error) {
+	out := new(GetStatsRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetAgenda(ctx context.Context, in *GetAgendaReq, opts ...grpc.CallOption) (*GetAgendaRes, error) {
+	out := new(GetAgendaRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/GetAgenda", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetBoard(ctx context.Context, in *GetBoardReq, opts ...grpc.CallOption) (*GetBoardRes, error) {
+	out := new(GetBoardRes)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/GetBoard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) MoveCard(ctx context.Context, in *MoveCardReq, opts ...grpc.CallOption) (*Todo, error) {
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/MoveCard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) SnoozeTodo(ctx context.Context, in *SnoozeTodoReq, opts ...grpc.CallOption) (*Todo, error) {
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, "/pb.TodoService/SnoozeTodo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TodoServiceServer is the server API for TodoService service.
 // All implementations must embed UnimplementedTodoServiceServer
 // for forward compatibility
@@ -126,6 +206,14 @@ type TodoServiceServer interface {
 	GetTodo(context.Context, *GetTodoReq) (*Todo, error)
 	ListTodo(context.Context, *ListTodoReq) (*ListTodoRes, error)
 	StreamTodo(*StreamTodoReq, TodoService_StreamTodoServer) error
+	ListAssignedTodos(context.Context, *ListAssignedTodosReq) (*ListTodoRes, error)
+	SyncTodos(context.Context, *SyncTodosReq) (*SyncTodosRes, error)
+	PushTodoChanges(context.Context, *PushTodoChangesReq) (*PushTodoChangesRes, error)
+	GetStats(context.Context, *GetStatsReq) (*GetStatsRes, error)
+	GetAgenda(context.Context, *GetAgendaReq) (*GetAgendaRes, error)
+	GetBoard(context.Context, *GetBoardReq) (*GetBoardRes, error)
+	MoveCard(context.Context, *MoveCardReq) (*Todo, error)
+	SnoozeTodo(context.Context, *SnoozeTodoReq) (*Todo, error)
 	mustEmbedUnimplementedTodoServiceServer()
 }
 
@@ -151,6 +239,30 @@ func (UnimplementedTodoServiceServer) ListTodo(context.Context, *ListTodoReq) (*
 func (UnimplementedTodoServiceServer) StreamTodo(*StreamTodoReq, TodoService_StreamTodoServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamTodo not implemented")
 }
+func (UnimplementedTodoServiceServer) ListAssignedTodos(context.Context, *ListAssignedTodosReq) (*ListTodoRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignedTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) SyncTodos(context.Context, *SyncTodosReq) (*SyncTodosRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) PushTodoChanges(context.Context, *PushTodoChangesReq) (*PushTodoChangesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushTodoChanges not implemented")
+}
+func (UnimplementedTodoServiceServer) GetStats(context.Context, *GetStatsReq) (*GetStatsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedTodoServiceServer) GetAgenda(context.Context, *GetAgendaReq) (*GetAgendaRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgenda not implemented")
+}
+func (UnimplementedTodoServiceServer) GetBoard(context.Context, *GetBoardReq) (*GetBoardRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoard not implemented")
+}
+func (UnimplementedTodoServiceServer) MoveCard(context.Context, *MoveCardReq) (*Todo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCard not implemented")
+}
+func (UnimplementedTodoServiceServer) SnoozeTodo(context.Context, *SnoozeTodoReq) (*Todo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeTodo not implemented")
+}
 func (UnimplementedTodoServiceServer) mustEmbedUnimplementedTodoServiceServer() {}
 
 // UnsafeTodoServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -275,6 +387,150 @@ func (x *todoServiceStreamTodoServer) Send(m *StreamTodoRes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TodoService_ListAssignedTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignedTodosReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListAssignedTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/ListAssignedTodos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListAssignedTodos(ctx, req.(*ListAssignedTodosReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_SyncTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncTodosReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).SyncTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/SyncTodos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).SyncTodos(ctx, req.(*SyncTodosReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_PushTodoChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushTodoChangesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).PushTodoChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/PushTodoChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).PushTodoChanges(ctx, req.(*PushTodoChangesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetStats(ctx, req.(*GetStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetAgenda_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgendaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetAgenda(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/GetAgenda",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetAgenda(ctx, req.(*GetAgendaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoardReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/GetBoard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetBoard(ctx, req.(*GetBoardReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_MoveCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCardReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).MoveCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/MoveCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).MoveCard(ctx, req.(*MoveCardReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_SnoozeTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeTodoReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).SnoozeTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.TodoService/SnoozeTodo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).SnoozeTodo(ctx, req.(*SnoozeTodoReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TodoService_ServiceDesc is the grpc.ServiceDesc for TodoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -302,6 +558,38 @@ var TodoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTodo",
 			Handler:    _TodoService_ListTodo_Handler,
 		},
+		{
+			MethodName: "ListAssignedTodos",
+			Handler:    _TodoService_ListAssignedTodos_Handler,
+		},
+		{
+			MethodName: "SyncTodos",
+			Handler:    _TodoService_SyncTodos_Handler,
+		},
+		{
+			MethodName: "PushTodoChanges",
+			Handler:    _TodoService_PushTodoChanges_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _TodoService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetAgenda",
+			Handler:    _TodoService_GetAgenda_Handler,
+		},
+		{
+			MethodName: "GetBoard",
+			Handler:    _TodoService_GetBoard_Handler,
+		},
+		{
+			MethodName: "MoveCard",
+			Handler:    _TodoService_MoveCard_Handler,
+		},
+		{
+			MethodName: "SnoozeTodo",
+			Handler:    _TodoService_SnoozeTodo_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,1045 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: view-service.proto
+
+package pb
+
+import (
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TodoQuery_StatusFilter int32
+
+const (
+	TodoQuery_ALL  TodoQuery_StatusFilter = 0
+	TodoQuery_OPEN TodoQuery_StatusFilter = 1
+	TodoQuery_DONE TodoQuery_StatusFilter = 2
+)
+
+// Enum value maps for TodoQuery_StatusFilter.
+var (
+	TodoQuery_StatusFilter_name = map[int32]string{
+		0: "ALL",
+		1: "OPEN",
+		2: "DONE",
+	}
+	TodoQuery_StatusFilter_value = map[string]int32{
+		"ALL":  0,
+		"OPEN": 1,
+		"DONE": 2,
+	}
+)
+
+func (x TodoQuery_StatusFilter) Enum() *TodoQuery_StatusFilter {
+	p := new(TodoQuery_StatusFilter)
+	*p = x
+	return p
+}
+
+func (x TodoQuery_StatusFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoQuery_StatusFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TodoQuery_StatusFilter) Type() protoreflect.EnumType {
+	return &file_view_service_proto_enumTypes[0]
+}
+
+func (x TodoQuery_StatusFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoQuery_StatusFilter.Descriptor instead.
+func (TodoQuery_StatusFilter) EnumDescriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type TodoQuery_AssigneeFilter int32
+
+const (
+	TodoQuery_ANY_ASSIGNEE TodoQuery_AssigneeFilter = 0
+	TodoQuery_ME           TodoQuery_AssigneeFilter = 1
+	TodoQuery_OTHERS       TodoQuery_AssigneeFilter = 2
+	TodoQuery_UNASSIGNED   TodoQuery_AssigneeFilter = 3
+)
+
+// Enum value maps for TodoQuery_AssigneeFilter.
+var (
+	TodoQuery_AssigneeFilter_name = map[int32]string{
+		0: "ANY_ASSIGNEE",
+		1: "ME",
+		2: "OTHERS",
+		3: "UNASSIGNED",
+	}
+	TodoQuery_AssigneeFilter_value = map[string]int32{
+		"ANY_ASSIGNEE": 0,
+		"ME":           1,
+		"OTHERS":       2,
+		"UNASSIGNED":   3,
+	}
+)
+
+func (x TodoQuery_AssigneeFilter) Enum() *TodoQuery_AssigneeFilter {
+	p := new(TodoQuery_AssigneeFilter)
+	*p = x
+	return p
+}
+
+func (x TodoQuery_AssigneeFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoQuery_AssigneeFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_service_proto_enumTypes[1].Descriptor()
+}
+
+func (TodoQuery_AssigneeFilter) Type() protoreflect.EnumType {
+	return &file_view_service_proto_enumTypes[1]
+}
+
+func (x TodoQuery_AssigneeFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoQuery_AssigneeFilter.Descriptor instead.
+func (TodoQuery_AssigneeFilter) EnumDescriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type TodoQuery_DeadlineWindow int32
+
+const (
+	TodoQuery_ANY_DEADLINE TodoQuery_DeadlineWindow = 0
+	TodoQuery_TODAY        TodoQuery_DeadlineWindow = 1
+	TodoQuery_THIS_WEEK    TodoQuery_DeadlineWindow = 2
+	TodoQuery_UPCOMING     TodoQuery_DeadlineWindow = 3
+	TodoQuery_OVERDUE      TodoQuery_DeadlineWindow = 4
+	TodoQuery_NO_DEADLINE  TodoQuery_DeadlineWindow = 5
+)
+
+// Enum value maps for TodoQuery_DeadlineWindow.
+var (
+	TodoQuery_DeadlineWindow_name = map[int32]string{
+		0: "ANY_DEADLINE",
+		1: "TODAY",
+		2: "THIS_WEEK",
+		3: "UPCOMING",
+		4: "OVERDUE",
+		5: "NO_DEADLINE",
+	}
+	TodoQuery_DeadlineWindow_value = map[string]int32{
+		"ANY_DEADLINE": 0,
+		"TODAY":        1,
+		"THIS_WEEK":    2,
+		"UPCOMING":     3,
+		"OVERDUE":      4,
+		"NO_DEADLINE":  5,
+	}
+)
+
+func (x TodoQuery_DeadlineWindow) Enum() *TodoQuery_DeadlineWindow {
+	p := new(TodoQuery_DeadlineWindow)
+	*p = x
+	return p
+}
+
+func (x TodoQuery_DeadlineWindow) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoQuery_DeadlineWindow) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_service_proto_enumTypes[2].Descriptor()
+}
+
+func (TodoQuery_DeadlineWindow) Type() protoreflect.EnumType {
+	return &file_view_service_proto_enumTypes[2]
+}
+
+func (x TodoQuery_DeadlineWindow) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoQuery_DeadlineWindow.Descriptor instead.
+func (TodoQuery_DeadlineWindow) EnumDescriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0, 2}
+}
+
+type TodoQuery_SortField int32
+
+const (
+	TodoQuery_CREATED_AT TodoQuery_SortField = 0
+	TodoQuery_UPDATED_AT TodoQuery_SortField = 1
+	TodoQuery_DEADLINE   TodoQuery_SortField = 2
+	TodoQuery_NAME       TodoQuery_SortField = 3
+	TodoQuery_RANK       TodoQuery_SortField = 4
+)
+
+// Enum value maps for TodoQuery_SortField.
+var (
+	TodoQuery_SortField_name = map[int32]string{
+		0: "CREATED_AT",
+		1: "UPDATED_AT",
+		2: "DEADLINE",
+		3: "NAME",
+		4: "RANK",
+	}
+	TodoQuery_SortField_value = map[string]int32{
+		"CREATED_AT": 0,
+		"UPDATED_AT": 1,
+		"DEADLINE":   2,
+		"NAME":       3,
+		"RANK":       4,
+	}
+)
+
+func (x TodoQuery_SortField) Enum() *TodoQuery_SortField {
+	p := new(TodoQuery_SortField)
+	*p = x
+	return p
+}
+
+func (x TodoQuery_SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoQuery_SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_service_proto_enumTypes[3].Descriptor()
+}
+
+func (TodoQuery_SortField) Type() protoreflect.EnumType {
+	return &file_view_service_proto_enumTypes[3]
+}
+
+func (x TodoQuery_SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoQuery_SortField.Descriptor instead.
+func (TodoQuery_SortField) EnumDescriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0, 3}
+}
+
+type TodoQuery_SortOrder int32
+
+const (
+	TodoQuery_DEFAULT_ORDER TodoQuery_SortOrder = 0
+	TodoQuery_ASCENDING     TodoQuery_SortOrder = 1
+	TodoQuery_DESCENDING    TodoQuery_SortOrder = 2
+)
+
+// Enum value maps for TodoQuery_SortOrder.
+var (
+	TodoQuery_SortOrder_name = map[int32]string{
+		0: "DEFAULT_ORDER",
+		1: "ASCENDING",
+		2: "DESCENDING",
+	}
+	TodoQuery_SortOrder_value = map[string]int32{
+		"DEFAULT_ORDER": 0,
+		"ASCENDING":     1,
+		"DESCENDING":    2,
+	}
+)
+
+func (x TodoQuery_SortOrder) Enum() *TodoQuery_SortOrder {
+	p := new(TodoQuery_SortOrder)
+	*p = x
+	return p
+}
+
+func (x TodoQuery_SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TodoQuery_SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_service_proto_enumTypes[4].Descriptor()
+}
+
+func (TodoQuery_SortOrder) Type() protoreflect.EnumType {
+	return &file_view_service_proto_enumTypes[4]
+}
+
+func (x TodoQuery_SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TodoQuery_SortOrder.Descriptor instead.
+func (TodoQuery_SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0, 4}
+}
+
+type TodoQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status     TodoQuery_StatusFilter   `protobuf:"varint,1,opt,name=status,proto3,enum=pb.TodoQuery_StatusFilter" json:"status,omitempty"`
+	Priorities []Todo_Priority          `protobuf:"varint,2,rep,packed,name=priorities,proto3,enum=pb.Todo_Priority" json:"priorities,omitempty"`
+	Labels     []string                 `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	Assignee   TodoQuery_AssigneeFilter `protobuf:"varint,4,opt,name=assignee,proto3,enum=pb.TodoQuery_AssigneeFilter" json:"assignee,omitempty"`
+	Deadline   TodoQuery_DeadlineWindow `protobuf:"varint,5,opt,name=deadline,proto3,enum=pb.TodoQuery_DeadlineWindow" json:"deadline,omitempty"`
+	SortBy     TodoQuery_SortField      `protobuf:"varint,6,opt,name=sort_by,json=sortBy,proto3,enum=pb.TodoQuery_SortField" json:"sort_by,omitempty"`
+	SortOrder  TodoQuery_SortOrder      `protobuf:"varint,7,opt,name=sort_order,json=sortOrder,proto3,enum=pb.TodoQuery_SortOrder" json:"sort_order,omitempty"`
+}
+
+func (x *TodoQuery) Reset() {
+	*x = TodoQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TodoQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoQuery) ProtoMessage() {}
+
+func (x *TodoQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoQuery.ProtoReflect.Descriptor instead.
+func (*TodoQuery) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TodoQuery) GetStatus() TodoQuery_StatusFilter {
+	if x != nil {
+		return x.Status
+	}
+	return TodoQuery_ALL
+}
+
+func (x *TodoQuery) GetPriorities() []Todo_Priority {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *TodoQuery) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *TodoQuery) GetAssignee() TodoQuery_AssigneeFilter {
+	if x != nil {
+		return x.Assignee
+	}
+	return TodoQuery_ANY_ASSIGNEE
+}
+
+func (x *TodoQuery) GetDeadline() TodoQuery_DeadlineWindow {
+	if x != nil {
+		return x.Deadline
+	}
+	return TodoQuery_ANY_DEADLINE
+}
+
+func (x *TodoQuery) GetSortBy() TodoQuery_SortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return TodoQuery_CREATED_AT
+}
+
+func (x *TodoQuery) GetSortOrder() TodoQuery_SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return TodoQuery_DEFAULT_ORDER
+}
+
+type SavedView struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Query       *TodoQuery `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Shared      bool       `protobuf:"varint,4,opt,name=shared,proto3" json:"shared,omitempty"`
+	OwnerId     string     `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	WorkspaceId string     `protobuf:"bytes,6,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// system views ship with the service and can't be changed
+	System    bool                 `protobuf:"varint,7,opt,name=system,proto3" json:"system,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *SavedView) Reset() {
+	*x = SavedView{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedView) ProtoMessage() {}
+
+func (x *SavedView) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedView.ProtoReflect.Descriptor instead.
+func (*SavedView) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SavedView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedView) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedView) GetQuery() *TodoQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *SavedView) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *SavedView) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *SavedView) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SavedView) GetSystem() bool {
+	if x != nil {
+		return x.System
+	}
+	return false
+}
+
+func (x *SavedView) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SavedView) GetUpdatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateViewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Query  *TodoQuery `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Shared bool       `protobuf:"varint,3,opt,name=shared,proto3" json:"shared,omitempty"`
+}
+
+func (x *CreateViewReq) Reset() {
+	*x = CreateViewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViewReq) ProtoMessage() {}
+
+func (x *CreateViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViewReq.ProtoReflect.Descriptor instead.
+func (*CreateViewReq) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateViewReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateViewReq) GetQuery() *TodoQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *CreateViewReq) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+type ListViewsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListViewsReq) Reset() {
+	*x = ListViewsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListViewsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsReq) ProtoMessage() {}
+
+func (x *ListViewsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsReq.ProtoReflect.Descriptor instead.
+func (*ListViewsReq) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{3}
+}
+
+type ListViewsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Views []*SavedView `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+}
+
+func (x *ListViewsRes) Reset() {
+	*x = ListViewsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListViewsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsRes) ProtoMessage() {}
+
+func (x *ListViewsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsRes.ProtoReflect.Descriptor instead.
+func (*ListViewsRes) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListViewsRes) GetViews() []*SavedView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type UpdateViewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewId string     `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	Name   string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Query  *TodoQuery `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Shared bool       `protobuf:"varint,4,opt,name=shared,proto3" json:"shared,omitempty"`
+}
+
+func (x *UpdateViewReq) Reset() {
+	*x = UpdateViewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViewReq) ProtoMessage() {}
+
+func (x *UpdateViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViewReq.ProtoReflect.Descriptor instead.
+func (*UpdateViewReq) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateViewReq) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *UpdateViewReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateViewReq) GetQuery() *TodoQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *UpdateViewReq) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+type DeleteViewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewId string `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+}
+
+func (x *DeleteViewReq) Reset() {
+	*x = DeleteViewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewReq) ProtoMessage() {}
+
+func (x *DeleteViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_view_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewReq.ProtoReflect.Descriptor instead.
+func (*DeleteViewReq) Descriptor() ([]byte, []int) {
+	return file_view_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteViewReq) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+var File_view_service_proto protoreflect.FileDescriptor
+
+var file_view_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x76, 0x69, 0x65, 0x77, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x74, 0x6f, 0x64, 0x6f, 0x2d, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd5, 0x05, 0x0a, 0x09, 0x54,
+	0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f,
+	0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31, 0x0a, 0x0a,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x54,
+	0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x65, 0x12, 0x38, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73,
+	0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x6f, 0x72, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x36, 0x0a,
+	0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f, 0x72, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x2b, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45,
+	0x10, 0x02, 0x22, 0x46, 0x0a, 0x0e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x4e, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x49,
+	0x47, 0x4e, 0x45, 0x45, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x53, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x4e,
+	0x41, 0x53, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10, 0x03, 0x22, 0x68, 0x0a, 0x0e, 0x44, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x0c,
+	0x41, 0x4e, 0x59, 0x5f, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x54, 0x4f, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x48, 0x49,
+	0x53, 0x5f, 0x57, 0x45, 0x45, 0x4b, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x50, 0x43, 0x4f,
+	0x4d, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x56, 0x45, 0x52, 0x44, 0x55,
+	0x45, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x4f, 0x5f, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49,
+	0x4e, 0x45, 0x10, 0x05, 0x22, 0x4d, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x41, 0x4e,
+	0x4b, 0x10, 0x04, 0x22, 0x3d, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x22, 0xb8, 0x02, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x60, 0x0a,
+	0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x22,
+	0x0e, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x22,
+	0x33, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x05, 0x76,
+	0x69, 0x65, 0x77, 0x73, 0x22, 0x79, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x6f, 0x64, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x22,
+	0x28, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71,
+	0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x32, 0xdf, 0x01, 0x0a, 0x0b, 0x56, 0x69,
+	0x65, 0x77, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x09, 0x4c,
+	0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x30,
+	0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x11, 0x2e, 0x70,
+	0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a,
+	0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x22, 0x00,
+	0x12, 0x39, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x11,
+	0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c, 0x74,
+	0x6f, 0x64, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_view_service_proto_rawDescOnce sync.Once
+	file_view_service_proto_rawDescData = file_view_service_proto_rawDesc
+)
+
+func file_view_service_proto_rawDescGZIP() []byte {
+	file_view_service_proto_rawDescOnce.Do(func() {
+		file_view_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_view_service_proto_rawDescData)
+	})
+	return file_view_service_proto_rawDescData
+}
+
+var file_view_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_view_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_view_service_proto_goTypes = []interface{}{
+	(TodoQuery_StatusFilter)(0),   // 0: pb.TodoQuery.StatusFilter
+	(TodoQuery_AssigneeFilter)(0), // 1: pb.TodoQuery.AssigneeFilter
+	(TodoQuery_DeadlineWindow)(0), // 2: pb.TodoQuery.DeadlineWindow
+	(TodoQuery_SortField)(0),      // 3: pb.TodoQuery.SortField
+	(TodoQuery_SortOrder)(0),      // 4: pb.TodoQuery.SortOrder
+	(*TodoQuery)(nil),             // 5: pb.TodoQuery
+	(*SavedView)(nil),             // 6: pb.SavedView
+	(*CreateViewReq)(nil),         // 7: pb.CreateViewReq
+	(*ListViewsReq)(nil),          // 8: pb.ListViewsReq
+	(*ListViewsRes)(nil),          // 9: pb.ListViewsRes
+	(*UpdateViewReq)(nil),         // 10: pb.UpdateViewReq
+	(*DeleteViewReq)(nil),         // 11: pb.DeleteViewReq
+	(Todo_Priority)(0),            // 12: pb.Todo.Priority
+	(*timestamp.Timestamp)(nil),   // 13: google.protobuf.Timestamp
+	(*empty.Empty)(nil),           // 14: google.protobuf.Empty
+}
+var file_view_service_proto_depIdxs = []int32{
+	0,  // 0: pb.TodoQuery.status:type_name -> pb.TodoQuery.StatusFilter
+	12, // 1: pb.TodoQuery.priorities:type_name -> pb.Todo.Priority
+	1,  // 2: pb.TodoQuery.assignee:type_name -> pb.TodoQuery.AssigneeFilter
+	2,  // 3: pb.TodoQuery.deadline:type_name -> pb.TodoQuery.DeadlineWindow
+	3,  // 4: pb.TodoQuery.sort_by:type_name -> pb.TodoQuery.SortField
+	4,  // 5: pb.TodoQuery.sort_order:type_name -> pb.TodoQuery.SortOrder
+	5,  // 6: pb.SavedView.query:type_name -> pb.TodoQuery
+	13, // 7: pb.SavedView.created_at:type_name -> google.protobuf.Timestamp
+	13, // 8: pb.SavedView.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 9: pb.CreateViewReq.query:type_name -> pb.TodoQuery
+	6,  // 10: pb.ListViewsRes.views:type_name -> pb.SavedView
+	5,  // 11: pb.UpdateViewReq.query:type_name -> pb.TodoQuery
+	7,  // 12: pb.ViewService.CreateView:input_type -> pb.CreateViewReq
+	8,  // 13: pb.ViewService.ListViews:input_type -> pb.ListViewsReq
+	10, // 14: pb.ViewService.UpdateView:input_type -> pb.UpdateViewReq
+	11, // 15: pb.ViewService.DeleteView:input_type -> pb.DeleteViewReq
+	6,  // 16: pb.ViewService.CreateView:output_type -> pb.SavedView
+	9,  // 17: pb.ViewService.ListViews:output_type -> pb.ListViewsRes
+	6,  // 18: pb.ViewService.UpdateView:output_type -> pb.SavedView
+	14, // 19: pb.ViewService.DeleteView:output_type -> google.protobuf.Empty
+	16, // [16:20] is the sub-list for method output_type
+	12, // [12:16] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_view_service_proto_init() }
+func file_view_service_proto_init() {
+	if File_view_service_proto != nil {
+		return
+	}
+	file_todo_service_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_view_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TodoQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SavedView); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateViewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListViewsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListViewsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateViewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteViewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_view_service_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_view_service_proto_goTypes,
+		DependencyIndexes: file_view_service_proto_depIdxs,
+		EnumInfos:         file_view_service_proto_enumTypes,
+		MessageInfos:      file_view_service_proto_msgTypes,
+	}.Build()
+	File_view_service_proto = out.File
+	file_view_service_proto_rawDesc = nil
+	file_view_service_proto_goTypes = nil
+	file_view_service_proto_depIdxs = nil
+}
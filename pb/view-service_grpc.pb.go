@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: view-service.proto
+
+package pb
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ViewServiceClient is the client API for ViewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ViewServiceClient interface {
+	CreateView(ctx context.Context, in *CreateViewReq, opts ...grpc.CallOption) (*SavedView, error)
+	ListViews(ctx context.Context, in *ListViewsReq, opts ...grpc.CallOption) (*ListViewsRes, error)
+	UpdateView(ctx context.Context, in *UpdateViewReq, opts ...grpc.CallOption) (*SavedView, error)
+	DeleteView(ctx context.Context, in *DeleteViewReq, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type viewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewViewServiceClient(cc grpc.ClientConnInterface) ViewServiceClient {
+	return &viewServiceClient{cc}
+}
+
+func (c *viewServiceClient) CreateView(ctx context.Context, in *CreateViewReq, opts ...grpc.CallOption) (*SavedView, error) {
+	out := new(SavedView)
+	err := c.cc.Invoke(ctx, "/pb.ViewService/CreateView", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) ListViews(ctx context.Context, in *ListViewsReq, opts ...grpc.CallOption) (*ListViewsRes, error) {
+	out := new(ListViewsRes)
+	err := c.cc.Invoke(ctx, "/pb.ViewService/ListViews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) UpdateView(ctx context.Context, in *UpdateViewReq, opts ...grpc.CallOption) (*SavedView, error) {
+	out := new(SavedView)
+	err := c.cc.Invoke(ctx, "/pb.ViewService/UpdateView", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) DeleteView(ctx context.Context, in *DeleteViewReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ViewService/DeleteView", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ViewServiceServer is the server API for ViewService service.
+// All implementations must embed UnimplementedViewServiceServer
+// for forward compatibility
+type ViewServiceServer interface {
+	CreateView(context.Context, *CreateViewReq) (*SavedView, error)
+	ListViews(context.Context, *ListViewsReq) (*ListViewsRes, error)
+	UpdateView(context.Context, *UpdateViewReq) (*SavedView, error)
+	DeleteView(context.Context, *DeleteViewReq) (*empty.Empty, error)
+	mustEmbedUnimplementedViewServiceServer()
+}
+
+// UnimplementedViewServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedViewServiceServer struct {
+}
+
+func (UnimplementedViewServiceServer) CreateView(context.Context, *CreateViewReq) (*SavedView, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateView not implemented")
+}
+func (UnimplementedViewServiceServer) ListViews(context.Context, *ListViewsReq) (*ListViewsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViews not implemented")
+}
+func (UnimplementedViewServiceServer) UpdateView(context.Context, *UpdateViewReq) (*SavedView, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateView not implemented")
+}
+func (UnimplementedViewServiceServer) DeleteView(context.Context, *DeleteViewReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteView not implemented")
+}
+func (UnimplementedViewServiceServer) mustEmbedUnimplementedViewServiceServer() {}
+
+// UnsafeViewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ViewServiceServer will
+// result in compilation errors.
+type UnsafeViewServiceServer interface {
+	mustEmbedUnimplementedViewServiceServer()
+}
+
+func RegisterViewServiceServer(s grpc.ServiceRegistrar, srv ViewServiceServer) {
+	s.RegisterService(&ViewService_ServiceDesc, srv)
+}
+
+func _ViewService_CreateView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).CreateView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ViewService/CreateView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).CreateView(ctx, req.(*CreateViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_ListViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViewsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).ListViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ViewService/ListViews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).ListViews(ctx, req.(*ListViewsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_UpdateView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).UpdateView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ViewService/UpdateView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).UpdateView(ctx, req.(*UpdateViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_DeleteView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).DeleteView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ViewService/DeleteView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).DeleteView(ctx, req.(*DeleteViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ViewService_ServiceDesc is the grpc.ServiceDesc for ViewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ViewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ViewService",
+	HandlerType: (*ViewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateView",
+			Handler:    _ViewService_CreateView_Handler,
+		},
+		{
+			MethodName: "ListViews",
+			Handler:    _ViewService_ListViews_Handler,
+		},
+		{
+			MethodName: "UpdateView",
+			Handler:    _ViewService_UpdateView_Handler,
+		},
+		{
+			MethodName: "DeleteView",
+			Handler:    _ViewService_DeleteView_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "view-service.proto",
+}
@@ -4,102 +4,110 @@ import (
 	"context"
 	"errors"
 	"github.com/segmentio/kafka-go"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/utils"
 )
 
-// KafkaProvider is a Kafka provider that implements the Provider interface
+// topics lists every topic the provider is able to publish on
+var topics = []models.QueueTopic{
+	models.TopicDeadlineNearby,
+	models.TopicPremiumEnding,
+	models.TopicTodoAssigned,
+	models.TopicTodoUnsnoozed,
+	models.TopicAnalyticsEvents,
+}
+
+// KafkaProvider is a Kafka provider that implements the Provider interface.
+// Its writers are created once and never replaced, they redial the brokers on
+// their own, so publishing needs no locking.
 type KafkaProvider struct {
-	deadLineNearbyWriter *kafka.Writer
-	premiumEndingWriter  *kafka.Writer
-	envProvider          utils.EnvConfig
-	logger               *utils.Logger
+	writers map[models.QueueTopic]*kafka.Writer
+	// confirmedWriters wait for the brokers to acknowledge every write
+	confirmedWriters map[models.QueueTopic]*kafka.Writer
+	envProvider      utils.EnvConfig
+	logger           *utils.Logger
 }
 
 type Provider interface {
 	// Publish PublishData publishes data to a message queue
 	Publish(topic models.QueueTopic, message []byte)
-	Reconnect()
+	// PublishConfirmed publishes the messages and only returns once they are
+	// stored, for callers that have to undo their work when publishing fails
+	PublishConfirmed(ctx context.Context, topic models.QueueTopic, messages ...[]byte) error
 	Close()
 }
 
 func NewKafkaProvider(env utils.EnvConfig, logger *utils.Logger) Provider {
 	kafkaHost := env.GetKafkaHost()
-	deadLineNearbyWriter := &kafka.Writer{
-		Addr:        kafka.TCP(kafkaHost),
-		Topic:       string(models.TopicDeadlineNearby),
-		Async:       true,
-		ErrorLogger: logger,
-	}
-
-	premiumEndingWriter := &kafka.Writer{
-		Addr:        kafka.TCP(kafkaHost),
-		Topic:       string(models.TopicPremiumEnding),
-		Async:       true,
-		ErrorLogger: logger,
+	writers := make(map[models.QueueTopic]*kafka.Writer, len(topics))
+	confirmedWriters := make(map[models.QueueTopic]*kafka.Writer, len(topics))
+	for _, topic := range topics {
+		writers[topic] = &kafka.Writer{
+			Addr:        kafka.TCP(kafkaHost),
+			Topic:       string(topic),
+			Async:       true,
+			ErrorLogger: logger,
+		}
+		confirmedWriters[topic] = &kafka.Writer{
+			Addr:         kafka.TCP(kafkaHost),
+			Topic:        string(topic),
+			RequiredAcks: kafka.RequireAll,
+			// the messages of a call are written together, there is no
+			// point in waiting for more
+			BatchTimeout: 10 * time.Millisecond,
+			ErrorLogger:  logger,
+		}
 	}
 
 	return &KafkaProvider{
-		deadLineNearbyWriter: deadLineNearbyWriter,
-		premiumEndingWriter:  premiumEndingWriter,
-		envProvider:          env,
-		logger:               logger,
+		writers:          writers,
+		confirmedWriters: confirmedWriters,
+		envProvider:      env,
+		logger:           logger,
 	}
 }
 
 // Publish publishes data to a Kafka topic
 func (k *KafkaProvider) Publish(topic models.QueueTopic, message []byte) {
-	switch topic {
-	case models.TopicDeadlineNearby:
-		err := k.deadLineNearbyWriter.WriteMessages(
-			context.Background(),
-			kafka.Message{
-				Value: message,
-			},
-		)
-		if err != nil {
-			k.logger.Error(
-				err, "Publish: failed to write unassigned ride request message on kafka: %v",
-			)
-			k.Reconnect()
-		}
-		k.logger.Info("Published kafka message: %v", message)
-
-	case models.TopicPremiumEnding:
-		err := k.premiumEndingWriter.WriteMessages(
-			context.Background(),
-			kafka.Message{
-				Value: message,
-			},
-		)
-		if err != nil {
-			k.logger.Error(err, "Publish: failed to write push notification message on kafka: %v")
-			k.Reconnect()
-		}
-		k.logger.Info("Published kafka message: %v", message)
-
-	default:
+	writer, ok := k.writers[topic]
+	if !ok {
 		k.logger.Error(errors.New("trying to publish on wrong topic"), "Trying to publish on wrong topic")
+		return
 	}
-}
-
-func (k *KafkaProvider) Reconnect() {
-	kafkaHost := k.envProvider.GetKafkaHost()
 
-	k.premiumEndingWriter = &kafka.Writer{
-		Addr:  kafka.TCP(kafkaHost),
-		Topic: string(models.TopicPremiumEnding),
+	err := writer.WriteMessages(
+		context.Background(),
+		kafka.Message{
+			Value: message,
+		},
+	)
+	if err != nil {
+		k.logger.Error(err, "Publish: failed to write message on kafka", "topic", topic)
+		return
 	}
+	k.logger.Info("Published kafka message: %v", message)
+}
 
-	k.deadLineNearbyWriter = &kafka.Writer{
-		Addr:  kafka.TCP(kafkaHost),
-		Topic: string(models.TopicDeadlineNearby),
+func (k *KafkaProvider) PublishConfirmed(ctx context.Context, topic models.QueueTopic, messages ...[]byte) error {
+	writer, ok := k.confirmedWriters[topic]
+	if !ok {
+		return errors.New("trying to publish on wrong topic")
 	}
 
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		kafkaMessages = append(kafkaMessages, kafka.Message{Value: message})
+	}
+	return writer.WriteMessages(ctx, kafkaMessages...)
 }
 
 func (k *KafkaProvider) Close() {
-	if err := k.premiumEndingWriter.Close(); err != nil {
-		k.logger.Error(err, "error closing kafka connection")
+	for _, writers := range []map[models.QueueTopic]*kafka.Writer{k.writers, k.confirmedWriters} {
+		for _, writer := range writers {
+			if err := writer.Close(); err != nil {
+				k.logger.Error(err, "error closing kafka connection")
+			}
+		}
 	}
 }
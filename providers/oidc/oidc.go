@@ -0,0 +1,369 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+const (
+	// metadataTTL is how long discovery documents and keys are cached
+	metadataTTL = time.Hour
+	// keyRefreshInterval limits refetching the keys for unknown key ids, so
+	// tokens with made up key ids can't make us hammer the provider
+	keyRefreshInterval = time.Minute
+	// clockSkew is how far the clock of a provider may be off
+	clockSkew       = time.Minute
+	maxResponseSize = 1 << 20
+)
+
+var ErrUnknownProvider = errors.New("unknown oidc provider")
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client talks to the configured OpenID Connect providers for the
+// authorization code flow with PKCE.
+type Client struct {
+	providers   map[string]*provider
+	names       []string
+	redirectUrl string
+	client      HTTPClient
+}
+
+type provider struct {
+	config utils.OidcProviderConfig
+
+	mu                  sync.Mutex
+	discovery           *discovery
+	discoveryExpireTime time.Time
+	keys                map[string]interface{}
+	keysFetchTime       time.Time
+}
+
+type discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JwksUri               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type tokenResponse struct {
+	IdToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func NewClient(config utils.EnvConfig) *Client {
+	c := &Client{
+		providers:   make(map[string]*provider),
+		redirectUrl: config.GetOidcRedirectUrl(),
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, providerConfig := range config.GetOidcProviders() {
+		c.providers[providerConfig.Name] = &provider{config: providerConfig}
+		c.names = append(c.names, providerConfig.Name)
+	}
+	return c
+}
+
+func (c *Client) ProviderNames() []string {
+	return c.names
+}
+
+// CodeChallenge is the S256 PKCE challenge of the code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthorizationUrl is where users are sent to log in with the provider
+func (c *Client) AuthorizationUrl(
+	ctx context.Context, providerName, state, nonce, codeVerifier string,
+) (string, error) {
+	p, ok := c.providers[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+	d, err := c.fetchDiscovery(ctx, p)
+	if err != nil {
+		return "", err
+	}
+
+	authUrl, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authUrl.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", c.redirectUrl)
+	query.Set("scope", "openid email profile")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authUrl.RawQuery = query.Encode()
+	return authUrl.String(), nil
+}
+
+// Exchange redeems the code for an ID token and returns the identity it
+// vouches for once its signature and claims check out.
+func (c *Client) Exchange(
+	ctx context.Context, providerName, code, codeVerifier, nonce string,
+) (*models.OidcIdentity, error) {
+	p, ok := c.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	d, err := c.fetchDiscovery(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.redirectUrl},
+		"code_verifier": {codeVerifier},
+	}
+	// client_secret_basic is the default of the spec
+	useBasicAuth := p.config.ClientSecret != "" &&
+		(len(d.TokenAuthMethods) == 0 || slices.Contains(d.TokenAuthMethods, "client_secret_basic"))
+	if !useBasicAuth {
+		form.Set("client_id", p.config.ClientID)
+		if p.config.ClientSecret != "" {
+			form.Set("client_secret", p.config.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if useBasicAuth {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var res tokenResponse
+	status, err := c.doJson(req, &res)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || res.Error != "" {
+		return nil, fmt.Errorf("token request failed with status %d: %s %s", status, res.Error, res.ErrorDescription)
+	}
+	if res.IdToken == "" {
+		return nil, errors.New("token response has no id token")
+	}
+
+	return c.verifyIdToken(ctx, p, d, res.IdToken, nonce)
+}
+
+func (c *Client) verifyIdToken(
+	ctx context.Context, p *provider, d *discovery, rawToken, nonce string,
+) (*models.OidcIdentity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(
+		rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+			switch token.Method.(type) {
+			case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			default:
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+			kid, _ := token.Header["kid"].(string)
+			return c.fetchKey(ctx, p, d, kid)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if claims.Issuer != d.Issuer {
+		return nil, fmt.Errorf("id token of issuer %s instead of %s", claims.Issuer, d.Issuer)
+	}
+	if !slices.Contains(claims.Audience, p.config.ClientID) {
+		return nil, errors.New("id token isn't meant for us")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, errors.New("id token was authorized for another party")
+	}
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, errors.New("id token has the wrong nonce")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	return &models.OidcIdentity{
+		Provider:      p.config.Name,
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (c *Client) fetchDiscovery(ctx context.Context, p *provider) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil && time.Now().Before(p.discoveryExpireTime) {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+"/.well-known/openid-configuration", nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating discovery request: %w", err)
+	}
+	var d discovery
+	status, err := c.doJson(req, &d)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery of %s failed with status %d", p.config.Issuer, status)
+	}
+	if d.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("discovery of %s is for issuer %s", p.config.Issuer, d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JwksUri == "" {
+		return nil, fmt.Errorf("discovery of %s misses endpoints", p.config.Issuer)
+	}
+
+	p.discovery = &d
+	p.discoveryExpireTime = time.Now().Add(metadataTTL)
+	return p.discovery, nil
+}
+
+// fetchKey returns the public key of the provider with the key id, the keys
+// are fetched again when it is unknown since providers rotate them.
+func (c *Client) fetchKey(ctx context.Context, p *provider, d *discovery, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	key, ok := p.keys[kid]
+	stale := now.Sub(p.keysFetchTime) > metadataTTL
+	if ok && !stale {
+		return key, nil
+	}
+	if !stale && now.Sub(p.keysFetchTime) < keyRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.JwksUri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating jwks request: %w", err)
+	}
+	var set jwkSet
+	status, err := c.doJson(req, &set)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("jwks request failed with status %d", status)
+	}
+
+	p.keys = set.publicKeys()
+	p.keysFetchTime = now
+	key, ok = p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (c *Client) doJson(req *http.Request, v interface{}) (int, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, fmt.Errorf("error reading response: %w", err)
+	}
+	if err = json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("error decoding response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+type idTokenClaims struct {
+	Issuer          string     `json:"iss"`
+	Subject         string     `json:"sub"`
+	Audience        audience   `json:"aud"`
+	AuthorizedParty string     `json:"azp"`
+	ExpiresAt       int64      `json:"exp"`
+	IssuedAt        int64      `json:"iat"`
+	Nonce           string     `json:"nonce"`
+	Email           string     `json:"email"`
+	EmailVerified   stringBool `json:"email_verified"`
+	Name            string     `json:"name"`
+}
+
+func (c *idTokenClaims) Valid() error {
+	now := time.Now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return errors.New("token is expired")
+	}
+	if c.IssuedAt != 0 && now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("token is issued in the future")
+	}
+	return nil
+}
+
+// audience is a single audience or a list of them
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// stringBool is a boolean some providers send as a string
+type stringBool bool
+
+func (b *stringBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = stringBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*b = stringBool(text == "true")
+	return nil
+}
@@ -0,0 +1,399 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"todo-grpc/utils"
+)
+
+const (
+	testProvider     = "stub"
+	testClientID     = "todo-client"
+	testClientSecret = "client-secret"
+	testNonce        = "nonce-1"
+)
+
+// stubIssuer is an OpenID provider serving discovery, keys and a token
+// endpoint that hands out the ID token the test set.
+type stubIssuer struct {
+	server *httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+
+	mu          sync.Mutex
+	keys        []jwk
+	jwksFetches int
+	idToken     string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate ecdsa key: %v", err)
+	}
+
+	s := &stubIssuer{
+		rsaKey: rsaKey,
+		ecKey:  ecKey,
+		keys:   []jwk{rsaJwk("rsa-1", &rsaKey.PublicKey), ecJwk("ec-1", &ecKey.PublicKey)},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, &discovery{
+			Issuer:                s.server.URL,
+			AuthorizationEndpoint: s.server.URL + "/authorize",
+			TokenEndpoint:         s.server.URL + "/token",
+			JwksUri:               s.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.jwksFetches++
+		writeJson(w, &jwkSet{Keys: s.keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientId, clientSecret, ok := r.BasicAuth()
+		if r.Method != http.MethodPost || !ok || clientId != testClientID || clientSecret != testClientSecret ||
+			r.PostFormValue("code") != "code-1" || r.PostFormValue("code_verifier") != "verifier-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJson(w, &tokenResponse{Error: "invalid_grant"})
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJson(w, &tokenResponse{IdToken: s.idToken})
+	})
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *stubIssuer) setIdToken(idToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idToken = idToken
+}
+
+func (s *stubIssuer) setKeys(keys ...jwk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *stubIssuer) fetches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jwksFetches
+}
+
+// claims are the claims of a valid ID token of the issuer
+func (s *stubIssuer) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            s.server.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          testNonce,
+		"email":          "ann@example.com",
+		"email_verified": true,
+		"name":           "Ann",
+	}
+}
+
+func (s *stubIssuer) newClient() *Client {
+	return &Client{
+		providers: map[string]*provider{
+			testProvider: {
+				config: utils.OidcProviderConfig{
+					Name:         testProvider,
+					Issuer:       s.server.URL,
+					ClientID:     testClientID,
+					ClientSecret: testClientSecret,
+				},
+			},
+		},
+		names:       []string{testProvider},
+		redirectUrl: "https://app.example.com/oidc/callback",
+		client:      s.server.Client(),
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unable to sign token: %v", err)
+	}
+	return signed
+}
+
+func rsaJwk(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJwk(kid string, key *ecdsa.PublicKey) jwk {
+	return jwk{
+		Kty: "EC",
+		Kid: kid,
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestExchange(t *testing.T) {
+	issuer := newStubIssuer(t)
+
+	rs256 := func(claims jwt.MapClaims) string {
+		return signToken(t, jwt.SigningMethodRS256, "rsa-1", issuer.rsaKey, claims)
+	}
+	tests := []struct {
+		name         string
+		token        func(claims jwt.MapClaims) string
+		mutate       func(claims jwt.MapClaims)
+		wantErr      bool
+		wantVerified bool
+	}{
+		{
+			name:         "rs256",
+			token:        rs256,
+			wantVerified: true,
+		},
+		{
+			name: "es256",
+			token: func(claims jwt.MapClaims) string {
+				return signToken(t, jwt.SigningMethodES256, "ec-1", issuer.ecKey, claims)
+			},
+			wantVerified: true,
+		},
+		{
+			name:  "email verified as string",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["email_verified"] = "true"
+			},
+			wantVerified: true,
+		},
+		{
+			name:  "email unverified as string",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["email_verified"] = "false"
+			},
+			wantVerified: false,
+		},
+		{
+			name:  "audiences authorized for us",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+				claims["azp"] = testClientID
+			},
+			wantVerified: true,
+		},
+		{
+			name:  "wrong nonce",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["nonce"] = "nonce-2"
+			},
+			wantErr: true,
+		},
+		{
+			name:  "missing nonce",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				delete(claims, "nonce")
+			},
+			wantErr: true,
+		},
+		{
+			name:  "wrong audience",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			},
+			wantErr: true,
+		},
+		{
+			name:  "audiences without authorized party",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+			},
+			wantErr: true,
+		},
+		{
+			name:  "audiences authorized for another party",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+				claims["azp"] = "other-client"
+			},
+			wantErr: true,
+		},
+		{
+			name:  "wrong issuer",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			wantErr: true,
+		},
+		{
+			name:  "expired",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-2 * clockSkew).Unix()
+			},
+			wantErr: true,
+		},
+		{
+			name:  "missing subject",
+			token: rs256,
+			mutate: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			wantErr: true,
+		},
+		{
+			name: "hs256 signed with the client secret",
+			token: func(claims jwt.MapClaims) string {
+				return signToken(t, jwt.SigningMethodHS256, "rsa-1", []byte(testClientSecret), claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed by another key",
+			token: func(claims jwt.MapClaims) string {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				if err != nil {
+					t.Fatalf("unable to generate rsa key: %v", err)
+				}
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, claims)
+			},
+			wantErr: true,
+		},
+	}
+
+	client := issuer.newClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := issuer.claims()
+			if tt.mutate != nil {
+				tt.mutate(claims)
+			}
+			issuer.setIdToken(tt.token(claims))
+
+			identity, err := client.Exchange(context.Background(), testProvider, "code-1", "verifier-1", testNonce)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Exchange returned identity %+v, want an error", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange returned error: %v", err)
+			}
+			if identity.Provider != testProvider || identity.Issuer != issuer.server.URL ||
+				identity.Subject != "subject-1" || identity.Email != "ann@example.com" || identity.Name != "Ann" {
+				t.Errorf("Exchange returned identity %+v", identity)
+			}
+			if identity.EmailVerified != tt.wantVerified {
+				t.Errorf("Exchange returned email verified %t, want %t", identity.EmailVerified, tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsFailedTokenRequest(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.setIdToken(signToken(t, jwt.SigningMethodRS256, "rsa-1", issuer.rsaKey, issuer.claims()))
+
+	_, err := issuer.newClient().Exchange(context.Background(), testProvider, "code-2", "verifier-1", testNonce)
+	if err == nil {
+		t.Fatalf("Exchange with a rejected code returned no error")
+	}
+}
+
+func TestExchangeRefetchesKeysForUnknownKid(t *testing.T) {
+	issuer := newStubIssuer(t)
+	client := issuer.newClient()
+	exchange := func(kid string, key *rsa.PrivateKey) error {
+		issuer.setIdToken(signToken(t, jwt.SigningMethodRS256, kid, key, issuer.claims()))
+		_, err := client.Exchange(context.Background(), testProvider, "code-1", "verifier-1", testNonce)
+		return err
+	}
+
+	if err := exchange("rsa-1", issuer.rsaKey); err != nil {
+		t.Fatalf("Exchange returned error: %v", err)
+	}
+	if fetches := issuer.fetches(); fetches != 1 {
+		t.Fatalf("keys were fetched %d times, want 1", fetches)
+	}
+
+	// the provider rotates its key
+	rotatedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate rsa key: %v", err)
+	}
+	issuer.setKeys(rsaJwk("rsa-2", &rotatedKey.PublicKey))
+
+	// right after a fetch unknown key ids don't fetch the keys again
+	if err = exchange("rsa-2", rotatedKey); err == nil {
+		t.Fatalf("Exchange with an unknown key id right after a fetch returned no error")
+	}
+	if fetches := issuer.fetches(); fetches != 1 {
+		t.Fatalf("keys were fetched %d times, want 1", fetches)
+	}
+
+	p := client.providers[testProvider]
+	p.mu.Lock()
+	p.keysFetchTime = time.Now().Add(-2 * keyRefreshInterval)
+	p.mu.Unlock()
+
+	if err = exchange("rsa-2", rotatedKey); err != nil {
+		t.Fatalf("Exchange with a rotated key returned error: %v", err)
+	}
+	if fetches := issuer.fetches(); fetches != 2 {
+		t.Fatalf("keys were fetched %d times, want 2", fetches)
+	}
+
+	// the old key is gone with the refetch
+	if err = exchange("rsa-1", issuer.rsaKey); err == nil {
+		t.Fatalf("Exchange with a removed key returned no error")
+	}
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"todo-grpc/utils"
+)
+
+// jwksMaxAge is how long verifiers may cache the JWKS. Rotating keys waits
+// at least this long between its steps.
+const jwksMaxAge = "max-age=300"
+
+// NewHttpServer serves the JWKS other services verify our access tokens
+// with. It has no keys while access tokens are signed with the jwt secret.
+func NewHttpServer(config utils.EnvConfig, logger *utils.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+
+			jwks := []byte(`{"keys":[]}`)
+			if keySet := config.GetJwtKeySet(); keySet != nil {
+				var err error
+				if jwks, err = keySet.Jwks(); err != nil {
+					logger.Error(err, "unable to encode jwks")
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", jwksMaxAge)
+			if _, err := w.Write(jwks); err != nil {
+				logger.Error(err, "unable to write jwks")
+			}
+		},
+	)
+
+	return &http.Server{
+		Addr:    config.GetHttpPort(),
+		Handler: mux,
+	}
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -9,38 +11,125 @@ import (
 	"todo-grpc/middleware"
 	"todo-grpc/pb"
 	kafkaQueueProvider "todo-grpc/providers/kafka"
+	"todo-grpc/providers/mail"
+	oidcProvider "todo-grpc/providers/oidc"
+	"todo-grpc/service"
+	"todo-grpc/service/accesstoken"
+	"todo-grpc/service/account"
+	"todo-grpc/service/admin"
 	"todo-grpc/service/alerts"
+	"todo-grpc/service/idempotency"
+	"todo-grpc/service/loginattempt"
+	"todo-grpc/service/oidc"
+	"todo-grpc/service/refreshtoken"
+	"todo-grpc/service/revocation"
 	"todo-grpc/service/todo"
 	"todo-grpc/service/user"
+	"todo-grpc/service/view"
+	"todo-grpc/service/workspace"
 	"todo-grpc/utils"
 )
 
 func NewServer(
 	db *mongo.Client,
+	analyticsSvc service.AnalyticsService,
+	redisClient *redis.Client,
 	logger *utils.Logger,
 	config utils.EnvConfig,
 	kafkaProvider kafkaQueueProvider.Provider,
 ) *grpc.Server {
+	revocationSvc := revocation.NewRevocationService(db, logger)
+	if redisClient != nil {
+		revocationSvc = revocation.NewRedisRevocationService(redisClient, logger)
+	}
+	if err := revocationSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create revoked token indexes")
+	}
+
+	refreshTokenSvc := refreshtoken.NewRefreshTokenService(db, logger)
+	if err := refreshTokenSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create refresh token indexes")
+	}
+
+	accessTokenSvc := accesstoken.NewAccessTokenService(db, logger)
+	if err := accessTokenSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create access token indexes")
+	}
+
+	loginAttemptSvc := loginattempt.NewLoginAttemptService(db, logger, config)
+	if err := loginAttemptSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create login attempt indexes")
+	}
+
+	emailClient := mail.NewEmailClient(config)
+	userSvc := user.NewUserService(
+		db,
+		logger,
+		config,
+		kafkaProvider,
+		revocationSvc,
+		refreshTokenSvc,
+		emailClient,
+		loginAttemptSvc,
+		accessTokenSvc,
+	)
+	if err := userSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create user indexes, duplicate emails are resolved by cmd/migrate-emails")
+	}
+	oidcSvc := oidc.NewOidcService(db, logger, oidcProvider.NewClient(config), userSvc)
+	if err := oidcSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create oidc login indexes")
+	}
+	adminSvc := admin.NewAdminService(db, logger, config, userSvc, revocationSvc)
+	if err := adminSvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create audit log indexes")
+	}
+	workspaceSvc := workspace.NewWorkspaceService(db, logger, config, userSvc, emailClient)
+	idempotencySvc := idempotency.NewIdempotencyService(db, logger)
+	if err := idempotencySvc.EnsureIndexes(context.Background()); err != nil {
+		logger.Error(err, "unable to create idempotency key indexes")
+	}
+
+	todoSvc := todo.NewTodoService(db, logger, config, userSvc, workspaceSvc, kafkaProvider)
+	alertSvc := alerts.NewAlertService(db, logger, config)
+	viewSvc := view.NewViewService(db, logger, userSvc)
+
 	srv := &api.Server{
-		TodoSvc:       todo.NewTodoService(db, logger, config),
-		UserSvc:       user.NewUserService(db, logger, config),
-		AlertSvc:      alerts.NewAlertService(db, logger, config),
+		TodoSvc:        todoSvc,
+		UserSvc:        userSvc,
+		AlertSvc:       alertSvc,
+		WorkspaceSvc:   workspaceSvc,
+		IdempotencySvc: idempotencySvc,
+		AnalyticsSvc:   analyticsSvc,
+		ViewSvc:        viewSvc,
+		RevocationSvc:  revocationSvc,
+		AccessTokenSvc: accessTokenSvc,
+		OidcSvc:        oidcSvc,
+		AdminSvc:       adminSvc,
+		AccountSvc: account.NewAccountService(
+			db, logger, userSvc, todoSvc, alertSvc, viewSvc, workspaceSvc, revocationSvc, refreshTokenSvc,
+			accessTokenSvc,
+		),
 		Config:        config,
 		Logger:        logger,
 		KafkaProvider: kafkaProvider,
 	}
 
 	server := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(middleware.AuthMiddleware),
+		grpc.ChainUnaryInterceptor(middleware.AuthMiddleware, middleware.IdempotencyMiddleware),
 		grpc.ChainStreamInterceptor(middleware.AuthStreamInterceptor),
 	)
 
 	pb.RegisterUserServiceServer(server, srv)
 	pb.RegisterTodoServiceServer(server, srv)
+	pb.RegisterWorkspaceServiceServer(server, srv)
+	pb.RegisterAnalyticsServiceServer(server, srv)
+	pb.RegisterViewServiceServer(server, srv)
+	pb.RegisterAdminServiceServer(server, srv)
 
 	reflection.Register(server)
 
-	go cron.InitCron(srv.TodoSvc, kafkaProvider)
+	go cron.InitCron(srv.TodoSvc, srv.UserSvc, kafkaProvider, config, logger)
 
 	return server
 }
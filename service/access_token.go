@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"todo-grpc/models"
+)
+
+type AccessTokenService interface {
+	EnsureIndexes(ctx context.Context) error
+	// CreateAccessToken stores a personal access token of the user and returns
+	// it with the token itself, which isn't stored
+	CreateAccessToken(ctx context.Context, token *models.AccessToken) (*models.AccessToken, string, error)
+	ListAccessTokens(ctx context.Context, userId primitive.ObjectID) ([]*models.AccessToken, error)
+	RevokeAccessToken(ctx context.Context, userId, tokenId primitive.ObjectID) error
+	// AuthenticateAccessToken returns the unexpired access token of the
+	// token and records its use
+	AuthenticateAccessToken(ctx context.Context, token string) (*models.AccessToken, error)
+	DeleteUserAccessTokens(ctx context.Context, userId primitive.ObjectID) error
+}
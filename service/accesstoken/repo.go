@@ -0,0 +1,197 @@
+package accesstoken
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	accessTokensC *mongo.Collection
+	logger        *utils.Logger
+}
+
+type accessTokenRepo interface {
+	ensureIndexes(ctx context.Context) error
+	insertAccessToken(ctx context.Context, token *models.AccessToken) error
+	countAccessTokens(ctx context.Context, userId primitive.ObjectID, now primitive.DateTime) (int64, error)
+	fetchAccessTokens(ctx context.Context, userId primitive.ObjectID, now primitive.DateTime) ([]*models.AccessToken, error)
+	fetchAccessTokenByHash(ctx context.Context, tokenHash string, now primitive.DateTime) (*models.AccessToken, error)
+	touchAccessToken(ctx context.Context, tokenId primitive.ObjectID, now, usedBefore primitive.DateTime) error
+	deleteAccessTokens(ctx context.Context, filter bson.M) (int64, error)
+}
+
+func newRepoClient(db *mongo.Client, logger *utils.Logger) accessTokenRepo {
+	return &repoClient{
+		accessTokensC: utils.GetCollection(db, "access_tokens"),
+		logger:        logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.accessTokensC.Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "token_hash", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "create_time", Value: -1}},
+			},
+			{
+				Keys:    bson.D{{Key: "expire_time", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	)
+	return err
+}
+
+func (r *repoClient) insertAccessToken(ctx context.Context, token *models.AccessToken) error {
+	if _, err := r.accessTokensC.InsertOne(ctx, token); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to store access token",
+			},
+		}
+	}
+
+	return nil
+}
+
+// unexpiredFilter matches the tokens the TTL index didn't remove yet though
+// they are expired
+func unexpiredFilter(now primitive.DateTime) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"expire_time": bson.M{"$exists": false}},
+			bson.M{"expire_time": bson.M{"$gt": now}},
+		},
+	}
+}
+
+func (r *repoClient) countAccessTokens(
+	ctx context.Context, userId primitive.ObjectID, now primitive.DateTime,
+) (int64, error) {
+	filter := unexpiredFilter(now)
+	filter["user_id"] = userId
+
+	count, err := r.accessTokensC.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to count access tokens",
+			},
+		}
+	}
+
+	return count, nil
+}
+
+func (r *repoClient) fetchAccessTokens(
+	ctx context.Context, userId primitive.ObjectID, now primitive.DateTime,
+) ([]*models.AccessToken, error) {
+	filter := unexpiredFilter(now)
+	filter["user_id"] = userId
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	cursor, err := r.accessTokensC.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch access tokens",
+			},
+		}
+	}
+
+	tokens := make([]*models.AccessToken, 0)
+	if err = cursor.All(ctx, &tokens); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch access tokens",
+			},
+		}
+	}
+
+	return tokens, nil
+}
+
+func (r *repoClient) fetchAccessTokenByHash(
+	ctx context.Context, tokenHash string, now primitive.DateTime,
+) (*models.AccessToken, error) {
+	filter := unexpiredFilter(now)
+	filter["token_hash"] = tokenHash
+
+	var token models.AccessToken
+	err := r.accessTokensC.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.UnAuthenticatedError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid or expired access token",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch access token",
+			},
+		}
+	}
+
+	return &token, nil
+}
+
+// touchAccessToken sets the last use of the token unless it was used after
+// usedBefore already
+func (r *repoClient) touchAccessToken(
+	ctx context.Context, tokenId primitive.ObjectID, now, usedBefore primitive.DateTime,
+) error {
+	filter := bson.M{
+		"_id": tokenId,
+		"$or": bson.A{
+			bson.M{"last_used_time": bson.M{"$exists": false}},
+			bson.M{"last_used_time": bson.M{"$lt": usedBefore}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"last_used_time": now,
+		},
+	}
+
+	if _, err := r.accessTokensC.UpdateOne(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update access token",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) deleteAccessTokens(ctx context.Context, filter bson.M) (int64, error) {
+	res, err := r.accessTokensC.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete access tokens",
+			},
+		}
+	}
+
+	return res.DeletedCount, nil
+}
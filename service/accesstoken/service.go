@@ -0,0 +1,126 @@
+package accesstoken
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const (
+	maxAccessTokensPerUser = 50
+	// lastUsedPrecision is how often the last use of a token is written,
+	// every request of a busy script shouldn't be a write
+	lastUsedPrecision = time.Minute
+)
+
+type serviceClient struct {
+	accessTokenRepo accessTokenRepo
+	logger          *utils.Logger
+}
+
+func NewAccessTokenService(db *mongo.Client, logger *utils.Logger) service.AccessTokenService {
+	return &serviceClient{
+		accessTokenRepo: newRepoClient(db, logger),
+		logger:          logger,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.accessTokenRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) CreateAccessToken(
+	ctx context.Context, token *models.AccessToken,
+) (*models.AccessToken, string, error) {
+	now := primitive.NewDateTimeFromTime(time.Now())
+	count, err := s.accessTokenRepo.countAccessTokens(ctx, token.UserID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= maxAccessTokensPerUser {
+		return nil, "", &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "too many access tokens, revoke unused ones first",
+			},
+		}
+	}
+
+	secret, err := utils.GenerateSecretToken()
+	if err != nil {
+		return nil, "", &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create access token",
+			},
+		}
+	}
+	plainToken := utils.AccessTokenPrefix + secret
+
+	token.ID = primitive.NewObjectID()
+	token.TokenHash = utils.HashSecretToken(plainToken)
+	token.CreateTime = now
+	if err = s.accessTokenRepo.insertAccessToken(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	return token, plainToken, nil
+}
+
+func (s *serviceClient) ListAccessTokens(
+	ctx context.Context, userId primitive.ObjectID,
+) ([]*models.AccessToken, error) {
+	return s.accessTokenRepo.fetchAccessTokens(ctx, userId, primitive.NewDateTimeFromTime(time.Now()))
+}
+
+func (s *serviceClient) RevokeAccessToken(ctx context.Context, userId, tokenId primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":     tokenId,
+		"user_id": userId,
+	}
+	deleted, err := s.accessTokenRepo.deleteAccessTokens(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return &utils.DbNotFoundError{
+			GeneralError: &utils.GeneralError{
+				Msg: "access token not found",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (s *serviceClient) AuthenticateAccessToken(ctx context.Context, token string) (*models.AccessToken, error) {
+	now := time.Now()
+	accessToken, err := s.accessTokenRepo.fetchAccessTokenByHash(
+		ctx, utils.HashSecretToken(token), primitive.NewDateTimeFromTime(now),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if accessToken.LastUsedTime.Time().Before(now.Add(-lastUsedPrecision)) {
+		// the request shouldn't fail only because its use wasn't recorded
+		err = s.accessTokenRepo.touchAccessToken(
+			ctx, accessToken.ID, primitive.NewDateTimeFromTime(now),
+			primitive.NewDateTimeFromTime(now.Add(-lastUsedPrecision)),
+		)
+		if err != nil {
+			s.logger.Error(err, "unable to record use of access token")
+		}
+	}
+
+	return accessToken, nil
+}
+
+func (s *serviceClient) DeleteUserAccessTokens(ctx context.Context, userId primitive.ObjectID) error {
+	_, err := s.accessTokenRepo.deleteAccessTokens(ctx, bson.M{"user_id": userId})
+	return err
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type AccountService interface {
+	// DeleteAccount deletes the user with their personal todos, alerts,
+	// views, tokens and the workspaces nobody else is a member of, and
+	// unassigns them in the other workspaces. The password of the user
+	// confirms it, users without a password confirm it by having
+	// authenticated recently.
+	DeleteAccount(ctx context.Context, userId primitive.ObjectID, password string, authTime time.Time) error
+}
@@ -0,0 +1,134 @@
+package account
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"slices"
+	"time"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+// serviceClient deletes accounts across the services that keep data of a
+// user, it has no data of its own.
+type serviceClient struct {
+	db                  *mongo.Client
+	logger              *utils.Logger
+	userService         service.UserService
+	todoService         service.TodoService
+	alertService        service.AlertService
+	viewService         service.ViewService
+	workspaceService    service.WorkspaceService
+	revocationService   service.RevocationService
+	refreshTokenService service.RefreshTokenService
+	accessTokenService  service.AccessTokenService
+}
+
+func NewAccountService(
+	db *mongo.Client,
+	logger *utils.Logger,
+	userService service.UserService,
+	todoService service.TodoService,
+	alertService service.AlertService,
+	viewService service.ViewService,
+	workspaceService service.WorkspaceService,
+	revocationService service.RevocationService,
+	refreshTokenService service.RefreshTokenService,
+	accessTokenService service.AccessTokenService,
+) service.AccountService {
+	return &serviceClient{
+		db:                  db,
+		logger:              logger,
+		userService:         userService,
+		todoService:         todoService,
+		alertService:        alertService,
+		viewService:         viewService,
+		workspaceService:    workspaceService,
+		revocationService:   revocationService,
+		refreshTokenService: refreshTokenService,
+		accessTokenService:  accessTokenService,
+	}
+}
+
+func (s *serviceClient) DeleteAccount(
+	ctx context.Context, userId primitive.ObjectID, password string, authTime time.Time,
+) error {
+	user, err := s.userService.FetchUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.Password == "" {
+		if err = utils.CheckRecentAuth(authTime); err != nil {
+			return err
+		}
+	} else if !utils.CheckPassword(password, user.Password) {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "password is incorrect",
+			},
+		}
+	}
+
+	// revocations may live outside of mongo, so they are made up front. A
+	// failed deletion only leaves the user logged out.
+	if _, err = s.revocationService.RevokeAllTokens(ctx, userId); err != nil {
+		return err
+	}
+
+	session, err := s.db.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	wc := writeconcern.New(writeconcern.WMajority())
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		workspaces, err := s.workspaceService.ListWorkspaces(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		workspaceIds, err := s.workspaceService.LeaveAllWorkspaces(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		// the todos of the workspaces that stay around lose their assignee
+		remainingIds := make([]primitive.ObjectID, 0, len(workspaces))
+		for _, workspace := range workspaces {
+			if !slices.Contains(workspaceIds, workspace.ID) {
+				remainingIds = append(remainingIds, workspace.ID)
+			}
+		}
+		if err = s.todoService.UnassignTodos(ctx, userId, remainingIds); err != nil {
+			return nil, err
+		}
+		if err = s.todoService.DeleteUserTodos(ctx, userId, workspaceIds); err != nil {
+			return nil, err
+		}
+		if err = s.alertService.DeleteUserAlerts(ctx, userId); err != nil {
+			return nil, err
+		}
+		if err = s.viewService.DeleteUserViews(ctx, userId); err != nil {
+			return nil, err
+		}
+		if err = s.refreshTokenService.DeleteUserRefreshTokens(ctx, userId); err != nil {
+			return nil, err
+		}
+		if err = s.accessTokenService.DeleteUserAccessTokens(ctx, userId); err != nil {
+			return nil, err
+		}
+		return nil, s.userService.DeleteUser(ctx, userId)
+	}
+
+	if _, err = session.WithTransaction(ctx, callback, txnOpts); err != nil {
+		return err
+	}
+
+	s.logger.Info("deleted account of user %s", userId.Hex())
+	return nil
+}
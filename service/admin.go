@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+	"todo-grpc/models"
+)
+
+// AdminService manages users for admins. Every action is written to the
+// audit log before it is taken, actions that fail may be logged as well.
+type AdminService interface {
+	EnsureIndexes(ctx context.Context) error
+	// CheckAdmin fails with a permission error unless the user is an admin
+	CheckAdmin(ctx context.Context, userId primitive.ObjectID) error
+	ListUsers(
+		ctx context.Context, actor *models.AuditActor, filter *models.ListUsersFilter,
+	) (*models.ListUsersRes, error)
+	GetUser(ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID) (*models.User, error)
+	// DisableUser rejects every token of the user and keeps them from
+	// logging in until EnableUser
+	DisableUser(
+		ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string,
+	) (*models.User, error)
+	EnableUser(
+		ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string,
+	) (*models.User, error)
+	// ForceLogout revokes every session of the user
+	ForceLogout(ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string) error
+	// SetPremium makes the user premium until the expire time, or forever
+	// when it is zero
+	SetPremium(
+		ctx context.Context,
+		actor *models.AuditActor,
+		userId primitive.ObjectID,
+		isPremium bool,
+		expireTime time.Time,
+		reason string,
+	) (*models.User, error)
+	// Impersonate issues an access token of the user to the admin, it can't
+	// be refreshed and admins can't be impersonated
+	Impersonate(
+		ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string, ttl time.Duration,
+	) (string, time.Time, error)
+	// RecordImpersonatedCall logs a call an admin made as the user
+	RecordImpersonatedCall(
+		ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, method string,
+	) error
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	auditLogC *mongo.Collection
+	logger    *utils.Logger
+}
+
+// adminRepo only ever inserts into the audit log, entries aren't updated or
+// deleted
+type adminRepo interface {
+	ensureIndexes(ctx context.Context) error
+	insertAuditLogEntry(ctx context.Context, entry *models.AuditLogEntry) error
+}
+
+func newRepoClient(db *mongo.Client, logger *utils.Logger) adminRepo {
+	return &repoClient{
+		auditLogC: utils.GetCollection(db, "audit_log"),
+		logger:    logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.auditLogC.Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "create_time", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "target_user_id", Value: 1}, {Key: "create_time", Value: -1}},
+			},
+		},
+	)
+	return err
+}
+
+func (r *repoClient) insertAuditLogEntry(ctx context.Context, entry *models.AuditLogEntry) error {
+	if _, err := r.auditLogC.InsertOne(ctx, entry); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to write audit log",
+			},
+		}
+	}
+
+	return nil
+}
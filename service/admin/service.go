@@ -0,0 +1,226 @@
+package admin
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type serviceClient struct {
+	adminRepo         adminRepo
+	logger            *utils.Logger
+	config            utils.EnvConfig
+	userService       service.UserService
+	revocationService service.RevocationService
+}
+
+func NewAdminService(
+	db *mongo.Client,
+	logger *utils.Logger,
+	config utils.EnvConfig,
+	userService service.UserService,
+	revocationService service.RevocationService,
+) service.AdminService {
+	return &serviceClient{
+		adminRepo:         newRepoClient(db, logger),
+		logger:            logger,
+		config:            config,
+		userService:       userService,
+		revocationService: revocationService,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.adminRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) CheckAdmin(ctx context.Context, userId primitive.ObjectID) error {
+	user, err := s.userService.FetchUser(ctx, userId)
+	if err != nil {
+		if _, ok := err.(*utils.DbNotFoundError); !ok {
+			return err
+		}
+		user = &models.User{}
+	}
+	if !user.HasRole(models.RoleAdmin) || user.Disabled {
+		return &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "user " + userId.Hex() + " isn't an admin",
+				Msg:     "only admins may do this",
+			},
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) ListUsers(
+	ctx context.Context, actor *models.AuditActor, filter *models.ListUsersFilter,
+) (*models.ListUsersRes, error) {
+	details := map[string]interface{}{
+		"query": filter.Query,
+		"limit": filter.Limit,
+		"page":  filter.Page,
+	}
+	if err := s.audit(ctx, actor, models.AuditListUsers, primitive.NilObjectID, "", details); err != nil {
+		return nil, err
+	}
+
+	return s.userService.ListUsers(ctx, filter)
+}
+
+func (s *serviceClient) GetUser(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID,
+) (*models.User, error) {
+	if err := s.audit(ctx, actor, models.AuditGetUser, userId, "", nil); err != nil {
+		return nil, err
+	}
+
+	return s.userService.FetchUser(ctx, userId)
+}
+
+func (s *serviceClient) DisableUser(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string,
+) (*models.User, error) {
+	// admins could lock everyone out by disabling themselves
+	if userId == actor.UserID {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "admins can't disable themselves",
+			},
+		}
+	}
+	if err := s.audit(ctx, actor, models.AuditDisableUser, userId, reason, nil); err != nil {
+		return nil, err
+	}
+
+	return s.userService.SetUserDisabled(ctx, userId, true)
+}
+
+func (s *serviceClient) EnableUser(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string,
+) (*models.User, error) {
+	if err := s.audit(ctx, actor, models.AuditEnableUser, userId, reason, nil); err != nil {
+		return nil, err
+	}
+
+	return s.userService.SetUserDisabled(ctx, userId, false)
+}
+
+func (s *serviceClient) ForceLogout(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string,
+) error {
+	if _, err := s.userService.FetchUser(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.audit(ctx, actor, models.AuditForceLogout, userId, reason, nil); err != nil {
+		return err
+	}
+
+	return s.userService.RevokeSessions(ctx, userId)
+}
+
+func (s *serviceClient) SetPremium(
+	ctx context.Context,
+	actor *models.AuditActor,
+	userId primitive.ObjectID,
+	isPremium bool,
+	expireTime time.Time,
+	reason string,
+) (*models.User, error) {
+	details := map[string]interface{}{
+		"is_premium": isPremium,
+	}
+	if !expireTime.IsZero() {
+		details["expire_time"] = expireTime
+	}
+	if err := s.audit(ctx, actor, models.AuditSetPremium, userId, reason, details); err != nil {
+		return nil, err
+	}
+
+	return s.userService.SetUserPremium(ctx, userId, isPremium, expireTime)
+}
+
+func (s *serviceClient) Impersonate(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, reason string, ttl time.Duration,
+) (string, time.Time, error) {
+	user, err := s.userService.FetchUser(ctx, userId)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	// an admin acting as another admin could hide behind them
+	if user.HasRole(models.RoleAdmin) {
+		return "", time.Time{}, &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "user " + userId.Hex() + " is an admin",
+				Msg:     "admins can't be impersonated",
+			},
+		}
+	}
+	if user.Disabled {
+		return "", time.Time{}, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "user " + userId.Hex() + " is disabled",
+				Msg:     "disabled users can't be impersonated",
+			},
+		}
+	}
+
+	details := map[string]interface{}{
+		"ttl_seconds": int64(ttl.Seconds()),
+	}
+	if err = s.audit(ctx, actor, models.AuditImpersonate, userId, reason, details); err != nil {
+		return "", time.Time{}, err
+	}
+
+	generation, err := s.revocationService.FetchTokenGeneration(ctx, userId)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token, expireTime, err := utils.GenerateImpersonationToken(
+		userId.Hex(), generation, actor.UserID.Hex(), ttl, s.config,
+	)
+	if err != nil {
+		return "", time.Time{}, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create token",
+			},
+		}
+	}
+	return token, expireTime, nil
+}
+
+func (s *serviceClient) RecordImpersonatedCall(
+	ctx context.Context, actor *models.AuditActor, userId primitive.ObjectID, method string,
+) error {
+	details := map[string]interface{}{
+		"method": method,
+	}
+	return s.audit(ctx, actor, models.AuditImpersonatedCall, userId, "", details)
+}
+
+func (s *serviceClient) audit(
+	ctx context.Context,
+	actor *models.AuditActor,
+	action models.AuditAction,
+	targetUserId primitive.ObjectID,
+	reason string,
+	details map[string]interface{},
+) error {
+	return s.adminRepo.insertAuditLogEntry(
+		ctx, &models.AuditLogEntry{
+			ID:           primitive.NewObjectID(),
+			ActorID:      actor.UserID,
+			ClientIp:     actor.ClientIp,
+			Action:       action,
+			TargetUserID: targetUserId,
+			Reason:       reason,
+			Details:      details,
+			CreateTime:   primitive.NewDateTimeFromTime(time.Now()),
+		},
+	)
+}
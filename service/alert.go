@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"todo-grpc/models"
 )
 
 type AlertService interface {
 	CreateAlert(ctx context.Context, todo *models.Alert) (*models.Alert, error)
+	CreateAlertOnce(ctx context.Context, alert *models.Alert) (*models.Alert, error)
+	DeleteUserAlerts(ctx context.Context, userId primitive.ObjectID) error
 }
@@ -2,8 +2,10 @@ package alerts
 
 import (
 	"context"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"todo-grpc/models"
 	"todo-grpc/utils"
 )
@@ -16,6 +18,8 @@ type repoClient struct {
 
 type alertsRepo interface {
 	insertAlert(ctx context.Context, alert *models.Alert) (primitive.ObjectID, error)
+	upsertAlert(ctx context.Context, alert *models.Alert) (bool, error)
+	deleteAlertsOfUser(ctx context.Context, userId primitive.ObjectID) error
 }
 
 func newRepoClient(
@@ -38,3 +42,41 @@ func (r *repoClient) insertAlert(ctx context.Context, alert *models.Alert) (prim
 	}
 	return insertedResp.InsertedID.(primitive.ObjectID), nil
 }
+
+// upsertAlert stores the alert unless the user already has an alert of the
+// same kind for the todo. It reports whether a new alert was stored.
+func (r *repoClient) upsertAlert(ctx context.Context, alert *models.Alert) (bool, error) {
+	filter := bson.M{
+		"type":     alert.KafkaTopic,
+		"sub_type": alert.Type,
+		"user_id":  alert.UserID,
+		"todo_id":  alert.TodoID,
+	}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"_id":         alert.ID,
+			"create_time": alert.CreateTime,
+		},
+	}
+
+	res, err := r.alertsC.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+func (r *repoClient) deleteAlertsOfUser(ctx context.Context, userId primitive.ObjectID) error {
+	filter := bson.M{
+		"user_id": userId,
+	}
+	if _, err := r.alertsC.DeleteMany(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete alerts",
+			},
+		}
+	}
+	return nil
+}
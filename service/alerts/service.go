@@ -4,6 +4,7 @@ import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/service"
 	"todo-grpc/utils"
@@ -27,6 +28,7 @@ func NewAlertService(
 
 func (s *serviceClient) CreateAlert(ctx context.Context, alert *models.Alert) (*models.Alert, error) {
 	alert.ID = primitive.NewObjectID()
+	alert.CreateTime = primitive.NewDateTimeFromTime(time.Now())
 	_, err := s.alertsRepo.insertAlert(ctx, alert)
 	if err != nil {
 		return nil, err
@@ -34,3 +36,17 @@ func (s *serviceClient) CreateAlert(ctx context.Context, alert *models.Alert) (*
 
 	return alert, nil
 }
+
+func (s *serviceClient) CreateAlertOnce(ctx context.Context, alert *models.Alert) (*models.Alert, error) {
+	alert.ID = primitive.NewObjectID()
+	alert.CreateTime = primitive.NewDateTimeFromTime(time.Now())
+	if _, err := s.alertsRepo.upsertAlert(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (s *serviceClient) DeleteUserAlerts(ctx context.Context, userId primitive.ObjectID) error {
+	return s.alertsRepo.deleteAlertsOfUser(ctx, userId)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"todo-grpc/models"
+)
+
+type AnalyticsService interface {
+	EnsureSchema(ctx context.Context) error
+	RecordEvents(ctx context.Context, events []models.AnalyticsEvent) error
+	DailyActiveUsers(
+		ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+	) ([]models.DailyActiveUsers, error)
+	CompletionFunnel(
+		ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+	) (*models.CompletionFunnel, error)
+}
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"context"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type noopClient struct {
+	logger *utils.Logger
+}
+
+// NewNoopAnalyticsService stands in when no analytics store is configured, it
+// drops every event and has nothing to report.
+func NewNoopAnalyticsService(logger *utils.Logger) service.AnalyticsService {
+	return &noopClient{
+		logger: logger,
+	}
+}
+
+func (n *noopClient) EnsureSchema(ctx context.Context) error {
+	return nil
+}
+
+func (n *noopClient) RecordEvents(ctx context.Context, events []models.AnalyticsEvent) error {
+	return nil
+}
+
+func (n *noopClient) DailyActiveUsers(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) ([]models.DailyActiveUsers, error) {
+	return nil, errAnalyticsDisabled()
+}
+
+func (n *noopClient) CompletionFunnel(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) (*models.CompletionFunnel, error) {
+	return nil, errAnalyticsDisabled()
+}
+
+func errAnalyticsDisabled() error {
+	return &utils.FailedPreconditionError{
+		GeneralError: &utils.GeneralError{
+			DevInfo: "CLICKHOUSE_DSN isn't set",
+			Msg:     "analytics aren't enabled",
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package analytics
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+// insertBatchSize caps the rows sent to ClickHouse in one insert
+const insertBatchSize = 1000
+
+type repoClient struct {
+	db     *gorm.DB
+	logger *utils.Logger
+}
+
+type analyticsRepo interface {
+	ensureSchema(ctx context.Context) error
+	insertEvents(ctx context.Context, events []models.AnalyticsEvent) error
+	fetchDailyActiveUsers(
+		ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+	) ([]models.DailyActiveUsers, error)
+	fetchCompletionFunnel(
+		ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+	) (*models.CompletionFunnel, error)
+}
+
+func newRepoClient(
+	db *gorm.DB, logger *utils.Logger,
+) analyticsRepo {
+	return &repoClient{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ensureSchema creates the events table. Events are delivered at least once,
+// the ReplacingMergeTree drops redelivered copies as their sorting key
+// matches the original.
+func (r *repoClient) ensureSchema(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec(
+		`CREATE TABLE IF NOT EXISTS analytics_events (
+			event_id String,
+			event_type LowCardinality(String),
+			user_id String,
+			workspace_id String,
+			todo_id String,
+			priority LowCardinality(String),
+			occur_time DateTime64(3, 'UTC')
+		)
+		ENGINE = ReplacingMergeTree
+		PARTITION BY toYYYYMM(occur_time)
+		ORDER BY (event_type, occur_time, event_id)`,
+	).Error
+}
+
+func (r *repoClient) insertEvents(ctx context.Context, events []models.AnalyticsEvent) error {
+	err := r.db.WithContext(ctx).CreateInBatches(events, insertBatchSize).Error
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to store analytics events",
+			},
+		}
+	}
+
+	return nil
+}
+
+// scopeCondition restricts a query to the events of the active workspace, or
+// to the events of the user outside any workspace.
+func scopeCondition(db *gorm.DB, scope *models.Scope) *gorm.DB {
+	if scope.IsWorkspace() {
+		return db.Where("workspace_id = ?", scope.WorkspaceID.Hex())
+	}
+	return db.Where("user_id = ? AND workspace_id = ''", scope.UserID.Hex())
+}
+
+func (r *repoClient) fetchDailyActiveUsers(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) ([]models.DailyActiveUsers, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.AnalyticsEvent{}).
+		Select("toString(toDate(occur_time, ?)) AS date, uniqExact(user_id) AS users", filter.Location.String()).
+		Where("occur_time >= ? AND occur_time < ?", filter.Start, filter.End)
+
+	dailyActiveUsers := make([]models.DailyActiveUsers, 0)
+	err := scopeCondition(query, scope).
+		Group("date").
+		Order("date").
+		Scan(&dailyActiveUsers).Error
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch daily active users",
+			},
+		}
+	}
+
+	return dailyActiveUsers, nil
+}
+
+// fetchCompletionFunnel looks at every todo created in the range, no matter
+// when it was worked on afterwards. Completing a todo counts as working on it.
+func (r *repoClient) fetchCompletionFunnel(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) (*models.CompletionFunnel, error) {
+	todos := r.db.WithContext(ctx).
+		Model(&models.AnalyticsEvent{}).
+		Select(
+			"todo_id, "+
+				"minIf(occur_time, event_type = ?) AS create_time, "+
+				"countIf(event_type IN ?) > 0 AS updated, "+
+				"countIf(event_type = ?) > 0 AS completed",
+			models.AnalyticsEventTodoCreated,
+			[]models.AnalyticsEventType{models.AnalyticsEventTodoUpdated, models.AnalyticsEventTodoCompleted},
+			models.AnalyticsEventTodoCompleted,
+		).
+		Where("todo_id != ''")
+	todos = scopeCondition(todos, scope).
+		Group("todo_id").
+		Having("create_time >= ? AND create_time < ?", filter.Start, filter.End)
+
+	var funnel models.CompletionFunnel
+	err := r.db.WithContext(ctx).
+		Table("(?) AS todos", todos).
+		Select("count() AS created, countIf(updated) AS updated, countIf(completed) AS completed").
+		Scan(&funnel).Error
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch completion funnel",
+			},
+		}
+	}
+
+	return &funnel, nil
+}
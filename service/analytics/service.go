@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type serviceClient struct {
+	analyticsRepo analyticsRepo
+	logger        *utils.Logger
+}
+
+func NewAnalyticsService(
+	db *gorm.DB,
+	logger *utils.Logger,
+) service.AnalyticsService {
+	return &serviceClient{
+		analyticsRepo: newRepoClient(db, logger),
+		logger:        logger,
+	}
+}
+
+func (s *serviceClient) EnsureSchema(ctx context.Context) error {
+	return s.analyticsRepo.ensureSchema(ctx)
+}
+
+func (s *serviceClient) RecordEvents(ctx context.Context, events []models.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.analyticsRepo.insertEvents(ctx, events)
+}
+
+func (s *serviceClient) DailyActiveUsers(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) ([]models.DailyActiveUsers, error) {
+	return s.analyticsRepo.fetchDailyActiveUsers(ctx, scope, filter)
+}
+
+func (s *serviceClient) CompletionFunnel(
+	ctx context.Context, scope *models.Scope, filter *models.AnalyticsFilter,
+) (*models.CompletionFunnel, error) {
+	return s.analyticsRepo.fetchCompletionFunnel(ctx, scope, filter)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"todo-grpc/models"
+)
+
+type IdempotencyService interface {
+	EnsureIndexes(ctx context.Context) error
+	// Begin claims the key of the record for the request. When the key was
+	// used before by the same request the stored record is returned, its
+	// state tells whether it holds a response to replay.
+	Begin(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *models.IdempotencyRecord, response []byte) error
+	Release(ctx context.Context, record *models.IdempotencyRecord) error
+}
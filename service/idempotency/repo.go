@@ -0,0 +1,182 @@
+package idempotency
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db           *mongo.Client
+	idempotencyC *mongo.Collection
+	logger       *utils.Logger
+}
+
+type idempotencyRepo interface {
+	ensureIndexes(ctx context.Context) error
+	insertRecord(ctx context.Context, record *models.IdempotencyRecord) error
+	fetchRecord(ctx context.Context, userId primitive.ObjectID, method, key string) (*models.IdempotencyRecord, error)
+	takeOverRecord(ctx context.Context, record *models.IdempotencyRecord, now primitive.DateTime) (bool, error)
+	completeRecord(ctx context.Context, recordId primitive.ObjectID, response []byte) error
+	deleteRecord(ctx context.Context, recordId primitive.ObjectID) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) idempotencyRepo {
+	return &repoClient{
+		db:           db,
+		idempotencyC: utils.GetCollection(db, "idempotency_keys"),
+		logger:       logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.idempotencyC.Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "method", Value: 1}, {Key: "key", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "expire_time", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	)
+	return err
+}
+
+// insertRecord claims the key of the record, it fails with an AlreadyExists
+// error when the key is taken already.
+func (r *repoClient) insertRecord(ctx context.Context, record *models.IdempotencyRecord) error {
+	_, err := r.idempotencyC.InsertOne(ctx, record)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &utils.AlreadyExists{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "idempotency key already used",
+				},
+			}
+		}
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to store idempotency key",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) fetchRecord(
+	ctx context.Context, userId primitive.ObjectID, method, key string,
+) (*models.IdempotencyRecord, error) {
+	filter := bson.M{
+		"user_id": userId,
+		"method":  method,
+		"key":     key,
+	}
+
+	var record models.IdempotencyRecord
+	err := r.idempotencyC.FindOne(ctx, filter).Decode(&record)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "idempotency key not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch idempotency key",
+			},
+		}
+	}
+
+	return &record, nil
+}
+
+// takeOverRecord hands an abandoned in progress record over to record. It
+// reports whether the record was taken over.
+func (r *repoClient) takeOverRecord(
+	ctx context.Context, record *models.IdempotencyRecord, now primitive.DateTime,
+) (bool, error) {
+	filter := bson.M{
+		"_id":   record.ID,
+		"state": models.IdempotencyStateInProgress,
+		"lock_expire_time": bson.M{
+			"$lt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"lock_expire_time": record.LockExpireTime,
+			"expire_time":      record.ExpireTime,
+		},
+	}
+
+	res, err := r.idempotencyC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update idempotency key",
+			},
+		}
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *repoClient) completeRecord(ctx context.Context, recordId primitive.ObjectID, response []byte) error {
+	filter := bson.M{
+		"_id": recordId,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"state":    models.IdempotencyStateCompleted,
+			"response": response,
+		},
+	}
+
+	_, err := r.idempotencyC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to store idempotent response",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) deleteRecord(ctx context.Context, recordId primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":   recordId,
+		"state": models.IdempotencyStateInProgress,
+	}
+
+	_, err := r.idempotencyC.DeleteOne(ctx, filter)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to release idempotency key",
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const (
+	// recordTTL is how long responses are kept around for retries
+	recordTTL = 24 * time.Hour
+	// lockTTL is how long a request may take before a retry is allowed to
+	// run it again
+	lockTTL = time.Minute
+)
+
+type serviceClient struct {
+	idempotencyRepo idempotencyRepo
+	logger          *utils.Logger
+}
+
+func NewIdempotencyService(
+	db *mongo.Client,
+	logger *utils.Logger,
+) service.IdempotencyService {
+	return &serviceClient{
+		idempotencyRepo: newRepoClient(db, logger),
+		logger:          logger,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.idempotencyRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) Begin(
+	ctx context.Context, record *models.IdempotencyRecord,
+) (*models.IdempotencyRecord, error) {
+	now := time.Now()
+	record.ID = primitive.NewObjectID()
+	record.State = models.IdempotencyStateInProgress
+	record.CreateTime = primitive.NewDateTimeFromTime(now)
+	record.LockExpireTime = primitive.NewDateTimeFromTime(now.Add(lockTTL))
+	record.ExpireTime = primitive.NewDateTimeFromTime(now.Add(recordTTL))
+
+	err := s.idempotencyRepo.insertRecord(ctx, record)
+	if err == nil {
+		return record, nil
+	}
+	if _, ok := err.(*utils.AlreadyExists); !ok {
+		return nil, err
+	}
+
+	stored, err := s.idempotencyRepo.fetchRecord(ctx, record.UserID, record.Method, record.Key)
+	if err != nil {
+		return nil, err
+	}
+	if stored.RequestHash != record.RequestHash {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "request hash " + record.RequestHash + " doesn't match " + stored.RequestHash,
+				Msg:     "idempotency key was already used for a different request",
+			},
+		}
+	}
+	if stored.State == models.IdempotencyStateCompleted {
+		return stored, nil
+	}
+
+	// the first request may have died before it released the key
+	record.ID = stored.ID
+	takenOver, err := s.idempotencyRepo.takeOverRecord(ctx, record, primitive.NewDateTimeFromTime(now))
+	if err != nil {
+		return nil, err
+	}
+	if !takenOver {
+		return nil, &utils.AbortedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "a request with the same idempotency key is still in progress",
+			},
+		}
+	}
+
+	return record, nil
+}
+
+func (s *serviceClient) Complete(
+	ctx context.Context, record *models.IdempotencyRecord, response []byte,
+) error {
+	if err := s.idempotencyRepo.completeRecord(ctx, record.ID, response); err != nil {
+		return err
+	}
+
+	record.State = models.IdempotencyStateCompleted
+	record.Response = response
+	return nil
+}
+
+func (s *serviceClient) Release(ctx context.Context, record *models.IdempotencyRecord) error {
+	return s.idempotencyRepo.deleteRecord(ctx, record.ID)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+)
+
+type LoginAttemptService interface {
+	EnsureIndexes(ctx context.Context) error
+	// CheckLogin fails with a resource exhausted error telling when to retry
+	// while the account or the client ip is backing off or locked out
+	CheckLogin(ctx context.Context, email, clientIp string) error
+	// RecordFailure counts a failed login and reports whether it locked the
+	// account out
+	RecordFailure(ctx context.Context, email, clientIp string) (bool, error)
+	// RecordSuccess forgets the failed logins of the account, those of the
+	// client ip stay
+	RecordSuccess(ctx context.Context, email string) error
+}
@@ -0,0 +1,162 @@
+package loginattempt
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db             *mongo.Client
+	loginAttemptsC *mongo.Collection
+	logger         *utils.Logger
+}
+
+type loginAttemptRepo interface {
+	ensureIndexes(ctx context.Context) error
+	fetchAttempts(ctx context.Context, ids []string) ([]models.LoginAttempts, error)
+	incrementFailures(ctx context.Context, id string, now time.Time, window time.Duration) (*models.LoginAttempts, error)
+	blockUntil(ctx context.Context, id string, blockedUntil time.Time) error
+	deleteAttempts(ctx context.Context, id string) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) loginAttemptRepo {
+	return &repoClient{
+		db:             db,
+		loginAttemptsC: utils.GetCollection(db, "login_attempts"),
+		logger:         logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.loginAttemptsC.Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expire_time", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+	return err
+}
+
+func (r *repoClient) fetchAttempts(ctx context.Context, ids []string) ([]models.LoginAttempts, error) {
+	filter := bson.M{
+		"_id": bson.M{
+			"$in": ids,
+		},
+	}
+
+	cursor, err := r.loginAttemptsC.Find(ctx, filter)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch login attempts",
+			},
+		}
+	}
+	attempts := make([]models.LoginAttempts, 0)
+	if err = cursor.All(ctx, &attempts); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch login attempts",
+			},
+		}
+	}
+
+	return attempts, nil
+}
+
+// incrementFailures counts another failure and extends the window of the
+// failures. Failures of an expired window the TTL monitor didn't remove yet
+// start over.
+func (r *repoClient) incrementFailures(
+	ctx context.Context, id string, now time.Time, window time.Duration,
+) (*models.LoginAttempts, error) {
+	filter := bson.M{
+		"_id": id,
+	}
+	nowDate := primitive.NewDateTimeFromTime(now)
+	update := bson.A{
+		bson.M{
+			"$set": bson.M{
+				"failures": bson.M{
+					"$cond": bson.A{
+						bson.M{"$gt": bson.A{"$expire_time", nowDate}},
+						bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failures", 0}}, 1}},
+						1,
+					},
+				},
+				"expire_time": bson.M{
+					"$max": bson.A{"$expire_time", primitive.NewDateTimeFromTime(now.Add(window))},
+				},
+			},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var attempts models.LoginAttempts
+	err := r.loginAttemptsC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&attempts)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to record login attempt",
+			},
+		}
+	}
+
+	return &attempts, nil
+}
+
+// blockUntil refuses logins until then, the failures are kept at least as
+// long.
+func (r *repoClient) blockUntil(ctx context.Context, id string, blockedUntil time.Time) error {
+	filter := bson.M{
+		"_id": id,
+	}
+	blockedUntilDate := primitive.NewDateTimeFromTime(blockedUntil)
+	update := bson.M{
+		"$set": bson.M{
+			"blocked_until": blockedUntilDate,
+		},
+		"$max": bson.M{
+			"expire_time": blockedUntilDate,
+		},
+	}
+
+	if _, err := r.loginAttemptsC.UpdateOne(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to record login attempt",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) deleteAttempts(ctx context.Context, id string) error {
+	filter := bson.M{
+		"_id": id,
+	}
+
+	if _, err := r.loginAttemptsC.DeleteOne(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to reset login attempts",
+			},
+		}
+	}
+
+	return nil
+}
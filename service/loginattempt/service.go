@@ -0,0 +1,124 @@
+package loginattempt
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type serviceClient struct {
+	loginAttemptRepo loginAttemptRepo
+	logger           *utils.Logger
+	accountPolicy    models.LoginThrottlePolicy
+	ipPolicy         models.LoginThrottlePolicy
+	failureWindow    time.Duration
+}
+
+func NewLoginAttemptService(
+	db *mongo.Client,
+	logger *utils.Logger,
+	config utils.EnvConfig,
+) service.LoginAttemptService {
+	return &serviceClient{
+		loginAttemptRepo: newRepoClient(db, logger),
+		logger:           logger,
+		accountPolicy: models.LoginThrottlePolicy{
+			BackoffThreshold: config.GetLoginBackoffThreshold(),
+			BackoffBase:      config.GetLoginBackoffBase(),
+			LockoutThreshold: config.GetLoginLockoutThreshold(),
+			LockoutDuration:  config.GetLoginLockoutDuration(),
+		},
+		ipPolicy: models.LoginThrottlePolicy{
+			BackoffThreshold: config.GetLoginIpBackoffThreshold(),
+			BackoffBase:      config.GetLoginBackoffBase(),
+			LockoutThreshold: config.GetLoginIpLockoutThreshold(),
+			LockoutDuration:  config.GetLoginLockoutDuration(),
+		},
+		failureWindow: config.GetLoginFailureWindow(),
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.loginAttemptRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) CheckLogin(ctx context.Context, email, clientIp string) error {
+	ids := []string{accountKey(email)}
+	if clientIp != "" {
+		ids = append(ids, ipKey(clientIp))
+	}
+	attempts, err := s.loginAttemptRepo.fetchAttempts(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		retryAfter = max(retryAfter, attempt.BlockedUntil.Time().Sub(now))
+	}
+	if retryAfter <= 0 {
+		return nil
+	}
+
+	return &utils.ResourceExhaustedError{
+		GeneralError: &utils.GeneralError{
+			Msg: "too many failed login attempts, try again later",
+		},
+		// rounded up so that retrying right after it isn't refused again
+		RetryAfter: retryAfter.Truncate(time.Second) + time.Second,
+	}
+}
+
+func (s *serviceClient) RecordFailure(ctx context.Context, email, clientIp string) (bool, error) {
+	now := time.Now()
+	lockedOut, err := s.recordFailure(ctx, accountKey(email), s.accountPolicy, now)
+	if err != nil {
+		return false, err
+	}
+
+	if clientIp != "" {
+		ipLockedOut, err := s.recordFailure(ctx, ipKey(clientIp), s.ipPolicy, now)
+		if err != nil {
+			return false, err
+		}
+		if ipLockedOut {
+			s.logger.Info("client ip %s is locked out of logging in", clientIp)
+		}
+	}
+
+	return lockedOut, nil
+}
+
+func (s *serviceClient) RecordSuccess(ctx context.Context, email string) error {
+	return s.loginAttemptRepo.deleteAttempts(ctx, accountKey(email))
+}
+
+func (s *serviceClient) recordFailure(
+	ctx context.Context, id string, policy models.LoginThrottlePolicy, now time.Time,
+) (bool, error) {
+	attempts, err := s.loginAttemptRepo.incrementFailures(ctx, id, now, s.failureWindow)
+	if err != nil {
+		return false, err
+	}
+
+	delay, lockedOut := policy.Delay(attempts.Failures)
+	if delay <= 0 {
+		return false, nil
+	}
+	if err = s.loginAttemptRepo.blockUntil(ctx, id, now.Add(delay)); err != nil {
+		return false, err
+	}
+	return lockedOut, nil
+}
+
+func accountKey(email string) string {
+	return "account:" + email
+}
+
+func ipKey(clientIp string) string {
+	return "ip:" + clientIp
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"todo-grpc/models"
+)
+
+type OidcService interface {
+	EnsureIndexes(ctx context.Context) error
+	// ListProviders returns the names of the providers users can log in with
+	ListProviders() []string
+	// StartLogin returns the url to send the user to and the state the
+	// provider sends back with the code
+	StartLogin(ctx context.Context, provider string) (string, string, error)
+	// CompleteLogin exchanges the code of the provider for our tokens, the
+	// state can only be used once
+	CompleteLogin(ctx context.Context, state, code string) (*models.AuthTokens, error)
+}
@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	oidcLoginsC *mongo.Collection
+	logger      *utils.Logger
+}
+
+type oidcRepo interface {
+	ensureIndexes(ctx context.Context) error
+	insertLogin(ctx context.Context, login *models.OidcLogin) error
+	takeLogin(ctx context.Context, stateHash string, now primitive.DateTime) (*models.OidcLogin, error)
+}
+
+func newRepoClient(db *mongo.Client, logger *utils.Logger) oidcRepo {
+	return &repoClient{
+		oidcLoginsC: utils.GetCollection(db, "oidc_logins"),
+		logger:      logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.oidcLoginsC.Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expire_time", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+	return err
+}
+
+func (r *repoClient) insertLogin(ctx context.Context, login *models.OidcLogin) error {
+	if _, err := r.oidcLoginsC.InsertOne(ctx, login); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to start login",
+			},
+		}
+	}
+
+	return nil
+}
+
+// takeLogin deletes the unexpired login and returns it, so that its state
+// can't be used again
+func (r *repoClient) takeLogin(
+	ctx context.Context, stateHash string, now primitive.DateTime,
+) (*models.OidcLogin, error) {
+	filter := bson.M{
+		"_id": stateHash,
+		"expire_time": bson.M{
+			"$gt": now,
+		},
+	}
+
+	var login models.OidcLogin
+	err := r.oidcLoginsC.FindOneAndDelete(ctx, filter).Decode(&login)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.UnAuthenticatedError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid or expired login state, start the login again",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch login",
+			},
+		}
+	}
+
+	return &login, nil
+}
@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	oidcProvider "todo-grpc/providers/oidc"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+// loginTTL is how long users have to log in with the provider
+const loginTTL = 10 * time.Minute
+
+type serviceClient struct {
+	oidcRepo    oidcRepo
+	logger      *utils.Logger
+	oidcClient  *oidcProvider.Client
+	userService service.UserService
+}
+
+func NewOidcService(
+	db *mongo.Client,
+	logger *utils.Logger,
+	oidcClient *oidcProvider.Client,
+	userService service.UserService,
+) service.OidcService {
+	return &serviceClient{
+		oidcRepo:    newRepoClient(db, logger),
+		logger:      logger,
+		oidcClient:  oidcClient,
+		userService: userService,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.oidcRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) ListProviders() []string {
+	return s.oidcClient.ProviderNames()
+}
+
+func (s *serviceClient) StartLogin(ctx context.Context, provider string) (string, string, error) {
+	secrets := make([]string, 3)
+	for i := range secrets {
+		secret, err := utils.GenerateSecretToken()
+		if err != nil {
+			return "", "", &utils.SystemInternalError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "unable to start login",
+				},
+			}
+		}
+		secrets[i] = secret
+	}
+	state, nonce, codeVerifier := secrets[0], secrets[1], secrets[2]
+
+	authorizationUrl, err := s.oidcClient.AuthorizationUrl(ctx, provider, state, nonce, codeVerifier)
+	if err != nil {
+		return "", "", providerError(err)
+	}
+
+	login := &models.OidcLogin{
+		ID:           utils.HashSecretToken(state),
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpireTime:   primitive.NewDateTimeFromTime(time.Now().Add(loginTTL)),
+	}
+	if err = s.oidcRepo.insertLogin(ctx, login); err != nil {
+		return "", "", err
+	}
+
+	return authorizationUrl, state, nil
+}
+
+func (s *serviceClient) CompleteLogin(ctx context.Context, state, code string) (*models.AuthTokens, error) {
+	login, err := s.oidcRepo.takeLogin(
+		ctx, utils.HashSecretToken(state), primitive.NewDateTimeFromTime(time.Now()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.oidcClient.Exchange(ctx, login.Provider, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	return s.userService.LoginWithIdentity(ctx, identity)
+}
+
+func providerError(err error) error {
+	if errors.Is(err, oidcProvider.ErrUnknownProvider) {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unknown login provider",
+			},
+		}
+	}
+	return &utils.UnAuthenticatedError{
+		GeneralError: &utils.GeneralError{
+			DevInfo: err.Error(),
+			Msg:     "unable to log in with the provider",
+		},
+	}
+}
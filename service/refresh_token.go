@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RefreshTokenService interface {
+	EnsureIndexes(ctx context.Context) error
+	// IssueRefreshToken starts a new token family for the user
+	IssueRefreshToken(ctx context.Context, userId primitive.ObjectID) (string, error)
+	// RotateRefreshToken exchanges a refresh token for the next one of its
+	// family and returns it with the id of its user. Using a token that was
+	// rotated already revokes the whole family.
+	RotateRefreshToken(ctx context.Context, token string) (string, primitive.ObjectID, error)
+	// RevokeRefreshToken revokes the family of a refresh token of the user
+	RevokeRefreshToken(ctx context.Context, userId primitive.ObjectID, token string) error
+	RevokeUserRefreshTokens(ctx context.Context, userId primitive.ObjectID) error
+	DeleteUserRefreshTokens(ctx context.Context, userId primitive.ObjectID) error
+}
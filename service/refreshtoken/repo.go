@@ -0,0 +1,172 @@
+package refreshtoken
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db             *mongo.Client
+	refreshTokensC *mongo.Collection
+	logger         *utils.Logger
+}
+
+type refreshTokenRepo interface {
+	startSession() (mongo.Session, error)
+	ensureIndexes(ctx context.Context) error
+	insertRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	fetchRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	rotateRefreshToken(ctx context.Context, tokenHash string, now primitive.DateTime) (*models.RefreshToken, error)
+	revokeRefreshTokens(ctx context.Context, filter bson.M) error
+	deleteRefreshTokens(ctx context.Context, filter bson.M) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) refreshTokenRepo {
+	return &repoClient{
+		db:             db,
+		refreshTokensC: utils.GetCollection(db, "refresh_tokens"),
+		logger:         logger,
+	}
+}
+
+func (r *repoClient) startSession() (mongo.Session, error) {
+	return r.db.StartSession()
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.refreshTokensC.Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "token_hash", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "family_id", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "expire_time", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	)
+	return err
+}
+
+func (r *repoClient) insertRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	_, err := r.refreshTokensC.InsertOne(ctx, token)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to store refresh token",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) fetchRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	filter := bson.M{
+		"token_hash": tokenHash,
+	}
+
+	var token models.RefreshToken
+	err := r.refreshTokensC.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.UnAuthenticatedError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid refresh token",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch refresh token",
+			},
+		}
+	}
+
+	return &token, nil
+}
+
+// rotateRefreshToken marks an active unexpired token as rotated and returns
+// it, nil when there is no such token.
+func (r *repoClient) rotateRefreshToken(
+	ctx context.Context, tokenHash string, now primitive.DateTime,
+) (*models.RefreshToken, error) {
+	filter := bson.M{
+		"token_hash": tokenHash,
+		"state":      models.RefreshTokenActive,
+		"expire_time": bson.M{
+			"$gt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"state":       models.RefreshTokenRotated,
+			"rotate_time": now,
+		},
+	}
+
+	var token models.RefreshToken
+	err := r.refreshTokensC.FindOneAndUpdate(ctx, filter, update).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to rotate refresh token",
+			},
+		}
+	}
+
+	return &token, nil
+}
+
+func (r *repoClient) revokeRefreshTokens(ctx context.Context, filter bson.M) error {
+	update := bson.M{
+		"$set": bson.M{
+			"state": models.RefreshTokenRevoked,
+		},
+	}
+
+	if _, err := r.refreshTokensC.UpdateMany(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to revoke refresh tokens",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) deleteRefreshTokens(ctx context.Context, filter bson.M) error {
+	if _, err := r.refreshTokensC.DeleteMany(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete refresh tokens",
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package refreshtoken
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+// refreshTokenTTL is how long a refresh token can be used, every rotation
+// starts it over
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+type serviceClient struct {
+	refreshTokenRepo refreshTokenRepo
+	logger           *utils.Logger
+}
+
+func NewRefreshTokenService(db *mongo.Client, logger *utils.Logger) service.RefreshTokenService {
+	return &serviceClient{
+		refreshTokenRepo: newRepoClient(db, logger),
+		logger:           logger,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.refreshTokenRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) IssueRefreshToken(ctx context.Context, userId primitive.ObjectID) (string, error) {
+	return s.insertRefreshToken(ctx, userId, primitive.NewObjectID())
+}
+
+func (s *serviceClient) RotateRefreshToken(
+	ctx context.Context, token string,
+) (string, primitive.ObjectID, error) {
+	tokenHash := utils.HashSecretToken(token)
+
+	session, err := s.refreshTokenRepo.startSession()
+	if err != nil {
+		return "", primitive.NilObjectID, err
+	}
+	defer session.EndSession(ctx)
+
+	var rotated *models.RefreshToken
+	var nextToken string
+	wc := writeconcern.New(writeconcern.WMajority())
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		var err error
+		rotated, err = s.refreshTokenRepo.rotateRefreshToken(
+			ctx, tokenHash, primitive.NewDateTimeFromTime(time.Now()),
+		)
+		if err != nil || rotated == nil {
+			return nil, err
+		}
+
+		nextToken, err = s.insertRefreshToken(ctx, rotated.UserID, rotated.FamilyID)
+		return nil, err
+	}
+
+	_, err = session.WithTransaction(ctx, callback, txnOpts)
+	if err != nil {
+		return "", primitive.NilObjectID, err
+	}
+	if rotated != nil {
+		return nextToken, rotated.UserID, nil
+	}
+
+	// the token can't be used, find out whether it is a rotated one used again
+	stored, err := s.refreshTokenRepo.fetchRefreshToken(ctx, tokenHash)
+	if err != nil {
+		return "", primitive.NilObjectID, err
+	}
+	if stored.State == models.RefreshTokenRotated {
+		s.logger.Info(
+			"refresh token of user %s reused, revoking family %s", stored.UserID.Hex(), stored.FamilyID.Hex(),
+		)
+		err = s.refreshTokenRepo.revokeRefreshTokens(ctx, bson.M{"family_id": stored.FamilyID})
+		if err != nil {
+			return "", primitive.NilObjectID, err
+		}
+	}
+
+	return "", primitive.NilObjectID, &utils.UnAuthenticatedError{
+		GeneralError: &utils.GeneralError{
+			DevInfo: "refresh token is " + string(stored.State),
+			Msg:     "invalid refresh token",
+		},
+	}
+}
+
+func (s *serviceClient) RevokeRefreshToken(ctx context.Context, userId primitive.ObjectID, token string) error {
+	stored, err := s.refreshTokenRepo.fetchRefreshToken(ctx, utils.HashSecretToken(token))
+	if err != nil {
+		return err
+	}
+	if stored.UserID != userId {
+		return &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "refresh token belongs to another user",
+			},
+		}
+	}
+
+	return s.refreshTokenRepo.revokeRefreshTokens(ctx, bson.M{"family_id": stored.FamilyID})
+}
+
+func (s *serviceClient) RevokeUserRefreshTokens(ctx context.Context, userId primitive.ObjectID) error {
+	return s.refreshTokenRepo.revokeRefreshTokens(ctx, bson.M{"user_id": userId})
+}
+
+func (s *serviceClient) DeleteUserRefreshTokens(ctx context.Context, userId primitive.ObjectID) error {
+	return s.refreshTokenRepo.deleteRefreshTokens(ctx, bson.M{"user_id": userId})
+}
+
+// insertRefreshToken stores a new token of the family and returns the opaque
+// token handed to the client.
+func (s *serviceClient) insertRefreshToken(
+	ctx context.Context, userId, familyId primitive.ObjectID,
+) (string, error) {
+	token, err := utils.GenerateSecretToken()
+	if err != nil {
+		return "", &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create refresh token",
+			},
+		}
+	}
+
+	now := time.Now()
+	err = s.refreshTokenRepo.insertRefreshToken(
+		ctx, &models.RefreshToken{
+			ID:         primitive.NewObjectID(),
+			UserID:     userId,
+			FamilyID:   familyId,
+			TokenHash:  utils.HashSecretToken(token),
+			State:      models.RefreshTokenActive,
+			CreateTime: primitive.NewDateTimeFromTime(now),
+			ExpireTime: primitive.NewDateTimeFromTime(now.Add(refreshTokenTTL)),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"todo-grpc/models"
+)
+
+type RevocationService interface {
+	EnsureIndexes(ctx context.Context) error
+	// RevokeToken revokes a single token until it expires
+	RevokeToken(ctx context.Context, claims *models.UserClaims) error
+	// RevokeAllTokens revokes every token issued to the user so far and
+	// returns the generation of the tokens issued from now on
+	RevokeAllTokens(ctx context.Context, userId primitive.ObjectID) (int64, error)
+	FetchTokenGeneration(ctx context.Context, userId primitive.ObjectID) (int64, error)
+	// IsTokenRevoked also reports the tokens of disabled users as revoked
+	IsTokenRevoked(ctx context.Context, claims *models.UserClaims) (bool, error)
+	// SetUserDisabled makes every token of the user be rejected until the
+	// user is enabled again
+	SetUserDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) error
+	IsUserDisabled(ctx context.Context, userId primitive.ObjectID) (bool, error)
+}
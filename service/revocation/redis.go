@@ -0,0 +1,153 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type redisClient struct {
+	client *redis.Client
+	logger *utils.Logger
+}
+
+// NewRedisRevocationService keeps revoked tokens in redis, their keys expire
+// together with the tokens.
+func NewRedisRevocationService(client *redis.Client, logger *utils.Logger) service.RevocationService {
+	return &redisClient{
+		client: client,
+		logger: logger,
+	}
+}
+
+func revokedTokenKey(tokenId string) string {
+	return "revoked_token:" + tokenId
+}
+
+func tokenGenerationKey(userId string) string {
+	return "token_generation:" + userId
+}
+
+// userDisabledKey is set while the user is disabled, it doesn't expire
+func userDisabledKey(userId string) string {
+	return "user_disabled:" + userId
+}
+
+func (r *redisClient) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (r *redisClient) RevokeToken(ctx context.Context, claims *models.UserClaims) error {
+	ttl := time.Until(time.Unix(claims.ExpiresAt, 0))
+	if ttl <= 0 {
+		// expired tokens are rejected anyway
+		return nil
+	}
+
+	if err := r.client.Set(ctx, revokedTokenKey(claims.Id), claims.UserID, ttl).Err(); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to revoke token",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *redisClient) RevokeAllTokens(ctx context.Context, userId primitive.ObjectID) (int64, error) {
+	generation, err := r.client.Incr(ctx, tokenGenerationKey(userId.Hex())).Result()
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to revoke tokens",
+			},
+		}
+	}
+
+	return generation, nil
+}
+
+func (r *redisClient) FetchTokenGeneration(ctx context.Context, userId primitive.ObjectID) (int64, error) {
+	generation, err := r.client.Get(ctx, tokenGenerationKey(userId.Hex())).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to check token",
+			},
+		}
+	}
+
+	return generation, nil
+}
+
+func (r *redisClient) IsTokenRevoked(ctx context.Context, claims *models.UserClaims) (bool, error) {
+	pipe := r.client.Pipeline()
+	generationCmd := pipe.Get(ctx, tokenGenerationKey(claims.UserID))
+	revokedCmd := pipe.Exists(ctx, revokedTokenKey(claims.Id))
+	disabledCmd := pipe.Exists(ctx, userDisabledKey(claims.UserID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return true, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to check token",
+			},
+		}
+	}
+
+	generation, err := generationCmd.Int64()
+	if err != nil && err != redis.Nil {
+		return true, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: fmt.Sprintf("invalid token generation: %v", err),
+				Msg:     "unable to check token",
+			},
+		}
+	}
+
+	return claims.Generation < generation || revokedCmd.Val() > 0 || disabledCmd.Val() > 0, nil
+}
+
+func (r *redisClient) SetUserDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) error {
+	var err error
+	if disabled {
+		err = r.client.Set(ctx, userDisabledKey(userId.Hex()), 1, 0).Err()
+	} else {
+		err = r.client.Del(ctx, userDisabledKey(userId.Hex())).Err()
+	}
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update user tokens",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *redisClient) IsUserDisabled(ctx context.Context, userId primitive.ObjectID) (bool, error) {
+	count, err := r.client.Exists(ctx, userDisabledKey(userId.Hex())).Result()
+	if err != nil {
+		return true, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to check user",
+			},
+		}
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,151 @@
+package revocation
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db           *mongo.Client
+	revokedC     *mongo.Collection
+	generationsC *mongo.Collection
+	logger       *utils.Logger
+}
+
+type revocationRepo interface {
+	ensureIndexes(ctx context.Context) error
+	insertRevokedToken(ctx context.Context, token *models.RevokedToken) error
+	countRevokedTokens(ctx context.Context, tokenId string) (int64, error)
+	incrementGeneration(ctx context.Context, userId primitive.ObjectID) (int64, error)
+	fetchGeneration(ctx context.Context, userId primitive.ObjectID) (*models.TokenGeneration, error)
+	setDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) revocationRepo {
+	return &repoClient{
+		db:           db,
+		revokedC:     utils.GetCollection(db, "revoked_tokens"),
+		generationsC: utils.GetCollection(db, "token_generations"),
+		logger:       logger,
+	}
+}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.revokedC.Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expire_time", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+	return err
+}
+
+func (r *repoClient) insertRevokedToken(ctx context.Context, token *models.RevokedToken) error {
+	_, err := r.revokedC.InsertOne(ctx, token)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to revoke token",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) countRevokedTokens(ctx context.Context, tokenId string) (int64, error) {
+	filter := bson.M{
+		"_id": tokenId,
+	}
+
+	count, err := r.revokedC.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to check token",
+			},
+		}
+	}
+
+	return count, nil
+}
+
+func (r *repoClient) incrementGeneration(ctx context.Context, userId primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"_id": userId,
+	}
+	update := bson.M{
+		"$inc": bson.M{
+			"generation": 1,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var generation models.TokenGeneration
+	err := r.generationsC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&generation)
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to revoke tokens",
+			},
+		}
+	}
+
+	return generation.Generation, nil
+}
+
+func (r *repoClient) fetchGeneration(ctx context.Context, userId primitive.ObjectID) (*models.TokenGeneration, error) {
+	filter := bson.M{
+		"_id": userId,
+	}
+
+	generation := &models.TokenGeneration{UserID: userId}
+	err := r.generationsC.FindOne(ctx, filter).Decode(generation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return generation, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to check token",
+			},
+		}
+	}
+
+	return generation, nil
+}
+
+func (r *repoClient) setDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) error {
+	filter := bson.M{
+		"_id": userId,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"disabled": disabled,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := r.generationsC.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update user tokens",
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package revocation
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type serviceClient struct {
+	revocationRepo revocationRepo
+	logger         *utils.Logger
+}
+
+// NewRevocationService keeps revoked tokens in a mongo collection, they are
+// removed by a TTL index once expired.
+func NewRevocationService(db *mongo.Client, logger *utils.Logger) service.RevocationService {
+	return &serviceClient{
+		revocationRepo: newRepoClient(db, logger),
+		logger:         logger,
+	}
+}
+
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.revocationRepo.ensureIndexes(ctx)
+}
+
+func (s *serviceClient) RevokeToken(ctx context.Context, claims *models.UserClaims) error {
+	userId, err := utils.ParseObjectId(claims.UserID, "user id")
+	if err != nil {
+		return err
+	}
+
+	return s.revocationRepo.insertRevokedToken(
+		ctx, &models.RevokedToken{
+			ID:         claims.Id,
+			UserID:     userId,
+			ExpireTime: primitive.NewDateTimeFromTime(time.Unix(claims.ExpiresAt, 0)),
+		},
+	)
+}
+
+func (s *serviceClient) RevokeAllTokens(ctx context.Context, userId primitive.ObjectID) (int64, error) {
+	return s.revocationRepo.incrementGeneration(ctx, userId)
+}
+
+func (s *serviceClient) FetchTokenGeneration(ctx context.Context, userId primitive.ObjectID) (int64, error) {
+	generation, err := s.revocationRepo.fetchGeneration(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	return generation.Generation, nil
+}
+
+func (s *serviceClient) IsTokenRevoked(ctx context.Context, claims *models.UserClaims) (bool, error) {
+	userId, err := utils.ParseObjectId(claims.UserID, "user id")
+	if err != nil {
+		return true, err
+	}
+
+	generation, err := s.revocationRepo.fetchGeneration(ctx, userId)
+	if err != nil {
+		return true, err
+	}
+	if generation.Disabled || claims.Generation < generation.Generation {
+		return true, nil
+	}
+
+	count, err := s.revocationRepo.countRevokedTokens(ctx, claims.Id)
+	if err != nil {
+		return true, err
+	}
+	return count > 0, nil
+}
+
+func (s *serviceClient) SetUserDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) error {
+	return s.revocationRepo.setDisabled(ctx, userId, disabled)
+}
+
+func (s *serviceClient) IsUserDisabled(ctx context.Context, userId primitive.ObjectID) (bool, error) {
+	generation, err := s.revocationRepo.fetchGeneration(ctx, userId)
+	if err != nil {
+		return true, err
+	}
+	return generation.Disabled, nil
+}
@@ -2,13 +2,48 @@ package service
 
 import (
 	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 	"todo-grpc/models"
 )
 
 type TodoService interface {
 	CreateTodo(ctx context.Context, todo *models.Todo) (*models.Todo, error)
-	ListTodos(ctx context.Context, userId string, filter *models.ListTodoFilter) (*models.ListTodoRes, error)
-	FetchTodo(ctx context.Context, todoId, userId string) (*models.Todo, error)
-	UpdateTodo(ctx context.Context, todo *models.Todo, fieldMasks []string) (*models.Todo, error)
+	ListTodos(ctx context.Context, scope *models.Scope, filter *models.ListTodoFilter) (*models.ListTodoRes, error)
+	FetchTodo(ctx context.Context, todoId string, scope *models.Scope) (*models.Todo, error)
+	UpdateTodo(ctx context.Context, scope *models.Scope, todo *models.Todo, fieldMasks []string) (*models.Todo, error)
+	ListAssignedTodos(ctx context.Context, scope *models.Scope, filter *models.ListTodoFilter) (*models.ListTodoRes, error)
+	DeleteTodo(ctx context.Context, todoId string, scope *models.Scope) error
 	FetchTodosWithNearbyDeadline(ctx context.Context) ([]models.Todo, error)
+	SyncTodos(
+		ctx context.Context, scope *models.Scope, since models.SyncPosition, limit int64,
+	) (*models.SyncTodosRes, error)
+	PushTodoChanges(
+		ctx context.Context, scope *models.Scope, changes []models.TodoChange,
+	) ([]models.TodoChangeResult, error)
+	CompactTombstones(ctx context.Context, retention time.Duration) (int64, error)
+	// UnassignTodos clears the user as assignee of the todos of the given
+	// workspaces, for when they are no longer a member
+	UnassignTodos(ctx context.Context, userId primitive.ObjectID, workspaceIds []primitive.ObjectID) error
+	// DeleteUserTodos deletes the personal todos of the user and every todo
+	// of the given workspaces, todos they made in other workspaces stay
+	DeleteUserTodos(ctx context.Context, userId primitive.ObjectID, workspaceIds []primitive.ObjectID) error
+	GetStats(ctx context.Context, scope *models.Scope, filter *models.StatsFilter) (*models.TodoStats, error)
+	GetAgenda(ctx context.Context, scope *models.Scope, filter *models.AgendaFilter) (*models.Agenda, error)
+	GetBoard(ctx context.Context, scope *models.Scope, filter *models.BoardFilter) (*models.Board, error)
+	MoveCard(ctx context.Context, scope *models.Scope, move *models.CardMove) (*models.Todo, error)
+	SnoozeTodo(ctx context.Context, scope *models.Scope, todoId string, until time.Time) (*models.Todo, error)
+	// ReleaseExpiredSnoozes returns the todos whose snooze ended since the
+	// last call, every todo is only returned once
+	ReleaseExpiredSnoozes(ctx context.Context) ([]models.Todo, error)
+	// RestoreSnoozes undoes the release of the todos whose end of snooze
+	// couldn't be announced, the next call returns them again
+	RestoreSnoozes(ctx context.Context, todoIds []primitive.ObjectID) error
+	WatchTodos(
+		ctx context.Context,
+		scope *models.Scope,
+		filter *models.WatchTodoFilter,
+		resumeToken string,
+		send func(event *models.TodoEvent) error,
+	) error
 }
@@ -6,15 +6,18 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
 	"time"
 	"todo-grpc/models"
 	"todo-grpc/utils"
 )
 
 type repoClient struct {
-	db     *mongo.Client
-	todoC  *mongo.Collection
-	logger *utils.Logger
+	db           *mongo.Client
+	todoC        *mongo.Collection
+	tombstonesC  *mongo.Collection
+	syncCounterC *mongo.Collection
+	logger       *utils.Logger
 }
 
 type todosRepo interface {
@@ -23,29 +26,109 @@ type todosRepo interface {
 		ctx context.Context, todo *models.Todo,
 	) (primitive.ObjectID, error)
 	fetchTodos(
-		ctx context.Context, userId primitive.ObjectID, limitFilter *models.ListTodoFilter,
+		ctx context.Context, scope *models.Scope, limitFilter *models.ListTodoFilter,
 	) ([]models.Todo, error)
 	countTodos(
-		ctx context.Context, userId primitive.ObjectID,
+		ctx context.Context, scope *models.Scope, limitFilter *models.ListTodoFilter,
 	) (int64, error)
 	fetchTodo(
-		ctx context.Context, todoId, userId primitive.ObjectID,
+		ctx context.Context, todoId primitive.ObjectID, scope *models.Scope,
 	) (*models.Todo, error)
 	updateTodo(
-		ctx context.Context, taskId primitive.ObjectID, update bson.M,
+		ctx context.Context, taskId primitive.ObjectID, scope *models.Scope, update, precondition bson.M,
+	) (*models.Todo, error)
+	deleteTodo(
+		ctx context.Context, todoId primitive.ObjectID, scope *models.Scope, precondition bson.M,
 	) (*models.Todo, error)
+	fetchAssignedTodos(
+		ctx context.Context, scope *models.Scope, workspaceIds []primitive.ObjectID,
+		limitFilter *models.ListTodoFilter,
+	) ([]models.Todo, error)
+	countAssignedTodos(
+		ctx context.Context, scope *models.Scope, workspaceIds []primitive.ObjectID,
+	) (int64, error)
+	unassignTodos(ctx context.Context, workspaceId, userId primitive.ObjectID, syncSeq int64) error
+	countUserTodos(
+		ctx context.Context, userId primitive.ObjectID,
+	) (int64, error)
 	fetchTodosWithNearbyDeadline(
 		ctx context.Context,
 	) ([]models.Todo, error)
+	restoreSnoozes(
+		ctx context.Context, todoIds []primitive.ObjectID, now time.Time,
+	) error
+	releaseExpiredSnooze(
+		ctx context.Context, now time.Time,
+	) (*models.Todo, error)
+	findWatchedTodos(
+		ctx context.Context, scope *models.Scope, watchFilter *models.WatchTodoFilter,
+	) (*mongo.Cursor, error)
+	watchTodos(
+		ctx context.Context, scope *models.Scope, watchFilter *models.WatchTodoFilter, resumeToken bson.Raw,
+	) (*mongo.ChangeStream, error)
+	nextSyncSeq(
+		ctx context.Context, scope *models.Scope,
+	) (int64, error)
+	fetchSyncCounter(
+		ctx context.Context, scope *models.Scope,
+	) (*models.SyncCounter, error)
+	backfillSyncSeq(
+		ctx context.Context, scope *models.Scope,
+	) error
+	fetchTodosSince(
+		ctx context.Context, scope *models.Scope, since models.SyncPosition, upTo int64, limit int64,
+	) ([]models.Todo, error)
+	insertTombstone(
+		ctx context.Context, tombstone *models.TodoTombstone,
+	) error
+	fetchTombstone(
+		ctx context.Context, todoId primitive.ObjectID, scope *models.Scope,
+	) (*models.TodoTombstone, error)
+	fetchTombstonesSince(
+		ctx context.Context, scope *models.Scope, since, upTo int64,
+	) ([]models.TodoTombstone, error)
+	compactTombstones(
+		ctx context.Context, deletedBefore time.Time,
+	) (int64, error)
+	aggregateStats(
+		ctx context.Context, scope *models.Scope, statsFilter *models.StatsFilter,
+	) (*todoStatsFacets, error)
+	fetchAgendaTodos(
+		ctx context.Context, scope *models.Scope, start, end time.Time,
+	) ([]models.Todo, error)
+	fetchOverdueTodos(
+		ctx context.Context, scope *models.Scope, now time.Time, limit int64,
+	) ([]models.Todo, error)
+	fetchMaxRank(
+		ctx context.Context, scope *models.Scope,
+	) (string, error)
+	backfillRanks(
+		ctx context.Context, scope *models.Scope,
+	) error
+	breakRankTie(
+		ctx context.Context, scope *models.Scope, rank string,
+	) error
+	countBoardColumns(
+		ctx context.Context, scope *models.Scope, groupBy models.BoardGroupBy, limitFilter *models.ListTodoFilter,
+	) ([]boardColumnCount, error)
+	fetchBoardCards(
+		ctx context.Context, scope *models.Scope, groupBy models.BoardGroupBy, column string,
+		limitFilter *models.ListTodoFilter,
+	) ([]models.Todo, error)
+	deleteScopeTodos(
+		ctx context.Context, scope *models.Scope,
+	) error
 }
 
 func newRepoClient(
 	db *mongo.Client, logger *utils.Logger,
 ) todosRepo {
 	return &repoClient{
-		db:     db,
-		todoC:  utils.GetCollection(db, "todos"),
-		logger: logger,
+		db:           db,
+		todoC:        utils.GetCollection(db, "todos"),
+		tombstonesC:  utils.GetCollection(db, "todo_tombstones"),
+		syncCounterC: utils.GetCollection(db, "sync_counters"),
+		logger:       logger,
 	}
 }
 
@@ -64,17 +147,229 @@ func (r *repoClient) startSession() (mongo.Session, error) {
 	return r.db.StartSession()
 }
 
-func (r *repoClient) fetchTodos(
-	ctx context.Context, userId primitive.ObjectID, limitFilter *models.ListTodoFilter,
-) ([]models.Todo, error) {
-	filter := bson.M{
-		"user_id": userId,
+// scopeFilter restricts a query to the todos of the active workspace, or to
+// the personal todos of the user when no workspace is active.
+func scopeFilter(scope *models.Scope) bson.M {
+	if scope.IsWorkspace() {
+		return bson.M{
+			"workspace_id": scope.WorkspaceID,
+		}
+	}
+	return bson.M{
+		"user_id": scope.UserID,
+		"workspace_id": bson.M{
+			"$exists": false,
+		},
+	}
+}
+
+// todoQueryFilter matches the todos of the scope that pass the query.
+// Relative deadlines are resolved against now in the given zone.
+func todoQueryFilter(
+	scope *models.Scope, query *models.TodoQuery, location *time.Location, now time.Time,
+) bson.M {
+	filter := scopeFilter(scope)
+	if query == nil {
+		return filter
+	}
+
+	if query.Status != nil {
+		if *query.Status {
+			filter["status"] = true
+		} else {
+			filter["status"] = bson.M{"$ne": true}
+		}
+	}
+	if len(query.Priorities) > 0 {
+		filter["priority"] = bson.M{"$in": query.Priorities}
+	}
+	if len(query.Labels) > 0 {
+		filter["labels"] = bson.M{"$in": query.Labels}
+	}
+
+	switch query.Assignee {
+	case models.AssigneeMe:
+		filter["assignee_id"] = scope.UserID
+	case models.AssigneeOthers:
+		filter["assignee_id"] = bson.M{"$nin": bson.A{nil, scope.UserID}}
+	case models.AssigneeUnassigned:
+		filter["assignee_id"] = nil
+	}
+
+	localNow := now.In(location)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, location)
+	deadlineBetween := func(start, end time.Time) bson.M {
+		return bson.M{
+			"$gte": primitive.NewDateTimeFromTime(start),
+			"$lt":  primitive.NewDateTimeFromTime(end),
+		}
+	}
+	switch query.Deadline {
+	case models.DeadlineToday:
+		filter["deadline"] = deadlineBetween(today, today.AddDate(0, 0, 1))
+	case models.DeadlineThisWeek:
+		// weeks start on monday
+		daysLeft := 7 - (int(today.Weekday())+6)%7
+		filter["deadline"] = deadlineBetween(today, today.AddDate(0, 0, daysLeft))
+	case models.DeadlineUpcoming:
+		filter["deadline"] = deadlineBetween(today.AddDate(0, 0, 1), today.AddDate(0, 0, 8))
+	case models.DeadlineOverdue:
+		filter["deadline"] = bson.M{
+			"$gt": primitive.DateTime(0),
+			"$lt": primitive.NewDateTimeFromTime(now),
+		}
+	case models.DeadlineNone:
+		filter["deadline"] = bson.M{"$in": bson.A{nil, primitive.DateTime(0)}}
+	}
+
+	return filter
+}
+
+// queryTimeFields maps the time fields of a query to the fields of todos
+var queryTimeFields = map[models.QueryField]string{
+	models.QueryFieldDue:     "deadline",
+	models.QueryFieldCreated: "create_time",
+	models.QueryFieldUpdated: "update_time",
+}
+
+// compileQueryNode compiles a parsed query into a filter of todos, times
+// are resolved against now in the given zone.
+func compileQueryNode(
+	scope *models.Scope, node *models.QueryNode, now time.Time, location *time.Location,
+) bson.M {
+	children := make(bson.A, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, compileQueryNode(scope, child, now, location))
+	}
+
+	switch node.Kind {
+	case models.QueryNodeAnd:
+		return bson.M{"$and": children}
+	case models.QueryNodeOr:
+		if len(children) == 0 {
+			return bson.M{"_id": bson.M{"$exists": false}}
+		}
+		return bson.M{"$or": children}
+	case models.QueryNodeNot:
+		return bson.M{"$nor": children}
 	}
 
-	sortOpns := bson.M{
-		"create_time": -1,
+	term := node.Term
+	switch term.Field {
+	case models.QueryFieldStatus:
+		if term.Value == models.QueryValueDone {
+			return bson.M{"status": true}
+		}
+		return bson.M{"status": bson.M{"$ne": true}}
+	case models.QueryFieldPriority:
+		return bson.M{"priority": term.Value}
+	case models.QueryFieldLabel:
+		return bson.M{"labels": term.Value}
+	case models.QueryFieldAssignee:
+		switch term.Value {
+		case models.QueryValueMe:
+			return bson.M{"assignee_id": scope.UserID}
+		case models.QueryValueNone:
+			return bson.M{"assignee_id": nil}
+		}
+		assigneeId, _ := primitive.ObjectIDFromHex(term.Value)
+		return bson.M{"assignee_id": assigneeId}
+	case models.QueryFieldName:
+		return bson.M{"name": primitive.Regex{Pattern: regexp.QuoteMeta(term.Value), Options: "i"}}
+	}
+
+	field := queryTimeFields[term.Field]
+	if term.Time == nil {
+		return bson.M{field: bson.M{"$in": bson.A{nil, primitive.DateTime(0)}}}
+	}
+
+	queryTime := term.Time
+	if term.Operator == models.QueryOpEqual && !queryTime.IsDay {
+		// an instant matches the whole day it falls on
+		instant, _ := queryTime.Range(now, location)
+		queryTime = &models.QueryTime{IsDay: true, Date: instant.In(location)}
+	}
+	start, end := queryTime.Range(now, location)
+
+	condition := bson.M{}
+	switch term.Operator {
+	case models.QueryOpEqual:
+		condition["$gte"] = primitive.NewDateTimeFromTime(start)
+		condition["$lt"] = primitive.NewDateTimeFromTime(end)
+	case models.QueryOpLess:
+		condition["$lt"] = primitive.NewDateTimeFromTime(start)
+	case models.QueryOpLessEqual:
+		if queryTime.IsDay {
+			condition["$lt"] = primitive.NewDateTimeFromTime(end)
+		} else {
+			condition["$lte"] = primitive.NewDateTimeFromTime(start)
+		}
+	case models.QueryOpGreater:
+		if queryTime.IsDay {
+			condition["$gte"] = primitive.NewDateTimeFromTime(end)
+		} else {
+			condition["$gt"] = primitive.NewDateTimeFromTime(start)
+		}
+	case models.QueryOpGreaterEqual:
+		condition["$gte"] = primitive.NewDateTimeFromTime(start)
+	}
+	if _, ok := condition["$gte"]; !ok && term.Field == models.QueryFieldDue {
+		// old todos store a missing deadline as the zero time
+		condition["$gt"] = primitive.DateTime(0)
+	}
+	return bson.M{field: condition}
+}
+
+// notHiddenFilter matches todos that aren't hidden until after now
+func notHiddenFilter(now time.Time) bson.M {
+	return bson.M{
+		"$not": bson.M{
+			"$gt": primitive.NewDateTimeFromTime(now),
+		},
+	}
+}
+
+// listTodosFilter matches the todos of the scope that pass both the saved
+// query and the query string of the filter.
+func listTodosFilter(scope *models.Scope, limitFilter *models.ListTodoFilter) bson.M {
+	now := time.Now()
+	location := limitFilter.Location
+	if location == nil {
+		location = time.UTC
 	}
-	opns := options.Find().SetSort(sortOpns).SetLimit(int64(limitFilter.Limit)).SetSkip(int64(limitFilter.Page))
+
+	filter := todoQueryFilter(scope, limitFilter.Query, location, now)
+	if !limitFilter.IncludeSnoozed {
+		filter["hide_until"] = notHiddenFilter(now)
+	}
+	if limitFilter.Expr != nil {
+		filter["$and"] = bson.A{compileQueryNode(scope, limitFilter.Expr, now, location)}
+	}
+	return filter
+}
+
+// todoQuerySort sorts by the field of the query, newest first by default.
+func todoQuerySort(query *models.TodoQuery) bson.D {
+	if query == nil || query.SortBy == "" {
+		return bson.D{{Key: "create_time", Value: -1}, {Key: "_id", Value: -1}}
+	}
+
+	order := 1
+	switch {
+	case query.SortOrder == models.SortDescending:
+		order = -1
+	case query.SortOrder == models.SortDefault &&
+		(query.SortBy == models.SortByCreateTime || query.SortBy == models.SortByUpdateTime):
+		order = -1
+	}
+	return bson.D{{Key: string(query.SortBy), Value: order}, {Key: "_id", Value: order}}
+}
+
+func (r *repoClient) fetchTodos(
+	ctx context.Context, scope *models.Scope, limitFilter *models.ListTodoFilter,
+) ([]models.Todo, error) {
+	filter := listTodosFilter(scope, limitFilter)
+	opns := findPageOptions(limitFilter).SetSort(todoQuerySort(limitFilter.Query))
 
 	cursor, err := r.todoC.Find(ctx, filter, opns)
 	if err != nil {
@@ -89,11 +384,9 @@ func (r *repoClient) fetchTodos(
 }
 
 func (r *repoClient) countTodos(
-	ctx context.Context, userId primitive.ObjectID,
+	ctx context.Context, scope *models.Scope, limitFilter *models.ListTodoFilter,
 ) (int64, error) {
-	filter := bson.M{
-		"user_id": userId,
-	}
+	filter := listTodosFilter(scope, limitFilter)
 
 	count, err := r.todoC.CountDocuments(ctx, filter)
 	if err != nil {
@@ -104,12 +397,10 @@ func (r *repoClient) countTodos(
 }
 
 func (r *repoClient) fetchTodo(
-	ctx context.Context, todoId, userId primitive.ObjectID,
+	ctx context.Context, todoId primitive.ObjectID, scope *models.Scope,
 ) (*models.Todo, error) {
-	filter := bson.M{
-		"_id":     todoId,
-		"user_id": userId,
-	}
+	filter := scopeFilter(scope)
+	filter["_id"] = todoId
 
 	var todo models.Todo
 	err := r.todoC.FindOne(ctx, filter).Decode(&todo)
@@ -135,14 +426,16 @@ func (r *repoClient) fetchTodo(
 }
 
 func (r *repoClient) updateTodo(
-	ctx context.Context, taskId primitive.ObjectID, update bson.M,
+	ctx context.Context, taskId primitive.ObjectID, scope *models.Scope, update, precondition bson.M,
 ) (*models.Todo, error) {
-	filter := bson.M{
-		"_id": taskId,
+	filter := scopeFilter(scope)
+	for key, value := range precondition {
+		filter[key] = value
 	}
+	filter["_id"] = taskId
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var todo models.Todo
-	err := r.todoC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&todo)
+	err := r.todoC.FindOneAndUpdate(ctx, filter, bson.M{"$set": update}, opts).Decode(&todo)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			customErr := &utils.ReqInvalidArgumentError{
@@ -164,14 +457,107 @@ func (r *repoClient) updateTodo(
 	return &todo, nil
 }
 
+// assignedFilter matches the todos assigned to the user in the active
+// workspace. Without one it matches their personal todos and the todos of
+// the given workspaces, which should be the ones they are still a member of.
+func assignedFilter(scope *models.Scope, workspaceIds []primitive.ObjectID) bson.M {
+	filter := bson.M{
+		"assignee_id": scope.UserID,
+	}
+	if scope.IsWorkspace() {
+		filter["workspace_id"] = scope.WorkspaceID
+		return filter
+	}
+	filter["$or"] = bson.A{
+		scopeFilter(scope),
+		bson.M{
+			"workspace_id": bson.M{
+				"$in": workspaceIds,
+			},
+		},
+	}
+	return filter
+}
+
+func findPageOptions(limitFilter *models.ListTodoFilter) *options.FindOptions {
+	return options.Find().
+		SetLimit(int64(limitFilter.Limit)).
+		SetSkip(int64((limitFilter.Page - 1) * limitFilter.Limit))
+}
+
+func (r *repoClient) fetchAssignedTodos(
+	ctx context.Context, scope *models.Scope, workspaceIds []primitive.ObjectID,
+	limitFilter *models.ListTodoFilter,
+) ([]models.Todo, error) {
+	opns := findPageOptions(limitFilter).SetSort(bson.M{"create_time": -1})
+
+	cursor, err := r.todoC.Find(ctx, assignedFilter(scope, workspaceIds), opns)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]models.Todo, 0)
+	if err = cursor.All(ctx, &todos); err != nil {
+		return todos, err
+	}
+
+	return todos, nil
+}
+
+func (r *repoClient) countAssignedTodos(
+	ctx context.Context, scope *models.Scope, workspaceIds []primitive.ObjectID,
+) (int64, error) {
+	return r.todoC.CountDocuments(ctx, assignedFilter(scope, workspaceIds))
+}
+
+func (r *repoClient) unassignTodos(
+	ctx context.Context, workspaceId, userId primitive.ObjectID, syncSeq int64,
+) error {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+		"assignee_id":  userId,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"assignee_id": nil,
+			"update_time": primitive.NewDateTimeFromTime(time.Now()),
+			"sync_seq":    syncSeq,
+		},
+	}
+	if _, err := r.todoC.UpdateMany(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to unassign todos",
+			},
+		}
+	}
+	return nil
+}
+
+// countUserTodos counts the todos the user created, in any workspace
+func (r *repoClient) countUserTodos(
+	ctx context.Context, userId primitive.ObjectID,
+) (int64, error) {
+	filter := bson.M{
+		"user_id": userId,
+	}
+	return r.todoC.CountDocuments(ctx, filter)
+}
+
 func (r *repoClient) fetchTodosWithNearbyDeadline(
 	ctx context.Context,
 ) ([]models.Todo, error) {
 	var todos []models.Todo
+	now := time.Now()
 	filter := bson.M{
 		"deadline": bson.M{
-			"$gte": primitive.NewDateTimeFromTime(time.Now().Add(-6 * time.Hour)),
+			"$gte": primitive.NewDateTimeFromTime(now),
+			"$lte": primitive.NewDateTimeFromTime(now.Add(6 * time.Hour)),
 		},
+		"status": bson.M{
+			"$ne": true,
+		},
+		"hide_until": notHiddenFilter(now),
 	}
 
 	cursor, err := r.todoC.Find(ctx, filter)
@@ -186,3 +572,872 @@ func (r *repoClient) fetchTodosWithNearbyDeadline(
 
 	return todos, nil
 }
+
+// releaseExpiredSnooze marks one todo whose snooze ended before now as
+// released and returns it, nil when there is none left.
+func (r *repoClient) releaseExpiredSnooze(
+	ctx context.Context, now time.Time,
+) (*models.Todo, error) {
+	filter := bson.M{
+		"snoozed": true,
+		"hide_until": bson.M{
+			"$lte": primitive.NewDateTimeFromTime(now),
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"snoozed": false,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var todo models.Todo
+	err := r.todoC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&todo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to release snoozed todo",
+			},
+		}
+	}
+
+	return &todo, nil
+}
+
+// restoreSnoozes marks the released todos as snoozed again, unless their
+// snooze was changed meanwhile.
+func (r *repoClient) restoreSnoozes(ctx context.Context, todoIds []primitive.ObjectID, now time.Time) error {
+	filter := bson.M{
+		"_id": bson.M{
+			"$in": todoIds,
+		},
+		"snoozed": bson.M{
+			"$ne": true,
+		},
+		"hide_until": bson.M{
+			"$lte": primitive.NewDateTimeFromTime(now),
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"snoozed": true,
+		},
+	}
+
+	if _, err := r.todoC.UpdateMany(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to restore snoozed todos",
+			},
+		}
+	}
+	return nil
+}
+
+// fetchAgendaTodos returns the todos due in the range together with the
+// recurring todos that may have an occurrence in it.
+func (r *repoClient) fetchAgendaTodos(
+	ctx context.Context, scope *models.Scope, start, end time.Time,
+) ([]models.Todo, error) {
+	filter := scopeFilter(scope)
+	filter["$or"] = bson.A{
+		bson.M{
+			"deadline": bson.M{
+				"$gte": primitive.NewDateTimeFromTime(start),
+				"$lt":  primitive.NewDateTimeFromTime(end),
+			},
+		},
+		bson.M{
+			"recurrence": bson.M{
+				"$exists": true,
+			},
+			"deadline": bson.M{
+				"$lt": primitive.NewDateTimeFromTime(end),
+			},
+			"$or": bson.A{
+				bson.M{"recurrence.until": bson.M{"$exists": false}},
+				bson.M{"recurrence.until": bson.M{"$gte": primitive.NewDateTimeFromTime(start)}},
+			},
+		},
+	}
+	opns := options.Find().SetSort(bson.D{{Key: "deadline", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]models.Todo, 0)
+	if err = cursor.All(ctx, &todos); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (r *repoClient) fetchOverdueTodos(
+	ctx context.Context, scope *models.Scope, now time.Time, limit int64,
+) ([]models.Todo, error) {
+	filter := scopeFilter(scope)
+	filter["status"] = bson.M{
+		"$ne": true,
+	}
+	filter["deadline"] = bson.M{
+		"$gt": primitive.NewDateTimeFromTime(time.Unix(0, 0)),
+		"$lt": primitive.NewDateTimeFromTime(now),
+	}
+	// a recurring todo always has an occurrence ahead until its recurrence
+	// ends, so only the ended ones can be overdue
+	filter["$or"] = bson.A{
+		bson.M{
+			"recurrence": bson.M{
+				"$exists": false,
+			},
+		},
+		bson.M{
+			"recurrence.until": bson.M{
+				"$lt": primitive.NewDateTimeFromTime(now),
+			},
+		},
+	}
+	opns := options.Find().
+		SetSort(bson.D{{Key: "deadline", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]models.Todo, 0)
+	if err = cursor.All(ctx, &todos); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// watchedTodosFilter matches the todos of the scope that pass the watch filter.
+func watchedTodosFilter(scope *models.Scope, watchFilter *models.WatchTodoFilter) bson.M {
+	filter := scopeFilter(scope)
+	if watchFilter.Status != nil {
+		if *watchFilter.Status {
+			filter["status"] = true
+		} else {
+			// open todos don't store the status at all
+			filter["status"] = bson.M{
+				"$ne": true,
+			}
+		}
+	}
+	if len(watchFilter.Priorities) > 0 {
+		filter["priority"] = bson.M{
+			"$in": watchFilter.Priorities,
+		}
+	}
+	return filter
+}
+
+// prefixFilter rewrites a filter on todos into a filter on the given
+// sub-document of a change event, e.g. "fullDocument".
+func prefixFilter(filter bson.M, prefix string) bson.M {
+	prefixed := make(bson.M, len(filter))
+	for key, value := range filter {
+		prefixed[prefix+"."+key] = value
+	}
+	return prefixed
+}
+
+func (r *repoClient) findWatchedTodos(
+	ctx context.Context, scope *models.Scope, watchFilter *models.WatchTodoFilter,
+) (*mongo.Cursor, error) {
+	opns := options.Find().SetSort(bson.M{"create_time": -1})
+
+	return r.todoC.Find(ctx, watchedTodosFilter(scope, watchFilter), opns)
+}
+
+// watchTodos opens a change stream on the todos of the scope. Updates are
+// matched on the scope alone, as a todo leaving the filter has to be reported
+// as well. Deletions only carry the document key, so they are matched against
+// the pre-image of the todo, which needs change stream pre-images enabled on
+// the collection.
+func (r *repoClient) watchTodos(
+	ctx context.Context, scope *models.Scope, watchFilter *models.WatchTodoFilter, resumeToken bson.Raw,
+) (*mongo.ChangeStream, error) {
+	filter := watchedTodosFilter(scope, watchFilter)
+
+	pipeline := mongo.Pipeline{
+		{
+			{
+				Key: "$match", Value: bson.M{
+					"$or": bson.A{
+						bson.M{
+							"operationType": "insert",
+							"$and":          bson.A{prefixFilter(filter, "fullDocument")},
+						},
+						bson.M{
+							"operationType": bson.M{
+								"$in": bson.A{"update", "replace"},
+							},
+							"$and": bson.A{prefixFilter(scopeFilter(scope), "fullDocument")},
+						},
+						bson.M{
+							"operationType": "delete",
+							"$and":          bson.A{prefixFilter(scopeFilter(scope), "fullDocumentBeforeChange")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	opns := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if resumeToken != nil {
+		opns.SetResumeAfter(resumeToken)
+	}
+
+	return r.todoC.Watch(ctx, pipeline, opns)
+}
+
+func (r *repoClient) deleteTodo(
+	ctx context.Context, todoId primitive.ObjectID, scope *models.Scope, precondition bson.M,
+) (*models.Todo, error) {
+	filter := scopeFilter(scope)
+	for key, value := range precondition {
+		filter[key] = value
+	}
+	filter["_id"] = todoId
+
+	var todo models.Todo
+	err := r.todoC.FindOneAndDelete(ctx, filter).Decode(&todo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "todo not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete todo",
+			},
+		}
+	}
+
+	return &todo, nil
+}
+
+// deleteScopeTodos deletes the todos of the scope along with its sync state,
+// there is no client left to sync the deletions to.
+func (r *repoClient) deleteScopeTodos(ctx context.Context, scope *models.Scope) error {
+	if _, err := r.todoC.DeleteMany(ctx, scopeFilter(scope)); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete todos",
+			},
+		}
+	}
+	if _, err := r.tombstonesC.DeleteMany(ctx, scopeFilter(scope)); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete todo tombstones",
+			},
+		}
+	}
+	if _, err := r.syncCounterC.DeleteOne(ctx, bson.M{"_id": scope.Key()}); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete sync counter",
+			},
+		}
+	}
+	return nil
+}
+
+func (r *repoClient) nextSyncSeq(ctx context.Context, scope *models.Scope) (int64, error) {
+	filter := bson.M{
+		"_id": scope.Key(),
+	}
+	update := bson.M{
+		"$inc": bson.M{
+			"seq": 1,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter models.SyncCounter
+	if err := r.syncCounterC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&counter); err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+func (r *repoClient) fetchSyncCounter(ctx context.Context, scope *models.Scope) (*models.SyncCounter, error) {
+	filter := bson.M{
+		"_id": scope.Key(),
+	}
+
+	counter := models.SyncCounter{ID: scope.Key()}
+	err := r.syncCounterC.FindOne(ctx, filter).Decode(&counter)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	return &counter, nil
+}
+
+// backfillSyncSeq gives todos written before syncing existed a sequence so
+// that they can be paged through like all others.
+func (r *repoClient) backfillSyncSeq(ctx context.Context, scope *models.Scope) error {
+	filter := scopeFilter(scope)
+	filter["sync_seq"] = bson.M{
+		"$exists": false,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"sync_seq": int64(0),
+		},
+	}
+
+	_, err := r.todoC.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (r *repoClient) fetchTodosSince(
+	ctx context.Context, scope *models.Scope, since models.SyncPosition, upTo int64, limit int64,
+) ([]models.Todo, error) {
+	filter := scopeFilter(scope)
+	filter["$or"] = bson.A{
+		bson.M{
+			"sync_seq": bson.M{
+				"$gt":  since.Seq,
+				"$lte": upTo,
+			},
+		},
+		bson.M{
+			"sync_seq": since.Seq,
+			"_id": bson.M{
+				"$gt": since.AfterID,
+			},
+		},
+	}
+	opns := options.Find().
+		SetSort(bson.D{{Key: "sync_seq", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]models.Todo, 0)
+	if err = cursor.All(ctx, &todos); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (r *repoClient) insertTombstone(ctx context.Context, tombstone *models.TodoTombstone) error {
+	_, err := r.tombstonesC.ReplaceOne(
+		ctx,
+		bson.M{"_id": tombstone.ID},
+		tombstone,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *repoClient) fetchTombstone(
+	ctx context.Context, todoId primitive.ObjectID, scope *models.Scope,
+) (*models.TodoTombstone, error) {
+	filter := bson.M{
+		"_id":      todoId,
+		"sync_key": scope.Key(),
+	}
+
+	var tombstone models.TodoTombstone
+	err := r.tombstonesC.FindOne(ctx, filter).Decode(&tombstone)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "todo not found",
+				},
+			}
+		}
+		return nil, err
+	}
+
+	return &tombstone, nil
+}
+
+func (r *repoClient) fetchTombstonesSince(
+	ctx context.Context, scope *models.Scope, since, upTo int64,
+) ([]models.TodoTombstone, error) {
+	filter := bson.M{
+		"sync_key": scope.Key(),
+		"sync_seq": bson.M{
+			"$gt":  since,
+			"$lte": upTo,
+		},
+	}
+	opns := options.Find().SetSort(bson.M{"sync_seq": 1})
+
+	cursor, err := r.tombstonesC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	tombstones := make([]models.TodoTombstone, 0)
+	if err = cursor.All(ctx, &tombstones); err != nil {
+		return nil, err
+	}
+
+	return tombstones, nil
+}
+
+// compactTombstones drops the tombstones of todos deleted before the given
+// time. The highest dropped sequence is remembered per scope so that clients
+// with an older sync token know they have to resync from scratch.
+func (r *repoClient) compactTombstones(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	match := bson.M{
+		"delete_time": bson.M{
+			"$lt": primitive.NewDateTimeFromTime(deletedBefore),
+		},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$sync_key", "seq": bson.M{"$max": "$sync_seq"}}}},
+	}
+
+	cursor, err := r.tombstonesC.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	var compacted []struct {
+		SyncKey string `bson:"_id"`
+		Seq     int64  `bson:"seq"`
+	}
+	if err = cursor.All(ctx, &compacted); err != nil {
+		return 0, err
+	}
+
+	for _, scope := range compacted {
+		_, err = r.syncCounterC.UpdateOne(
+			ctx,
+			bson.M{"_id": scope.SyncKey},
+			bson.M{"$max": bson.M{"compacted_seq": scope.Seq}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := r.tombstonesC.DeleteMany(ctx, match)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+type dayCount struct {
+	Date  string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+type priorityCount struct {
+	Priority  string `bson:"_id"`
+	Created   int64  `bson:"created"`
+	Completed int64  `bson:"completed"`
+}
+
+// todoStatsFacets is the raw outcome of the stats aggregation, days are
+// formatted as YYYY-MM-DD in the zone of the filter.
+type todoStatsFacets struct {
+	Created        []dayCount      `bson:"created"`
+	Completed      []dayCount      `bson:"completed"`
+	Overdue        []dayCount      `bson:"overdue"`
+	CompletionDays []dayCount      `bson:"completion_days"`
+	Priorities     []priorityCount `bson:"priorities"`
+	Durations      []struct {
+		AverageMillis float64 `bson:"average_millis"`
+	} `bson:"durations"`
+}
+
+// aggregateStats computes the numbers behind GetStats in a single pass over
+// the todos of the scope. Completion streaks look at the whole history, every
+// other number only at the range of the filter.
+func (r *repoClient) aggregateStats(
+	ctx context.Context, scope *models.Scope, statsFilter *models.StatsFilter,
+) (*todoStatsFacets, error) {
+	start := primitive.NewDateTimeFromTime(statsFilter.Start)
+	end := primitive.NewDateTimeFromTime(statsFilter.End)
+	inRange := bson.M{"$gte": start, "$lt": end}
+	overdueEnd := end
+	if now := primitive.NewDateTimeFromTime(time.Now()); now < overdueEnd {
+		overdueEnd = now
+	}
+	dayOf := func(field string) bson.M {
+		return bson.M{
+			"$dateToString": bson.M{
+				"format":   "%Y-%m-%d",
+				"date":     field,
+				"timezone": statsFilter.Location.String(),
+			},
+		}
+	}
+	countByDay := func(field string) bson.M {
+		return bson.M{"$group": bson.M{"_id": dayOf(field), "count": bson.M{"$sum": 1}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: scopeFilter(scope)}},
+		// todos completed before complete_time was introduced fall back to
+		// their last update
+		{{Key: "$addFields", Value: bson.M{
+			"done_time": bson.M{
+				"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$status", true}},
+					bson.M{"$ifNull": bson.A{"$complete_time", "$update_time"}},
+					nil,
+				},
+			},
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"created": bson.A{
+				bson.M{"$match": bson.M{"create_time": inRange}},
+				countByDay("$create_time"),
+			},
+			"completed": bson.A{
+				bson.M{"$match": bson.M{"done_time": inRange}},
+				countByDay("$done_time"),
+			},
+			"overdue": bson.A{
+				bson.M{"$match": bson.M{"deadline": bson.M{"$gte": start, "$lt": overdueEnd}}},
+				bson.M{"$match": bson.M{"$expr": bson.M{
+					"$or": bson.A{
+						bson.M{"$eq": bson.A{"$done_time", nil}},
+						bson.M{"$gt": bson.A{"$done_time", "$deadline"}},
+					},
+				}}},
+				countByDay("$deadline"),
+			},
+			"completion_days": bson.A{
+				bson.M{"$match": bson.M{"done_time": bson.M{"$ne": nil}}},
+				countByDay("$done_time"),
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"priorities": bson.A{
+				bson.M{"$match": bson.M{"create_time": inRange}},
+				bson.M{"$group": bson.M{
+					"_id":     "$priority",
+					"created": bson.M{"$sum": 1},
+					"completed": bson.M{"$sum": bson.M{
+						"$cond": bson.A{bson.M{"$ne": bson.A{"$done_time", nil}}, 1, 0},
+					}},
+				}},
+			},
+			"durations": bson.A{
+				bson.M{"$match": bson.M{"done_time": inRange}},
+				bson.M{"$group": bson.M{
+					"_id": nil,
+					"average_millis": bson.M{
+						"$avg": bson.M{"$subtract": bson.A{"$done_time", "$create_time"}},
+					},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.todoC.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to compute stats",
+			},
+		}
+	}
+	defer cursor.Close(ctx)
+
+	var facets todoStatsFacets
+	if cursor.Next(ctx) {
+		err = cursor.Decode(&facets)
+	} else {
+		err = cursor.Err()
+	}
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to compute stats",
+			},
+		}
+	}
+
+	return &facets, nil
+}
+
+// fetchMaxRank returns the rank of the last todo of the scope, or an empty
+// rank when no todo is ranked yet.
+func (r *repoClient) fetchMaxRank(ctx context.Context, scope *models.Scope) (string, error) {
+	filter := scopeFilter(scope)
+	filter["rank"] = bson.M{
+		"$exists": true,
+	}
+	opns := options.FindOne().
+		SetSort(bson.M{"rank": -1}).
+		SetProjection(bson.M{"rank": 1})
+
+	var todo models.Todo
+	err := r.todoC.FindOne(ctx, filter, opns).Decode(&todo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return todo.Rank, nil
+}
+
+// backfillRanks ranks the todos created before ranks were introduced after
+// the ranked ones, oldest first. It only runs once per scope, as every todo
+// created since gets a rank.
+func (r *repoClient) backfillRanks(ctx context.Context, scope *models.Scope) error {
+	counter, err := r.fetchSyncCounter(ctx, scope)
+	if err != nil {
+		return err
+	}
+	if counter.RanksBackfilled {
+		return nil
+	}
+
+	filter := scopeFilter(scope)
+	filter["rank"] = bson.M{
+		"$exists": false,
+	}
+	opns := options.Find().
+		SetSort(bson.D{{Key: "create_time", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return err
+	}
+	var unranked []models.Todo
+	if err = cursor.All(ctx, &unranked); err != nil {
+		return err
+	}
+
+	if len(unranked) > 0 {
+		rank, err := r.fetchMaxRank(ctx, scope)
+		if err != nil {
+			return err
+		}
+		writes := make([]mongo.WriteModel, 0, len(unranked))
+		for _, todo := range unranked {
+			rank, err = utils.RankBetween(rank, "")
+			if err != nil {
+				return err
+			}
+			writes = append(
+				writes, mongo.NewUpdateOneModel().
+					SetFilter(bson.M{"_id": todo.ID, "rank": bson.M{"$exists": false}}).
+					SetUpdate(bson.M{"$set": bson.M{"rank": rank}}),
+			)
+		}
+		if _, err = r.todoC.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.syncCounterC.UpdateOne(
+		ctx,
+		bson.M{"_id": scope.Key()},
+		bson.M{"$set": bson.M{"ranks_backfilled": true}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// breakRankTie gives the todos sharing the rank distinct ranks in the order
+// of their ids, which is the order boards show them in. The first one keeps
+// the rank, the others are spread up to the next higher rank.
+func (r *repoClient) breakRankTie(ctx context.Context, scope *models.Scope, rank string) error {
+	filter := scopeFilter(scope)
+	filter["rank"] = rank
+	opns := options.Find().
+		SetSort(bson.M{"_id": 1}).
+		SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return err
+	}
+	var tied []models.Todo
+	if err = cursor.All(ctx, &tied); err != nil {
+		return err
+	}
+	if len(tied) < 2 {
+		return nil
+	}
+
+	filter = scopeFilter(scope)
+	filter["rank"] = bson.M{
+		"$gt": rank,
+	}
+	var next models.Todo
+	err = r.todoC.FindOne(
+		ctx, filter, options.FindOne().SetSort(bson.M{"rank": 1}).SetProjection(bson.M{"rank": 1}),
+	).Decode(&next)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(tied)-1)
+	spreadRank := rank
+	for _, todo := range tied[1:] {
+		spreadRank, err = utils.RankBetween(spreadRank, next.Rank)
+		if err != nil {
+			return err
+		}
+		writes = append(
+			writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": todo.ID, "rank": rank}).
+				SetUpdate(bson.M{"$set": bson.M{"rank": spreadRank}}),
+		)
+	}
+
+	_, err = r.todoC.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+type boardColumnCount struct {
+	Key   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// boardColumnKey is the aggregation expression of the column key of a todo,
+// labels have to be unwound before.
+func boardColumnKey(groupBy models.BoardGroupBy) interface{} {
+	switch groupBy {
+	case models.BoardGroupByPriority:
+		return "$priority"
+	case models.BoardGroupByAssignee:
+		return bson.M{"$ifNull": bson.A{bson.M{"$toString": "$assignee_id"}, ""}}
+	case models.BoardGroupByLabel:
+		return bson.M{"$ifNull": bson.A{"$labels", ""}}
+	default:
+		return bson.M{
+			"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$status", true}},
+				models.BoardColumnDone,
+				models.BoardColumnOpen,
+			},
+		}
+	}
+}
+
+// boardColumnFilter matches the todos of the scope shown in the column.
+func boardColumnFilter(
+	scope *models.Scope, groupBy models.BoardGroupBy, column string, limitFilter *models.ListTodoFilter,
+) (bson.M, error) {
+	filter := bson.M{}
+	switch groupBy {
+	case models.BoardGroupByPriority:
+		filter["priority"] = column
+	case models.BoardGroupByAssignee:
+		if column == "" {
+			filter["assignee_id"] = nil
+			break
+		}
+		assigneeId, err := primitive.ObjectIDFromHex(column)
+		if err != nil {
+			return nil, err
+		}
+		filter["assignee_id"] = assigneeId
+	case models.BoardGroupByLabel:
+		if column == "" {
+			filter["labels.0"] = bson.M{"$exists": false}
+			break
+		}
+		filter["labels"] = column
+	default:
+		if column == models.BoardColumnDone {
+			filter["status"] = true
+		} else {
+			filter["status"] = bson.M{"$ne": true}
+		}
+	}
+	// the list filter may match on the grouped field itself
+	return bson.M{
+		"$and": bson.A{listTodosFilter(scope, limitFilter), filter},
+	}, nil
+}
+
+func (r *repoClient) countBoardColumns(
+	ctx context.Context, scope *models.Scope, groupBy models.BoardGroupBy, limitFilter *models.ListTodoFilter,
+) ([]boardColumnCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: listTodosFilter(scope, limitFilter)}},
+	}
+	if groupBy == models.BoardGroupByLabel {
+		pipeline = append(
+			pipeline, bson.D{{Key: "$unwind", Value: bson.M{
+				"path":                       "$labels",
+				"preserveNullAndEmptyArrays": true,
+			}}},
+		)
+	}
+	pipeline = append(
+		pipeline,
+		bson.D{{Key: "$group", Value: bson.M{"_id": boardColumnKey(groupBy), "count": bson.M{"$sum": 1}}}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	)
+
+	cursor, err := r.todoC.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]boardColumnCount, 0)
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *repoClient) fetchBoardCards(
+	ctx context.Context, scope *models.Scope, groupBy models.BoardGroupBy, column string,
+	limitFilter *models.ListTodoFilter,
+) ([]models.Todo, error) {
+	filter, err := boardColumnFilter(scope, groupBy, column, limitFilter)
+	if err != nil {
+		return nil, err
+	}
+	opns := findPageOptions(limitFilter).
+		SetSort(bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.todoC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]models.Todo, 0)
+	if err = cursor.All(ctx, &todos); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
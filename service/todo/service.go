@@ -2,6 +2,9 @@ package todo
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -10,32 +13,61 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"golang.org/x/sync/errgroup"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 	"todo-grpc/models"
+	kafkaQueueProvider "todo-grpc/providers/kafka"
 	"todo-grpc/service"
-	"todo-grpc/service/user"
 	"todo-grpc/utils"
 )
 
+// statsCacheTTL is how long stats are served from the cache, the dashboard
+// polls them far more often than they meaningfully change
+const statsCacheTTL = time.Minute
+
 type serviceClient struct {
-	todoRepo    todosRepo
-	logger      *utils.Logger
-	userService service.UserService
+	todoRepo         todosRepo
+	logger           *utils.Logger
+	config           utils.EnvConfig
+	userService      service.UserService
+	workspaceService service.WorkspaceService
+	kafkaProvider    kafkaQueueProvider.Provider
+	statsCache       *statsCache
 }
 
 func NewTodoService(
 	db *mongo.Client,
 	logger *utils.Logger,
 	config utils.EnvConfig,
+	userService service.UserService,
+	workspaceService service.WorkspaceService,
+	kafkaProvider kafkaQueueProvider.Provider,
 ) service.TodoService {
 	return &serviceClient{
-		todoRepo:    newRepoClient(db, logger),
-		logger:      logger,
-		userService: user.NewUserService(db, logger, config),
+		todoRepo:         newRepoClient(db, logger),
+		logger:           logger,
+		config:           config,
+		userService:      userService,
+		workspaceService: workspaceService,
+		kafkaProvider:    kafkaProvider,
+		statsCache:       newStatsCache(statsCacheTTL),
 	}
 }
 
 func (s *serviceClient) CreateTodo(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	if err := checkRecurrence(todo.Recurrence, todo.DeadLine); err != nil {
+		return nil, err
+	}
+	err := s.checkAssignee(ctx, todo.UserID, todo.AssigneeID, todo.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userService.FetchUser(ctx, todo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
 	session, err := s.todoRepo.startSession()
 	if err != nil {
 		return nil, err
@@ -47,9 +79,31 @@ func (s *serviceClient) CreateTodo(ctx context.Context, todo *models.Todo) (*mod
 	rc := readconcern.Snapshot()
 	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
 	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		if err := s.checkTodoQuota(ctx, user); err != nil {
+			return nil, err
+		}
 		todo.ID = primitive.NewObjectID()
 		todo.CreateTime = primitive.NewDateTimeFromTime(time.Now())
-		todo.DeadLine = primitive.NewDateTimeFromTime(time.Now().Add(-1 * time.Hour))
+		if todo.Status {
+			todo.CompleteTime = todo.CreateTime
+		}
+		todo.Snoozed = todo.HideUntil.Time().After(time.Now())
+		scope := &models.Scope{UserID: todo.UserID, WorkspaceID: todo.WorkspaceID}
+		todo.SyncSeq, err = s.todoRepo.nextSyncSeq(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		// new todos go to the bottom of boards. Taking the sync sequence
+		// makes concurrent creates of the scope conflict, so the retried one
+		// reads the rank of the other.
+		lastRank, err := s.todoRepo.fetchMaxRank(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		todo.Rank, err = utils.RankBetween(lastRank, "")
+		if err != nil {
+			return nil, err
+		}
 		todoId, err := s.todoRepo.insertTodo(ctx, todo)
 		if err != nil {
 			return nil, err
@@ -65,25 +119,66 @@ func (s *serviceClient) CreateTodo(ctx context.Context, todo *models.Todo) (*mod
 
 	_, err = session.WithTransaction(ctx, callback, txnOpts)
 	if err != nil {
-		return nil, err
+		if _, ok := err.(*utils.ResourceExhaustedError); ok {
+			return nil, err
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create todo",
+			},
+		}
+	}
+
+	if !todo.AssigneeID.IsZero() && todo.AssigneeID != todo.UserID {
+		s.publishTodoAssigned(todo, todo.UserID)
 	}
+	s.publishAnalyticsEvent(models.NewTodoAnalyticsEvent(models.AnalyticsEventTodoCreated, todo, todo.UserID))
 
 	return todo, nil
 }
 
+// checkTodoQuota fails once the user has as many todos as their plan allows.
+// It runs in the transaction creating the todo, concurrent creates of the
+// user conflict on adding the todo to the user and are retried, so they
+// can't overshoot the quota.
+func (s *serviceClient) checkTodoQuota(ctx context.Context, user *models.User) error {
+	plan := user.Plan(time.Now())
+	maxTodos := s.config.GetPlanQuota(plan).MaxTodos
+	if maxTodos < 0 {
+		return nil
+	}
+
+	count, err := s.todoRepo.countUserTodos(ctx, user.ID)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to count todos",
+			},
+		}
+	}
+	if count >= maxTodos {
+		return &utils.ResourceExhaustedError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: fmt.Sprintf("user %s has %d todos", user.ID.Hex(), count),
+				Msg:     fmt.Sprintf("the %s plan allows at most %d todos", plan, maxTodos),
+			},
+		}
+	}
+	return nil
+}
+
 func (s *serviceClient) ListTodos(
-	ctx context.Context, userId string, filter *models.ListTodoFilter,
+	ctx context.Context, scope *models.Scope, filter *models.ListTodoFilter,
 ) (*models.ListTodoRes, error) {
 	var todoRes models.ListTodoRes
-	userID, err := primitive.ObjectIDFromHex(userId)
-	if err != nil {
-		return nil, err
-	}
+	var err error
 	erg, _ := errgroup.WithContext(ctx)
 	erg.Go(
 		func() error {
 			var todoErr error
-			todoRes.Todos, todoErr = s.todoRepo.fetchTodos(ctx, userID, filter)
+			todoRes.Todos, todoErr = s.todoRepo.fetchTodos(ctx, scope, filter)
 			return todoErr
 		},
 	)
@@ -91,7 +186,7 @@ func (s *serviceClient) ListTodos(
 	erg.Go(
 		func() error {
 			var countErr error
-			todoRes.Count, countErr = s.todoRepo.countTodos(ctx, userID)
+			todoRes.Count, countErr = s.todoRepo.countTodos(ctx, scope, filter)
 			return countErr
 		},
 	)
@@ -102,7 +197,7 @@ func (s *serviceClient) ListTodos(
 	return &todoRes, err
 }
 
-func (s *serviceClient) FetchTodo(ctx context.Context, todoId, userId string) (*models.Todo, error) {
+func (s *serviceClient) FetchTodo(ctx context.Context, todoId string, scope *models.Scope) (*models.Todo, error) {
 	todoID, err := primitive.ObjectIDFromHex(todoId)
 	if err != nil {
 		customErr := &utils.ReqInvalidArgumentError{
@@ -113,18 +208,8 @@ func (s *serviceClient) FetchTodo(ctx context.Context, todoId, userId string) (*
 		}
 		return nil, customErr
 	}
-	userID, err := primitive.ObjectIDFromHex(userId)
-	if err != nil {
-		customErr := &utils.ReqInvalidArgumentError{
-			GeneralError: &utils.GeneralError{
-				DevInfo: err.Error(),
-				Msg:     "invalid user id",
-			},
-		}
-		return nil, customErr
-	}
 
-	todo, err := s.todoRepo.fetchTodo(ctx, todoID, userID)
+	todo, err := s.todoRepo.fetchTodo(ctx, todoID, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +217,29 @@ func (s *serviceClient) FetchTodo(ctx context.Context, todoId, userId string) (*
 	return todo, nil
 }
 
-func (s *serviceClient) UpdateTodo(ctx context.Context, todo *models.Todo, fieldMasks []string) (*models.Todo, error) {
+func (s *serviceClient) UpdateTodo(
+	ctx context.Context, scope *models.Scope, todo *models.Todo, fieldMasks []string,
+) (*models.Todo, error) {
+	return s.updateTodo(ctx, scope, todo, fieldMasks, nil)
+}
+
+// updateTodo applies the masked fields of todo. The update is only made when
+// the stored todo also matches the precondition.
+func (s *serviceClient) updateTodo(
+	ctx context.Context, scope *models.Scope, todo *models.Todo, fieldMasks []string, precondition bson.M,
+) (*models.Todo, error) {
+	var existingTodo *models.Todo
+	if slices.Contains(fieldMasks, "status") ||
+		slices.Contains(fieldMasks, "assignee_id") ||
+		slices.Contains(fieldMasks, "recurrence") {
+		var err error
+		existingTodo, err = s.todoRepo.fetchTodo(ctx, todo.ID, scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
 	update := bson.M{}
 	if slices.Contains(fieldMasks, "name") {
 		if todo.Name == "" {
@@ -150,29 +257,1175 @@ func (s *serviceClient) UpdateTodo(ctx context.Context, todo *models.Todo, field
 	}
 	if slices.Contains(fieldMasks, "status") {
 		update["status"] = todo.Status
+		if !todo.Status {
+			update["complete_time"] = nil
+		} else if !existingTodo.Status {
+			update["complete_time"] = primitive.NewDateTimeFromTime(now)
+		}
 	}
 	if slices.Contains(fieldMasks, "priority") {
 		update["priority"] = todo.Priority
 	}
+	deadline := todo.DeadLine
 	if slices.Contains(fieldMasks, "deadline") {
-		update["deadline"] = todo.DeadLine
+		if todo.DeadLine == 0 {
+			update["deadline"] = nil
+		} else {
+			update["deadline"] = todo.DeadLine
+		}
+	} else if existingTodo != nil {
+		deadline = existingTodo.DeadLine
+	}
+	if slices.Contains(fieldMasks, "labels") {
+		update["labels"] = todo.Labels
+	}
+	if slices.Contains(fieldMasks, "rank") && todo.Rank != "" {
+		update["rank"] = todo.Rank
+	}
+	if slices.Contains(fieldMasks, "hide_until") {
+		if todo.HideUntil == 0 {
+			update["hide_until"] = nil
+			update["snoozed"] = false
+		} else {
+			update["hide_until"] = todo.HideUntil
+			update["snoozed"] = todo.HideUntil.Time().After(now)
+		}
+	}
+	if slices.Contains(fieldMasks, "recurrence") {
+		if err := checkRecurrence(todo.Recurrence, deadline); err != nil {
+			return nil, err
+		}
+		update["recurrence"] = todo.Recurrence
 	}
-	update["update_time"] = primitive.NewDateTimeFromTime(time.Now())
-	updateTodo, err := s.todoRepo.updateTodo(ctx, todo.ID, update)
+
+	var previousAssignee primitive.ObjectID
+	if slices.Contains(fieldMasks, "assignee_id") {
+		err := s.checkAssignee(ctx, scope.UserID, todo.AssigneeID, existingTodo.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		previousAssignee = existingTodo.AssigneeID
+		if todo.AssigneeID.IsZero() {
+			update["assignee_id"] = nil
+		} else {
+			update["assignee_id"] = todo.AssigneeID
+		}
+	}
+
+	update["update_time"] = primitive.NewDateTimeFromTime(now)
+	var updateTodo *models.Todo
+	err := s.withSyncSeq(
+		ctx, scope, func(ctx mongo.SessionContext, seq int64) error {
+			var err error
+			update["sync_seq"] = seq
+			updateTodo, err = s.todoRepo.updateTodo(ctx, todo.ID, scope, update, precondition)
+			return err
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	if slices.Contains(fieldMasks, "assignee_id") &&
+		!updateTodo.AssigneeID.IsZero() &&
+		updateTodo.AssigneeID != previousAssignee &&
+		updateTodo.AssigneeID != scope.UserID {
+		s.publishTodoAssigned(updateTodo, scope.UserID)
+	}
+	s.publishAnalyticsEvent(
+		models.NewTodoAnalyticsEvent(models.AnalyticsEventTodoUpdated, updateTodo, scope.UserID),
+	)
+	if update["complete_time"] != nil {
+		s.publishAnalyticsEvent(
+			models.NewTodoAnalyticsEvent(models.AnalyticsEventTodoCompleted, updateTodo, scope.UserID),
+		)
+	}
+
 	return updateTodo, nil
 }
 
-func (s *serviceClient) FetchTodosWithNearbyDeadline(
-	ctx context.Context,
-) ([]models.Todo, error) {
-	todos, err := s.todoRepo.fetchTodosWithNearbyDeadline(ctx)
+func (s *serviceClient) DeleteTodo(ctx context.Context, todoId string, scope *models.Scope) error {
+	todoID, err := primitive.ObjectIDFromHex(todoId)
+	if err != nil {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid todo id",
+			},
+		}
+	}
+
+	_, err = s.deleteTodo(ctx, todoID, scope, nil)
+	return err
+}
+
+// deleteTodo removes the todo and leaves a tombstone behind for syncing
+// clients. The todo is only deleted when it also matches the precondition.
+func (s *serviceClient) deleteTodo(
+	ctx context.Context, todoId primitive.ObjectID, scope *models.Scope, precondition bson.M,
+) (*models.Todo, error) {
+	var deletedTodo *models.Todo
+	err := s.withSyncSeq(
+		ctx, scope, func(ctx mongo.SessionContext, seq int64) error {
+			var err error
+			deletedTodo, err = s.todoRepo.deleteTodo(ctx, todoId, scope, precondition)
+			if err != nil {
+				return err
+			}
+
+			err = s.todoRepo.insertTombstone(
+				ctx, &models.TodoTombstone{
+					ID:          deletedTodo.ID,
+					UserID:      deletedTodo.UserID,
+					WorkspaceID: deletedTodo.WorkspaceID,
+					SyncKey:     scope.Key(),
+					SyncSeq:     seq,
+					DeleteTime:  primitive.NewDateTimeFromTime(time.Now()),
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return s.userService.RemoveTodoIdFromUser(ctx, deletedTodo.UserID, deletedTodo.ID)
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
+	s.publishAnalyticsEvent(
+		models.NewTodoAnalyticsEvent(models.AnalyticsEventTodoDeleted, deletedTodo, scope.UserID),
+	)
 
-	return todos, nil
+	return deletedTodo, nil
+}
+
+func (s *serviceClient) SyncTodos(
+	ctx context.Context, scope *models.Scope, since models.SyncPosition, limit int64,
+) (*models.SyncTodosRes, error) {
+	// the counter is read first, every change up to it is committed already
+	counter, err := s.todoRepo.fetchSyncCounter(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	syncRes := &models.SyncTodosRes{}
+	initial := since.Seq == 0 && since.AfterID.IsZero()
+	if !initial && !since.Resync && (since.Seq < counter.CompactedSeq || since.Seq > counter.Seq) {
+		// deletions the client hasn't seen may be compacted away already
+		syncRes.FullResync = true
+		since = models.SyncPosition{}
+	}
+	if since.Seq == 0 {
+		if err = s.todoRepo.backfillSyncSeq(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	syncRes.Todos, err = s.todoRepo.fetchTodosSince(ctx, scope, since, counter.Seq, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	upTo := counter.Seq
+	syncRes.Position = models.SyncPosition{Seq: counter.Seq}
+	if int64(len(syncRes.Todos)) > limit {
+		syncRes.Todos = syncRes.Todos[:limit]
+		last := syncRes.Todos[limit-1]
+		upTo = last.SyncSeq
+		syncRes.HasMore = true
+		syncRes.Position = models.SyncPosition{
+			Seq:     last.SyncSeq,
+			AfterID: last.ID,
+			// the client is rebuilding its todos, the deletions it
+			// didn't get don't matter to it
+			Resync: initial || since.Resync || syncRes.FullResync,
+		}
+	}
+
+	if !initial && !syncRes.FullResync {
+		syncRes.Tombstones, err = s.todoRepo.fetchTombstonesSince(ctx, scope, since.Seq, upTo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return syncRes, nil
+}
+
+func (s *serviceClient) PushTodoChanges(
+	ctx context.Context, scope *models.Scope, changes []models.TodoChange,
+) ([]models.TodoChangeResult, error) {
+	results := make([]models.TodoChangeResult, 0, len(changes))
+	for _, change := range changes {
+		result, err := s.applyTodoChange(ctx, scope, &change)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+func (s *serviceClient) applyTodoChange(
+	ctx context.Context, scope *models.Scope, change *models.TodoChange,
+) (*models.TodoChangeResult, error) {
+	result := &models.TodoChangeResult{
+		ClientChangeID: change.ClientChangeID,
+		Status:         models.TodoChangeApplied,
+	}
+
+	var err error
+	switch {
+	case change.Delete:
+		_, err = s.deleteTodo(ctx, change.Todo.ID, scope, baseSeqPrecondition(change.BaseSyncSeq))
+	case change.Todo.ID.IsZero():
+		result.Todo, err = s.CreateTodo(ctx, change.Todo)
+	default:
+		result.Todo, err = s.updateTodo(
+			ctx, scope, change.Todo, change.FieldMasks, baseSeqPrecondition(change.BaseSyncSeq),
+		)
+	}
+	if err == nil {
+		return result, nil
+	}
+
+	switch err.(type) {
+	case *utils.ReqInvalidArgumentError, *utils.DbNotFoundError, *utils.ResourceExhaustedError:
+		if change.Todo.ID.IsZero() {
+			result.Status = models.TodoChangeInvalid
+			result.Msg = err.Error()
+			return result, nil
+		}
+	default:
+		return nil, err
+	}
+
+	// the precondition failed, find out whether the todo changed meanwhile
+	// or doesn't exist at all
+	current, fetchErr := s.todoRepo.fetchTodo(ctx, change.Todo.ID, scope)
+	if fetchErr == nil {
+		if current.SyncSeq > change.BaseSyncSeq {
+			result.Status = models.TodoChangeConflict
+			result.Msg = "todo changed on the server"
+		} else {
+			result.Status = models.TodoChangeInvalid
+			result.Msg = err.Error()
+		}
+		result.Todo = current
+		return result, nil
+	}
+
+	if _, tombstoneErr := s.todoRepo.fetchTombstone(ctx, change.Todo.ID, scope); tombstoneErr == nil {
+		if change.Delete {
+			// deleted already, e.g. by a retry of the same change
+			return result, nil
+		}
+		result.Status = models.TodoChangeConflict
+		result.Msg = "todo was deleted on the server"
+		return result, nil
+	}
+
+	result.Status = models.TodoChangeNotFound
+	result.Msg = "todo not found"
+	return result, nil
+}
+
+func (s *serviceClient) CompactTombstones(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.todoRepo.compactTombstones(ctx, time.Now().Add(-retention))
+}
+
+func (s *serviceClient) UnassignTodos(
+	ctx context.Context, userId primitive.ObjectID, workspaceIds []primitive.ObjectID,
+) error {
+	for _, workspaceId := range workspaceIds {
+		scope := &models.Scope{UserID: userId, WorkspaceID: workspaceId}
+		err := s.withSyncSeq(
+			ctx, scope, func(ctx mongo.SessionContext, seq int64) error {
+				return s.todoRepo.unassignTodos(ctx, workspaceId, userId, seq)
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) DeleteUserTodos(
+	ctx context.Context, userId primitive.ObjectID, workspaceIds []primitive.ObjectID,
+) error {
+	if err := s.todoRepo.deleteScopeTodos(ctx, &models.Scope{UserID: userId}); err != nil {
+		return err
+	}
+	for _, workspaceId := range workspaceIds {
+		scope := &models.Scope{UserID: userId, WorkspaceID: workspaceId}
+		if err := s.todoRepo.deleteScopeTodos(ctx, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withSyncSeq runs write in a transaction together with taking the next sync
+// sequence of the scope. Clients that see the new sequence are thereby sure
+// to also see the write made with it.
+func (s *serviceClient) withSyncSeq(
+	ctx context.Context, scope *models.Scope, write func(ctx mongo.SessionContext, seq int64) error,
+) error {
+	// callers running their own transaction, like account deletion, take the
+	// sequence in theirs
+	if sessionCtx, ok := ctx.(mongo.SessionContext); ok {
+		seq, err := s.todoRepo.nextSyncSeq(sessionCtx, scope)
+		if err != nil {
+			return err
+		}
+		return write(sessionCtx, seq)
+	}
+
+	session, err := s.todoRepo.startSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	wc := writeconcern.New(writeconcern.WMajority())
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		seq, err := s.todoRepo.nextSyncSeq(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, write(ctx, seq)
+	}
+
+	_, err = session.WithTransaction(ctx, callback, txnOpts)
+	return err
+}
+
+// baseSeqPrecondition only lets a change through when the todo wasn't
+// changed since the client last synced it.
+func baseSeqPrecondition(baseSeq int64) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{
+				"sync_seq": bson.M{
+					"$lte": baseSeq,
+				},
+			},
+			bson.M{
+				"sync_seq": bson.M{
+					"$exists": false,
+				},
+			},
+		},
+	}
+}
+
+func (s *serviceClient) ListAssignedTodos(
+	ctx context.Context, scope *models.Scope, filter *models.ListTodoFilter,
+) (*models.ListTodoRes, error) {
+	// without a workspace, only the workspaces the user is still a member of
+	// are listed, the todos of the others aren't theirs to see anymore
+	workspaceIds := make([]primitive.ObjectID, 0)
+	if !scope.IsWorkspace() {
+		workspaces, err := s.workspaceService.ListWorkspaces(ctx, scope.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, workspace := range workspaces {
+			workspaceIds = append(workspaceIds, workspace.ID)
+		}
+	}
+
+	var todoRes models.ListTodoRes
+	erg, _ := errgroup.WithContext(ctx)
+	erg.Go(
+		func() error {
+			var todoErr error
+			todoRes.Todos, todoErr = s.todoRepo.fetchAssignedTodos(ctx, scope, workspaceIds, filter)
+			return todoErr
+		},
+	)
+
+	erg.Go(
+		func() error {
+			var countErr error
+			todoRes.Count, countErr = s.todoRepo.countAssignedTodos(ctx, scope, workspaceIds)
+			return countErr
+		},
+	)
+	if err := erg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &todoRes, nil
+}
+
+type todoChangeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument             *models.Todo `bson:"fullDocument"`
+	FullDocumentBeforeChange *models.Todo `bson:"fullDocumentBeforeChange"`
+}
+
+var changeEventTypes = map[string]models.TodoEventType{
+	"insert":  models.TodoEventCreated,
+	"update":  models.TodoEventUpdated,
+	"replace": models.TodoEventUpdated,
+	"delete":  models.TodoEventDeleted,
+}
+
+func (s *serviceClient) WatchTodos(
+	ctx context.Context,
+	scope *models.Scope,
+	filter *models.WatchTodoFilter,
+	resumeToken string,
+	send func(event *models.TodoEvent) error,
+) error {
+	var token bson.Raw
+	if resumeToken != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err == nil {
+			err = bson.Raw(decoded).Validate()
+		}
+		if err != nil {
+			return &utils.ReqInvalidArgumentError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid resume token",
+				},
+			}
+		}
+		token = decoded
+	}
+
+	// the change stream is opened before the snapshot is read so that no
+	// change made in between gets lost
+	changeStream, err := s.todoRepo.watchTodos(ctx, scope, filter, token)
+	if err != nil {
+		if token != nil {
+			return &utils.ReqInvalidArgumentError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "unable to resume stream, start a new one",
+				},
+			}
+		}
+		return err
+	}
+	defer changeStream.Close(context.Background())
+
+	if token == nil {
+		if err = s.sendSnapshot(ctx, scope, filter, encodeResumeToken(changeStream.ResumeToken()), send); err != nil {
+			return err
+		}
+	}
+
+	for changeStream.Next(ctx) {
+		var changeEvent todoChangeEvent
+		if err = changeStream.Decode(&changeEvent); err != nil {
+			return err
+		}
+
+		event := &models.TodoEvent{
+			Type:        changeEventTypes[changeEvent.OperationType],
+			TodoID:      changeEvent.DocumentKey.ID,
+			Todo:        changeEvent.FullDocument,
+			ResumeToken: encodeResumeToken(changeStream.ResumeToken()),
+		}
+		// an update looked up after the todo got deleted, the deletion follows
+		if event.Type != models.TodoEventDeleted && event.Todo == nil {
+			continue
+		}
+		if event.Type == models.TodoEventUpdated && !filter.Matches(event.Todo) {
+			// the todo left the filter, without a pre-image it may never
+			// have been in it, which clients handle as a no-op removal
+			before := changeEvent.FullDocumentBeforeChange
+			if before != nil && !filter.Matches(before) {
+				continue
+			}
+			event.Type = models.TodoEventDeleted
+			event.Todo = nil
+		}
+		if err = send(event); err != nil {
+			return err
+		}
+	}
+
+	// the client went away, nothing left to report
+	if ctx.Err() != nil {
+		return nil
+	}
+	return changeStream.Err()
+}
+
+func (s *serviceClient) sendSnapshot(
+	ctx context.Context,
+	scope *models.Scope,
+	filter *models.WatchTodoFilter,
+	resumeToken string,
+	send func(event *models.TodoEvent) error,
+) error {
+	cursor, err := s.todoRepo.findWatchedTodos(ctx, scope, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	var count int64
+	for cursor.Next(ctx) {
+		var todo models.Todo
+		if err = cursor.Decode(&todo); err != nil {
+			return err
+		}
+		err = send(
+			&models.TodoEvent{
+				Type:   models.TodoEventSnapshot,
+				TodoID: todo.ID,
+				Todo:   &todo,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	if err = cursor.Err(); err != nil {
+		return err
+	}
+
+	return send(
+		&models.TodoEvent{
+			Type:        models.TodoEventSnapshotComplete,
+			ResumeToken: resumeToken,
+			Count:       count,
+		},
+	)
+}
+
+func encodeResumeToken(token bson.Raw) string {
+	if token == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// checkAssignee makes sure a todo can be handed over to assigneeId. Todos of
+// a workspace can only be assigned to its members, personal todos to users
+// sharing at least one workspace with the assigner.
+func (s *serviceClient) checkAssignee(
+	ctx context.Context, assignerId, assigneeId, workspaceId primitive.ObjectID,
+) error {
+	if assigneeId.IsZero() || assigneeId == assignerId {
+		return nil
+	}
+
+	if !workspaceId.IsZero() {
+		_, err := s.workspaceService.FetchMember(ctx, workspaceId, assigneeId)
+		if err != nil {
+			if _, ok := err.(*utils.ResourcePermissionDeniedError); ok {
+				return &utils.ReqInvalidArgumentError{
+					GeneralError: &utils.GeneralError{
+						DevInfo: err.Error(),
+						Msg:     "assignee is not a member of the workspace",
+					},
+				}
+			}
+			return err
+		}
+		return nil
+	}
+
+	// personal todos are only visible to their owner
+	return &utils.ReqInvalidArgumentError{
+		GeneralError: &utils.GeneralError{
+			Msg: "only workspace todos can be assigned to other users",
+		},
+	}
+}
+
+func (s *serviceClient) publishTodoAssigned(todo *models.Todo, assignerId primitive.ObjectID) {
+	data, err := json.Marshal(
+		&models.TodoAssignedEvent{
+			TodoID:      todo.ID,
+			TodoName:    todo.Name,
+			AssigneeID:  todo.AssigneeID,
+			AssignerID:  assignerId,
+			WorkspaceID: todo.WorkspaceID,
+		},
+	)
+	if err != nil {
+		s.logger.Error(err, "unable to marshal todo assigned event")
+		return
+	}
+	s.kafkaProvider.Publish(models.TopicTodoAssigned, data)
+}
+
+func (s *serviceClient) publishAnalyticsEvent(event *models.AnalyticsEvent) {
+	// nothing consumes the events without an analytics store
+	if s.config.GetClickHouseDsn() == "" {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error(err, "unable to marshal analytics event")
+		return
+	}
+	s.kafkaProvider.Publish(models.TopicAnalyticsEvents, data)
+}
+
+func (s *serviceClient) SnoozeTodo(
+	ctx context.Context, scope *models.Scope, todoId string, until time.Time,
+) (*models.Todo, error) {
+	todoID, err := primitive.ObjectIDFromHex(todoId)
+	if err != nil {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid todo id",
+			},
+		}
+	}
+
+	todo := &models.Todo{ID: todoID}
+	if !until.IsZero() {
+		todo.HideUntil = primitive.NewDateTimeFromTime(until)
+	}
+	return s.updateTodo(ctx, scope, todo, []string{"hide_until"}, nil)
+}
+
+// maxReleasedSnoozes caps the snoozes ended in one go, the rest is picked up
+// by the next call
+const maxReleasedSnoozes = 500
+
+func (s *serviceClient) ReleaseExpiredSnoozes(ctx context.Context) ([]models.Todo, error) {
+	now := time.Now()
+	todos := make([]models.Todo, 0)
+	for len(todos) < maxReleasedSnoozes {
+		todo, err := s.todoRepo.releaseExpiredSnooze(ctx, now)
+		if err != nil {
+			return todos, err
+		}
+		if todo == nil {
+			break
+		}
+		todos = append(todos, *todo)
+	}
+
+	return todos, nil
+}
+
+func (s *serviceClient) RestoreSnoozes(ctx context.Context, todoIds []primitive.ObjectID) error {
+	return s.todoRepo.restoreSnoozes(ctx, todoIds, time.Now())
+}
+
+func (s *serviceClient) FetchTodosWithNearbyDeadline(
+	ctx context.Context,
+) ([]models.Todo, error) {
+	todos, err := s.todoRepo.fetchTodosWithNearbyDeadline(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (s *serviceClient) GetStats(
+	ctx context.Context, scope *models.Scope, filter *models.StatsFilter,
+) (*models.TodoStats, error) {
+	cacheKey := fmt.Sprintf(
+		"%s|%d|%d|%s", scope.Key(), filter.Start.Unix(), filter.End.Unix(), filter.Location,
+	)
+	if stats, ok := s.statsCache.get(cacheKey); ok {
+		return stats, nil
+	}
+
+	facets, err := s.todoRepo.aggregateStats(ctx, scope, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.TodoStats{}
+	for day := filter.Start.In(filter.Location); day.Before(filter.End); day = day.AddDate(0, 0, 1) {
+		stats.Days = append(stats.Days, models.DailyTodoStats{Date: day.Format(time.DateOnly)})
+	}
+	days := make(map[string]*models.DailyTodoStats, len(stats.Days))
+	for i := range stats.Days {
+		days[stats.Days[i].Date] = &stats.Days[i]
+	}
+
+	for _, created := range facets.Created {
+		if day, ok := days[created.Date]; ok {
+			day.Created = created.Count
+		}
+		stats.Created += created.Count
+	}
+	for _, completed := range facets.Completed {
+		if day, ok := days[completed.Date]; ok {
+			day.Completed = completed.Count
+		}
+		stats.Completed += completed.Count
+	}
+	for _, overdue := range facets.Overdue {
+		if day, ok := days[overdue.Date]; ok {
+			day.Overdue = overdue.Count
+		}
+	}
+
+	var createdCompleted int64
+	for _, priority := range facets.Priorities {
+		stats.Priorities = append(
+			stats.Priorities, models.PriorityTodoStats{
+				Priority:  priority.Priority,
+				Created:   priority.Created,
+				Completed: priority.Completed,
+			},
+		)
+		createdCompleted += priority.Completed
+	}
+	slices.SortFunc(
+		stats.Priorities, func(a, b models.PriorityTodoStats) int {
+			return strings.Compare(a.Priority, b.Priority)
+		},
+	)
+	if stats.Created > 0 {
+		stats.CompletionRate = float64(createdCompleted) / float64(stats.Created)
+	}
+	if len(facets.Durations) > 0 {
+		stats.AverageTimeToComplete = time.Duration(facets.Durations[0].AverageMillis) * time.Millisecond
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = completionStreaks(facets.CompletionDays, filter.Location)
+
+	s.statsCache.set(cacheKey, stats)
+	return stats, nil
+}
+
+// maxBoardColumns caps the columns of a board, boards grouped by label may
+// otherwise grow without bounds
+const maxBoardColumns = 50
+
+func (s *serviceClient) GetBoard(
+	ctx context.Context, scope *models.Scope, filter *models.BoardFilter,
+) (*models.Board, error) {
+	if err := s.todoRepo.backfillRanks(ctx, scope); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch board",
+			},
+		}
+	}
+
+	counts, err := s.todoRepo.countBoardColumns(ctx, scope, filter.GroupBy, filter.Todos)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch board",
+			},
+		}
+	}
+
+	var keys []string
+	switch {
+	case filter.Column != nil:
+		keys = []string{*filter.Column}
+	case filter.GroupBy == models.BoardGroupByStatus:
+		keys = []string{models.BoardColumnOpen, models.BoardColumnDone}
+	case filter.GroupBy == models.BoardGroupByPriority:
+		keys = []string{"HIGH", "MEDIUM", "LOW"}
+	default:
+		// the column of unassigned or unlabeled todos comes first
+		keys = []string{""}
+	}
+	countByKey := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countByKey[count.Key] = count.Count
+		if filter.Column == nil && !slices.Contains(keys, count.Key) {
+			keys = append(keys, count.Key)
+		}
+	}
+	if len(keys) > maxBoardColumns {
+		keys = keys[:maxBoardColumns]
+	}
+
+	board := &models.Board{
+		GroupBy: filter.GroupBy,
+		Columns: make([]models.BoardColumn, len(keys)),
+	}
+	erg, _ := errgroup.WithContext(ctx)
+	for i, key := range keys {
+		column := &board.Columns[i]
+		column.Key = key
+		column.Count = countByKey[key]
+		erg.Go(
+			func() error {
+				var err error
+				column.Cards, err = s.todoRepo.fetchBoardCards(ctx, scope, filter.GroupBy, column.Key, filter.Todos)
+				return err
+			},
+		)
+	}
+	if err = erg.Wait(); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch board",
+			},
+		}
+	}
+
+	return board, nil
+}
+
+// MoveCard changes the field the board is grouped by together with the rank
+// of the todo in a single update. The update only goes through when the todo
+// didn't change since it was read.
+func (s *serviceClient) MoveCard(
+	ctx context.Context, scope *models.Scope, move *models.CardMove,
+) (*models.Todo, error) {
+	if err := s.todoRepo.backfillRanks(ctx, scope); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to move card",
+			},
+		}
+	}
+
+	existingTodo, err := s.todoRepo.fetchTodo(ctx, move.TodoID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	todo := &models.Todo{
+		ID:   existingTodo.ID,
+		Rank: existingTodo.Rank,
+	}
+	fieldMasks := []string{"rank"}
+	switch move.GroupBy {
+	case models.BoardGroupByStatus:
+		todo.Status = move.ToColumn == models.BoardColumnDone
+		fieldMasks = append(fieldMasks, "status")
+	case models.BoardGroupByPriority:
+		todo.Priority = move.ToColumn
+		fieldMasks = append(fieldMasks, "priority")
+	case models.BoardGroupByAssignee:
+		if move.ToColumn != "" {
+			todo.AssigneeID, err = primitive.ObjectIDFromHex(move.ToColumn)
+			if err != nil {
+				return nil, &utils.ReqInvalidArgumentError{
+					GeneralError: &utils.GeneralError{
+						DevInfo: err.Error(),
+						Msg:     "invalid column",
+					},
+				}
+			}
+		}
+		fieldMasks = append(fieldMasks, "assignee_id")
+	case models.BoardGroupByLabel:
+		if move.FromColumn != "" && !slices.Contains(existingTodo.Labels, move.FromColumn) {
+			return nil, &utils.ReqInvalidArgumentError{
+				GeneralError: &utils.GeneralError{
+					Msg: "todo isn't in the column it is moved from",
+				},
+			}
+		}
+		for _, label := range existingTodo.Labels {
+			if label != move.FromColumn {
+				todo.Labels = append(todo.Labels, label)
+			}
+		}
+		if move.ToColumn != "" && !slices.Contains(todo.Labels, move.ToColumn) {
+			todo.Labels = append(todo.Labels, move.ToColumn)
+		}
+		fieldMasks = append(fieldMasks, "labels")
+	}
+
+	if !move.PreviousID.IsZero() || !move.NextID.IsZero() {
+		todo.Rank, err = s.rankBetween(ctx, scope, move.PreviousID, move.NextID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	movedTodo, err := s.updateTodo(ctx, scope, todo, fieldMasks, baseSeqPrecondition(existingTodo.SyncSeq))
+	if err != nil {
+		if _, ok := err.(*utils.ReqInvalidArgumentError); !ok {
+			return nil, err
+		}
+		// the precondition failed unless the todo is still as it was read,
+		// e.g. when the assignee was rejected
+		current, fetchErr := s.todoRepo.fetchTodo(ctx, move.TodoID, scope)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		if current.SyncSeq > existingTodo.SyncSeq {
+			return nil, &utils.AbortedError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "the todo changed meanwhile, reload the board",
+				},
+			}
+		}
+		return nil, err
+	}
+
+	return movedTodo, nil
+}
+
+// rankBetween returns a rank that puts a todo between the two todos, a zero
+// id stands for the top or the bottom of the board.
+func (s *serviceClient) rankBetween(
+	ctx context.Context, scope *models.Scope, previousId, nextId primitive.ObjectID,
+) (string, error) {
+	var previousRank, nextRank string
+	if !previousId.IsZero() {
+		previous, err := s.todoRepo.fetchTodo(ctx, previousId, scope)
+		if err != nil {
+			return "", err
+		}
+		previousRank = previous.Rank
+	}
+	if !nextId.IsZero() {
+		next, err := s.todoRepo.fetchTodo(ctx, nextId, scope)
+		if err != nil {
+			return "", err
+		}
+		nextRank = next.Rank
+		// todos moved into the same gap at once share a rank, there is no
+		// room between them until they are told apart
+		if nextRank != "" && nextRank == previousRank {
+			if err = s.todoRepo.breakRankTie(ctx, scope, nextRank); err != nil {
+				return "", err
+			}
+			if next, err = s.todoRepo.fetchTodo(ctx, nextId, scope); err != nil {
+				return "", err
+			}
+			nextRank = next.Rank
+		}
+	}
+
+	rank, err := utils.RankBetween(previousRank, nextRank)
+	if err != nil {
+		return "", &utils.AbortedError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "the neighbouring todos moved meanwhile, reload the board",
+			},
+		}
+	}
+	return rank, nil
+}
+
+// maxOverdueTodos caps the overdue bucket of the agenda, the oldest todos are
+// the ones shown
+const maxOverdueTodos = 200
+
+func (s *serviceClient) GetAgenda(
+	ctx context.Context, scope *models.Scope, filter *models.AgendaFilter,
+) (*models.Agenda, error) {
+	now := time.Now()
+	var todos, overdueTodos []models.Todo
+	erg, _ := errgroup.WithContext(ctx)
+	erg.Go(
+		func() error {
+			var err error
+			todos, err = s.todoRepo.fetchAgendaTodos(ctx, scope, filter.Start, filter.End)
+			return err
+		},
+	)
+	erg.Go(
+		func() error {
+			var err error
+			overdueTodos, err = s.todoRepo.fetchOverdueTodos(ctx, scope, now, maxOverdueTodos)
+			return err
+		},
+	)
+	if err := erg.Wait(); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch agenda",
+			},
+		}
+	}
+
+	agenda := &models.Agenda{}
+	for day := filter.Start.In(filter.Location); day.Before(filter.End); day = day.AddDate(0, 0, 1) {
+		agenda.Days = append(agenda.Days, models.AgendaBucket{Date: day.Format(time.DateOnly)})
+	}
+	days := make(map[string]*models.AgendaBucket, len(agenda.Days))
+	for i := range agenda.Days {
+		days[agenda.Days[i].Date] = &agenda.Days[i]
+	}
+
+	for i := range todos {
+		todo := &todos[i]
+		for _, occursAt := range occurrences(todo, filter) {
+			day, ok := days[occursAt.In(filter.Location).Format(time.DateOnly)]
+			if !ok {
+				continue
+			}
+			day.Add(
+				models.AgendaItem{
+					Todo:      todo,
+					OccursAt:  occursAt,
+					Recurring: todo.Recurrence != nil,
+				},
+			)
+		}
+	}
+	for i := range overdueTodos {
+		agenda.Overdue.Add(
+			models.AgendaItem{
+				Todo:      &overdueTodos[i],
+				OccursAt:  lastOccurrence(&overdueTodos[i], filter.Location),
+				Recurring: overdueTodos[i].Recurrence != nil,
+			},
+		)
+	}
+
+	return agenda, nil
+}
+
+// occurrences lists the times the todo is due within the range of the filter.
+// Recurrences are expanded in the zone of the filter, so that e.g. a daily
+// todo keeps its local time across daylight saving changes.
+func occurrences(todo *models.Todo, filter *models.AgendaFilter) []time.Time {
+	if todo.DeadLine == 0 {
+		return nil
+	}
+	first := todo.DeadLine.Time().In(filter.Location)
+	if todo.Recurrence == nil {
+		if first.Before(filter.Start) || !first.Before(filter.End) {
+			return nil
+		}
+		return []time.Time{first}
+	}
+
+	var occursAt []time.Time
+	for n := 0; ; n++ {
+		occurrence := todo.Recurrence.Occurrence(first, n)
+		if !occurrence.Before(filter.End) ||
+			(todo.Recurrence.Until != 0 && occurrence.After(todo.Recurrence.Until.Time())) {
+			break
+		}
+		if !occurrence.Before(filter.Start) {
+			occursAt = append(occursAt, occurrence)
+		}
+	}
+	return occursAt
+}
+
+// lastOccurrence returns the last time the todo is due, which is the deadline
+// unless the todo recurs until a given time.
+func lastOccurrence(todo *models.Todo, location *time.Location) time.Time {
+	first := todo.DeadLine.Time().In(location)
+	if todo.Recurrence == nil || todo.Recurrence.Until == 0 {
+		return first
+	}
+
+	last := first
+	for n := 1; ; n++ {
+		occurrence := todo.Recurrence.Occurrence(first, n)
+		if occurrence.After(todo.Recurrence.Until.Time()) {
+			return last
+		}
+		last = occurrence
+	}
+}
+
+// checkRecurrence makes sure a recurring todo has a deadline to start from.
+func checkRecurrence(recurrence *models.Recurrence, deadline primitive.DateTime) error {
+	if recurrence != nil && deadline == 0 {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "a recurring todo needs a deadline",
+			},
+		}
+	}
+	return nil
+}
+
+// completionStreaks counts the runs of consecutive days with a completed todo.
+// The current streak is still alive when the last completion was yesterday,
+// as there is time left to complete a todo today.
+func completionStreaks(completionDays []dayCount, location *time.Location) (int, int) {
+	var current, longest int
+	var previous time.Time
+	for _, completionDay := range completionDays {
+		day, err := time.ParseInLocation(time.DateOnly, completionDay.Date, location)
+		if err != nil {
+			continue
+		}
+		if !previous.IsZero() && previous.AddDate(0, 0, 1).Equal(day) {
+			current++
+		} else {
+			current = 1
+		}
+		longest = max(longest, current)
+		previous = day
+	}
+
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	if previous.IsZero() || previous.Before(today.AddDate(0, 0, -1)) {
+		current = 0
+	}
+	return current, longest
+}
+
+type statsCacheEntry struct {
+	stats      *models.TodoStats
+	expireTime time.Time
+}
+
+// statsCache keeps computed stats in memory for a short while.
+type statsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]statsCacheEntry
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{
+		ttl:     ttl,
+		entries: make(map[string]statsCacheEntry),
+	}
+}
+
+func (c *statsCache) get(key string) (*models.TodoStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expireTime) {
+		return nil, false
+	}
+	return entry.stats, true
+}
+
+func (c *statsCache) set(key string, stats *models.TodoStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for cachedKey, entry := range c.entries {
+		if now.After(entry.expireTime) {
+			delete(c.entries, cachedKey)
+		}
+	}
+	c.entries[key] = statsCacheEntry{
+		stats:      stats,
+		expireTime: now.Add(c.ttl),
+	}
 }
@@ -3,12 +3,84 @@ package service
 import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/pb"
 )
 
 type UserService interface {
+	EnsureIndexes(ctx context.Context) error
 	Register(ctx context.Context, user *models.User) (*pb.RegisterResponse, error)
-	Login(ctx context.Context, user *models.User) (string, error)
+	// Login backs off and eventually locks out accounts and client ips with
+	// too many failed logins. Users with two factor authentication only get
+	// a challenge token.
+	Login(ctx context.Context, user *models.User, clientIp string) (*models.AuthTokens, error)
+	// LoginWithIdentity logs in the user linked to an identity of an OpenID
+	// Connect provider. Unknown identities are linked to the user with their
+	// verified email, or get a new user.
+	LoginWithIdentity(ctx context.Context, identity *models.OidcIdentity) (*models.AuthTokens, error)
+	// Logout revokes the token of the claims and the session of the refresh
+	// token, or every token of the user when everywhere is set
+	Logout(ctx context.Context, claims *models.UserClaims, refreshToken string, everywhere bool) error
+	RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	// Verify2FA exchanges the challenge token of a login and a TOTP or backup
+	// code for the tokens of the login
+	Verify2FA(ctx context.Context, challengeToken, code, clientIp string) (*models.AuthTokens, error)
+	// Enable2FA creates a pending TOTP secret, Confirm2FA enables it with a
+	// first code and returns the backup codes
+	Enable2FA(ctx context.Context, userId primitive.ObjectID) (*models.TwoFactorSetup, error)
+	Confirm2FA(ctx context.Context, userId primitive.ObjectID, code string) ([]string, error)
+	Disable2FA(ctx context.Context, userId primitive.ObjectID, code, clientIp string) error
+	VerifyEmail(ctx context.Context, token string) error
+	// ResendVerification sends another verification email, at most once
+	// every few minutes
+	ResendVerification(ctx context.Context, userId primitive.ObjectID) error
+	// CheckEmailVerified fails with a precondition error when the user
+	// didn't verify their email yet
+	CheckEmailVerified(ctx context.Context, userId primitive.ObjectID) error
+	// RequestPasswordReset emails a single use reset token when a user has
+	// the email, it doesn't fail for unknown emails
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets the password of the user of the reset token and
+	// revokes all of their tokens
+	ResetPassword(ctx context.Context, token, newPassword string) error
 	AddTodoIdToUser(ctx context.Context, userId, todoId primitive.ObjectID) error
+	RemoveTodoIdFromUser(ctx context.Context, userId, todoId primitive.ObjectID) error
+	FetchUser(ctx context.Context, userId primitive.ObjectID) (*models.User, error)
+	// UpdateProfile updates the fields of the masks, a new email has to be
+	// verified again. Changing the email is confirmed like ChangePassword and
+	// the old address is told about it.
+	UpdateProfile(
+		ctx context.Context, userId primitive.ObjectID, user *models.User, fieldMasks []string,
+		currentPassword string, authTime time.Time,
+	) (*models.User, error)
+	// ChangePassword revokes every token of the user and returns the tokens
+	// of a new session. Users without a password confirm it by having
+	// authenticated recently instead, see utils.CheckRecentAuth.
+	ChangePassword(
+		ctx context.Context, userId primitive.ObjectID, currentPassword, newPassword string, authTime time.Time,
+	) (*models.AuthTokens, error)
+	// ListUsers searches every user by name or email, for admins
+	ListUsers(ctx context.Context, filter *models.ListUsersFilter) (*models.ListUsersRes, error)
+	// SetUserDisabled disables or enables the user, disabling also revokes
+	// every token of the user
+	SetUserDisabled(ctx context.Context, userId primitive.ObjectID, disabled bool) (*models.User, error)
+	// SetUserPremium sets whether the user is premium and when that ends, a
+	// zero expire time doesn't end
+	SetUserPremium(
+		ctx context.Context, userId primitive.ObjectID, isPremium bool, expireTime time.Time,
+	) (*models.User, error)
+	// ClaimPremiumEndingUsers marks the users whose premium plan ends within
+	// the notice as notified and returns them, each user is only returned
+	// once per expire time
+	ClaimPremiumEndingUsers(ctx context.Context, notice time.Duration) ([]models.User, error)
+	// ReleasePremiumEndingClaims undoes the claims of the users who couldn't
+	// be notified, the next call returns them again
+	ReleasePremiumEndingClaims(ctx context.Context, userIds []primitive.ObjectID) error
+	// RevokeSessions revokes every access, refresh and personal access token
+	// of the user
+	RevokeSessions(ctx context.Context, userId primitive.ObjectID) error
+	// DeleteUser only deletes the user itself, AccountService deletes the
+	// rest of the account
+	DeleteUser(ctx context.Context, userId primitive.ObjectID) error
 }
@@ -5,6 +5,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/utils"
 )
@@ -16,9 +19,28 @@ type repoClient struct {
 }
 
 type userRepo interface {
+	ensureIndexes(ctx context.Context) error
 	insertUser(ctx context.Context, user *models.User) (primitive.ObjectID, error)
 	fetchUserByEmail(ctx context.Context, email string) (*models.User, error)
+	fetchUserById(ctx context.Context, userId primitive.ObjectID) (*models.User, error)
+	fetchUserByIdentity(ctx context.Context, issuer, subject string) (*models.User, error)
+	fetchUsers(ctx context.Context, filter *models.ListUsersFilter) ([]models.User, error)
+	countUsers(ctx context.Context, filter *models.ListUsersFilter) (int64, error)
+	claimPremiumEnding(ctx context.Context, now, endingBefore time.Time) (*models.User, error)
+	releasePremiumEndingClaims(ctx context.Context, userIds []primitive.ObjectID) error
 	addTodoIdToUser(ctx context.Context, todoId, userId primitive.ObjectID) error
+	removeTodoIdFromUser(ctx context.Context, todoId, userId primitive.ObjectID) error
+	verifyEmail(ctx context.Context, userId primitive.ObjectID, email string) (bool, error)
+	claimVerificationSend(
+		ctx context.Context, userId primitive.ObjectID, now, sentBefore time.Time,
+	) (*models.User, error)
+	setPasswordReset(
+		ctx context.Context, userId primitive.ObjectID, reset *models.PasswordReset, sentBefore time.Time,
+	) (bool, error)
+	resetPassword(ctx context.Context, tokenHash string, password string, now time.Time) (*models.User, error)
+	updateUser(ctx context.Context, userId primitive.ObjectID, update bson.M) (*models.User, error)
+	updateUserWhere(ctx context.Context, userId primitive.ObjectID, precondition, update bson.M) (bool, error)
+	deleteUser(ctx context.Context, userId primitive.ObjectID) error
 	startSession() (mongo.Session, error)
 }
 
@@ -32,21 +54,66 @@ func newRepoClient(
 	}
 }
 
+// emailCollation compares emails case-insensitively. Lookups by email use
+// it to be served by the unique email index.
+var emailCollation = &options.Collation{Locale: "en", Strength: 2}
+
+func (r *repoClient) ensureIndexes(ctx context.Context) error {
+	_, err := r.usersC.Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "email", Value: 1}},
+				Options: options.Index().SetUnique(true).SetCollation(emailCollation),
+			},
+			{
+				Keys:    bson.D{{Key: "password_reset.token_hash", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys: bson.D{
+					{Key: "identities.issuer", Value: 1},
+					{Key: "identities.subject", Value: 1},
+				},
+				Options: options.Index().SetUnique(true).SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "premium_expire_time", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+		},
+	)
+	return err
+}
+
 func (r *repoClient) insertUser(ctx context.Context, user *models.User) (primitive.ObjectID, error) {
 	insertedRes, err := r.usersC.InsertOne(ctx, user)
 	if err != nil {
-		return primitive.NilObjectID, err
+		if mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, &utils.AlreadyExists{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "email is already registered",
+				},
+			}
+		}
+		return primitive.NilObjectID, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create user",
+			},
+		}
 	}
 
-	return insertedRes.InsertedID.(primitive.ObjectID), err
+	return insertedRes.InsertedID.(primitive.ObjectID), nil
 }
 
 func (r *repoClient) fetchUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	filter := bson.M{
 		"email": email,
 	}
+	opts := options.FindOne().SetCollation(emailCollation)
 	var user models.User
-	err := r.usersC.FindOne(ctx, filter).Decode(&user)
+	err := r.usersC.FindOne(ctx, filter, opts).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			customErr := &utils.ReqInvalidArgumentError{
@@ -67,6 +134,176 @@ func (r *repoClient) fetchUserByEmail(ctx context.Context, email string) (*model
 	return &user, err
 }
 
+func (r *repoClient) fetchUserById(ctx context.Context, userId primitive.ObjectID) (*models.User, error) {
+	filter := bson.M{
+		"_id": userId,
+	}
+	var user models.User
+	err := r.usersC.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "user not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch user",
+			},
+		}
+	}
+	return &user, nil
+}
+
+func (r *repoClient) fetchUserByIdentity(ctx context.Context, issuer, subject string) (*models.User, error) {
+	filter := bson.M{
+		"identities": bson.M{
+			"$elemMatch": bson.M{
+				"issuer":  issuer,
+				"subject": subject,
+			},
+		},
+	}
+	var user models.User
+	err := r.usersC.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "user not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch user",
+			},
+		}
+	}
+	return &user, nil
+}
+
+// listUsersFilter matches the users with the query in their name or email
+func listUsersFilter(filter *models.ListUsersFilter) bson.M {
+	if filter.Query == "" {
+		return bson.M{}
+	}
+	pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		},
+	}
+}
+
+func (r *repoClient) fetchUsers(ctx context.Context, filter *models.ListUsersFilter) ([]models.User, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(filter.Limit)).
+		SetSkip(int64((filter.Page - 1) * filter.Limit))
+
+	cursor, err := r.usersC.Find(ctx, listUsersFilter(filter), opts)
+	if err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch users",
+			},
+		}
+	}
+	users := []models.User{}
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch users",
+			},
+		}
+	}
+	return users, nil
+}
+
+func (r *repoClient) countUsers(ctx context.Context, filter *models.ListUsersFilter) (int64, error) {
+	count, err := r.usersC.CountDocuments(ctx, listUsersFilter(filter))
+	if err != nil {
+		return 0, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to count users",
+			},
+		}
+	}
+	return count, nil
+}
+
+// claimPremiumEnding marks one premium user whose plan ends between now and
+// endingBefore as notified and returns them, nil when there is none left.
+func (r *repoClient) claimPremiumEnding(ctx context.Context, now, endingBefore time.Time) (*models.User, error) {
+	filter := bson.M{
+		"is_premium": true,
+		"premium_expire_time": bson.M{
+			"$gt":  primitive.NewDateTimeFromTime(now),
+			"$lte": primitive.NewDateTimeFromTime(endingBefore),
+		},
+		"premium_ending_notified": bson.M{
+			"$ne": true,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"premium_ending_notified": true,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user models.User
+	err := r.usersC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to claim premium ending user",
+			},
+		}
+	}
+
+	return &user, nil
+}
+
+func (r *repoClient) releasePremiumEndingClaims(ctx context.Context, userIds []primitive.ObjectID) error {
+	filter := bson.M{
+		"_id": bson.M{
+			"$in": userIds,
+		},
+		"premium_ending_notified": true,
+	}
+	update := bson.M{
+		"$unset": bson.M{
+			"premium_ending_notified": "",
+		},
+	}
+
+	if _, err := r.usersC.UpdateMany(ctx, filter, update); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to release premium ending users",
+			},
+		}
+	}
+	return nil
+}
+
 func (r *repoClient) addTodoIdToUser(ctx context.Context, todoId, userId primitive.ObjectID) error {
 	filter := bson.M{
 		"_id": userId,
@@ -87,6 +324,231 @@ func (r *repoClient) addTodoIdToUser(ctx context.Context, todoId, userId primiti
 	return nil
 }
 
+func (r *repoClient) removeTodoIdFromUser(ctx context.Context, todoId, userId primitive.ObjectID) error {
+	filter := bson.M{
+		"_id": userId,
+	}
+	update := bson.M{
+		"$pull": bson.M{
+			"todos": todoId,
+		},
+	}
+	_, err := r.usersC.UpdateOne(
+		ctx,
+		filter,
+		update,
+	)
+	return err
+}
+
+// verifyEmail marks the email of the user as verified, it reports false when
+// the user doesn't have the email anymore.
+func (r *repoClient) verifyEmail(ctx context.Context, userId primitive.ObjectID, email string) (bool, error) {
+	filter := bson.M{
+		"_id":   userId,
+		"email": email,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"email_verified": true,
+		},
+	}
+	res, err := r.usersC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to verify email",
+			},
+		}
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// claimVerificationSend records that a verification email is sent now to an
+// unverified user, as long as the last one was sent before sentBefore. It
+// returns nil when the user doesn't qualify.
+func (r *repoClient) claimVerificationSend(
+	ctx context.Context, userId primitive.ObjectID, now, sentBefore time.Time,
+) (*models.User, error) {
+	filter := bson.M{
+		"_id":            userId,
+		"email_verified": bson.M{"$ne": true},
+		"$or": bson.A{
+			bson.M{"verification_sent_at": bson.M{"$exists": false}},
+			bson.M{"verification_sent_at": bson.M{"$lt": primitive.NewDateTimeFromTime(sentBefore)}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"verification_sent_at": primitive.NewDateTimeFromTime(now),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user models.User
+	err := r.usersC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update user",
+			},
+		}
+	}
+	return &user, nil
+}
+
+// setPasswordReset replaces the pending password reset of the user unless
+// the last one was sent after sentBefore, it reports whether it did.
+func (r *repoClient) setPasswordReset(
+	ctx context.Context, userId primitive.ObjectID, reset *models.PasswordReset, sentBefore time.Time,
+) (bool, error) {
+	filter := bson.M{
+		"_id": userId,
+		"$or": bson.A{
+			bson.M{"password_reset": bson.M{"$exists": false}},
+			bson.M{"password_reset.sent_at": bson.M{"$lt": primitive.NewDateTimeFromTime(sentBefore)}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"password_reset": reset,
+		},
+	}
+	res, err := r.usersC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to request password reset",
+			},
+		}
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// resetPassword sets the password of the user with an unexpired reset of the
+// token hash and removes the reset, so the token can't be used again. It
+// returns nil when no user has such a reset.
+func (r *repoClient) resetPassword(
+	ctx context.Context, tokenHash string, password string, now time.Time,
+) (*models.User, error) {
+	filter := bson.M{
+		"password_reset.token_hash":  tokenHash,
+		"password_reset.expire_time": bson.M{"$gt": primitive.NewDateTimeFromTime(now)},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"password": password,
+		},
+		"$unset": bson.M{
+			"password_reset": "",
+		},
+	}
+
+	var user models.User
+	err := r.usersC.FindOneAndUpdate(ctx, filter, update).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to reset password",
+			},
+		}
+	}
+	return &user, nil
+}
+
+func (r *repoClient) updateUser(
+	ctx context.Context, userId primitive.ObjectID, update bson.M,
+) (*models.User, error) {
+	filter := bson.M{
+		"_id": userId,
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var user models.User
+	err := r.usersC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "user not found",
+				},
+			}
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, &utils.AlreadyExists{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "email is already registered",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update user",
+			},
+		}
+	}
+	return &user, nil
+}
+
+// updateUserWhere updates the user as long as it matches the precondition,
+// it reports whether it did.
+func (r *repoClient) updateUserWhere(
+	ctx context.Context, userId primitive.ObjectID, precondition, update bson.M,
+) (bool, error) {
+	filter := bson.M{}
+	for key, value := range precondition {
+		filter[key] = value
+	}
+	filter["_id"] = userId
+
+	res, err := r.usersC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update user",
+			},
+		}
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (r *repoClient) deleteUser(ctx context.Context, userId primitive.ObjectID) error {
+	filter := bson.M{
+		"_id": userId,
+	}
+	res, err := r.usersC.DeleteOne(ctx, filter)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete user",
+			},
+		}
+	}
+	if res.DeletedCount == 0 {
+		return &utils.DbNotFoundError{
+			GeneralError: &utils.GeneralError{
+				Msg: "user not found",
+			},
+		}
+	}
+	return nil
+}
+
 func (r *repoClient) startSession() (mongo.Session, error) {
 	return r.db.StartSession()
 }
@@ -2,84 +2,1148 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"html"
+	"net/url"
+	"strings"
 	"time"
 	"todo-grpc/models"
 	"todo-grpc/pb"
+	kafkaQueueProvider "todo-grpc/providers/kafka"
+	"todo-grpc/providers/mail"
 	"todo-grpc/service"
 	"todo-grpc/utils"
 )
 
+const (
+	emailVerificationTTL = 24 * time.Hour
+	// verificationResendInterval is how long users wait before another
+	// verification email is sent
+	verificationResendInterval = 5 * time.Minute
+	passwordResetTTL           = time.Hour
+	// passwordResetInterval is how long it takes before another reset email
+	// is sent to the same user
+	passwordResetInterval = 5 * time.Minute
+	// maxClaimedPremiumEndings is how many users are notified per run, the
+	// rest are left to the next one
+	maxClaimedPremiumEndings = 100
+)
+
 type serviceClient struct {
-	userRepo userRepo
-	logger   *utils.Logger
-	config   utils.EnvConfig
+	userRepo            userRepo
+	logger              *utils.Logger
+	config              utils.EnvConfig
+	kafkaProvider       kafkaQueueProvider.Provider
+	revocationService   service.RevocationService
+	refreshTokenService service.RefreshTokenService
+	emailClient         *mail.EmailClient
+	loginAttemptService service.LoginAttemptService
+	accessTokenService  service.AccessTokenService
 }
 
 func NewUserService(
 	db *mongo.Client,
 	logger *utils.Logger,
 	config utils.EnvConfig,
+	kafkaProvider kafkaQueueProvider.Provider,
+	revocationService service.RevocationService,
+	refreshTokenService service.RefreshTokenService,
+	emailClient *mail.EmailClient,
+	loginAttemptService service.LoginAttemptService,
+	accessTokenService service.AccessTokenService,
 ) service.UserService {
 	return &serviceClient{
-		userRepo: newRepoClient(db, logger),
-		logger:   logger,
-		config:   config,
+		userRepo:            newRepoClient(db, logger),
+		logger:              logger,
+		config:              config,
+		kafkaProvider:       kafkaProvider,
+		revocationService:   revocationService,
+		refreshTokenService: refreshTokenService,
+		emailClient:         emailClient,
+		loginAttemptService: loginAttemptService,
+		accessTokenService:  accessTokenService,
 	}
 }
 
+func (s *serviceClient) EnsureIndexes(ctx context.Context) error {
+	return s.userRepo.ensureIndexes(ctx)
+}
+
 func (s *serviceClient) Register(ctx context.Context, user *models.User) (*pb.RegisterResponse, error) {
 	var err error
+	now := time.Now()
 	user.ID = primitive.NewObjectID()
-	user.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+	user.CreatedAt = primitive.NewDateTimeFromTime(now)
+	user.EmailVerified = false
+	user.VerificationSentAt = primitive.NewDateTimeFromTime(now)
+	user.Email = utils.NormalizeEmail(user.Email)
 	user.Password, err = utils.HashPassword(user.Password)
 	if err != nil {
-		return nil, err
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create user",
+			},
+		}
 	}
 	userId, err := s.userRepo.insertUser(ctx, user)
 	if err != nil {
 		return nil, err
 	}
-	token, err := utils.GenerateToken(userId.Hex(), s.config)
+	// the account exists already, a failed email can be sent again by
+	// resending the verification
+	if err = s.sendVerificationEmail(user, now); err != nil {
+		s.logger.Error(err, "unable to send verification email")
+	}
+
+	tokens, err := s.issueTokens(ctx, userId, "")
 	if err != nil {
 		return nil, err
 	}
+	s.publishAnalyticsEvent(models.NewUserAnalyticsEvent(models.AnalyticsEventUserRegistered, userId))
 	return &pb.RegisterResponse{
-		Token:  token,
-		UserId: userId.Hex(),
+		Token:        tokens.AccessToken,
+		UserId:       userId.Hex(),
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpireTime),
 	}, nil
 }
 
-func (s *serviceClient) Login(ctx context.Context, user *models.User) (string, error) {
+func (s *serviceClient) Login(
+	ctx context.Context, user *models.User, clientIp string,
+) (*models.AuthTokens, error) {
+	// checked before the password, so guesses don't even cost a hash
+	if err := s.loginAttemptService.CheckLogin(ctx, user.Email, clientIp); err != nil {
+		return nil, err
+	}
+
 	dbUser, err := s.userRepo.fetchUserByEmail(ctx, user.Email)
 	if err != nil {
-		return "", err
+		if _, ok := err.(*utils.ReqInvalidArgumentError); !ok {
+			return nil, err
+		}
+		// takes as long as a wrong password
+		utils.CheckPassword(user.Password, "")
+		return nil, s.failLogin(ctx, nil, user.Email, clientIp)
 	}
 
 	if ok := utils.CheckPassword(user.Password, dbUser.Password); !ok {
-		customErr := &utils.ReqInvalidArgumentError{
+		return nil, s.failLogin(ctx, dbUser, user.Email, clientIp)
+	}
+
+	return s.passFirstFactor(ctx, dbUser)
+}
+
+func (s *serviceClient) LoginWithIdentity(
+	ctx context.Context, identity *models.OidcIdentity,
+) (*models.AuthTokens, error) {
+	dbUser, err := s.userRepo.fetchUserByIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return s.passFirstFactor(ctx, dbUser)
+	}
+	if _, ok := err.(*utils.DbNotFoundError); !ok {
+		return nil, err
+	}
+
+	// accounts are only linked by emails both sides verified, otherwise
+	// whoever registered an email first could take over the account
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "identity " + identity.Subject + " of " + identity.Issuer + " has no verified email",
+				Msg:     identity.Provider + " didn't share a verified email",
+			},
+		}
+	}
+	userIdentity := models.UserIdentity{
+		Issuer:   identity.Issuer,
+		Subject:  identity.Subject,
+		LinkTime: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	email := utils.NormalizeEmail(identity.Email)
+	dbUser, err = s.userRepo.fetchUserByEmail(ctx, email)
+	if err != nil {
+		if _, ok := err.(*utils.ReqInvalidArgumentError); !ok {
+			return nil, err
+		}
+		return s.registerIdentity(ctx, identity, email, userIdentity)
+	}
+
+	if !dbUser.EmailVerified {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "user " + dbUser.ID.Hex() + " has an unverified email",
+				Msg:     "an account with the email exists, verify its email to log in with " + identity.Provider,
+			},
+		}
+	}
+	precondition := bson.M{
+		"identities.issuer": bson.M{"$ne": identity.Issuer},
+	}
+	update := bson.M{
+		"$push": bson.M{
+			"identities": userIdentity,
+		},
+	}
+	linked, err := s.userRepo.updateUserWhere(ctx, dbUser.ID, precondition, update)
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "the account is linked to another " + identity.Provider + " account",
+			},
+		}
+	}
+	s.logger.Info("linked user %s to %s of %s", dbUser.ID.Hex(), identity.Subject, identity.Issuer)
+
+	return s.passFirstFactor(ctx, dbUser)
+}
+
+// registerIdentity creates a user without a password for an identity, its
+// email was verified by the provider.
+func (s *serviceClient) registerIdentity(
+	ctx context.Context, identity *models.OidcIdentity, email string, userIdentity models.UserIdentity,
+) (*models.AuthTokens, error) {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	user := &models.User{
+		ID:            primitive.NewObjectID(),
+		Name:          name,
+		Email:         email,
+		CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+		EmailVerified: true,
+		Identities:    []models.UserIdentity{userIdentity},
+	}
+	userId, err := s.userRepo.insertUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.publishAnalyticsEvent(models.NewUserAnalyticsEvent(models.AnalyticsEventUserRegistered, userId))
+
+	return s.completeLogin(ctx, user)
+}
+
+// passFirstFactor continues the login of a user who proved who they are.
+// Users with two factor authentication only get a challenge token, the
+// failures are only cleared with the second factor so that the first one
+// alone doesn't buy more guesses of codes.
+func (s *serviceClient) passFirstFactor(ctx context.Context, dbUser *models.User) (*models.AuthTokens, error) {
+	if err := checkUserEnabled(dbUser); err != nil {
+		return nil, err
+	}
+	if dbUser.TwoFactor != nil && dbUser.TwoFactor.Enabled {
+		challengeToken, err := utils.GenerateTwoFactorChallengeToken(dbUser.ID, s.config)
+		if err != nil {
+			return nil, &utils.SystemInternalError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "unable to create token",
+				},
+			}
+		}
+		return &models.AuthTokens{ChallengeToken: challengeToken}, nil
+	}
+
+	return s.completeLogin(ctx, dbUser)
+}
+
+func (s *serviceClient) Verify2FA(
+	ctx context.Context, challengeToken, code, clientIp string,
+) (*models.AuthTokens, error) {
+	claims, err := utils.ValidateTwoFactorChallengeToken(challengeToken, s.config)
+	if err != nil {
+		return nil, &utils.UnAuthenticatedError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "invalid todo id",
+				Msg:     "invalid or expired challenge token, log in again",
+			},
+		}
+	}
+	userId, err := utils.ParseObjectId(claims.UserID, "user id")
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkUserEnabled(user); err != nil {
+		return nil, err
+	}
+
+	if err = s.verifySecondFactor(ctx, user, code, clientIp); err != nil {
+		return nil, err
+	}
+	return s.completeLogin(ctx, user)
+}
+
+// checkUserEnabled only lets users log in who weren't disabled by an admin.
+// It is checked once the user proved who they are, so it doesn't tell
+// anybody else whether an account is disabled.
+func checkUserEnabled(user *models.User) error {
+	if user.Disabled {
+		return &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: "user " + user.ID.Hex() + " is disabled",
+				Msg:     "the account is disabled",
 			},
 		}
-		return "", customErr
 	}
+	return nil
+}
 
-	token, err := utils.GenerateToken(dbUser.ID.Hex(), s.config)
+// completeLogin hands out the tokens of a user who passed every factor
+func (s *serviceClient) completeLogin(ctx context.Context, user *models.User) (*models.AuthTokens, error) {
+	if err := s.loginAttemptService.RecordSuccess(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user.ID, "")
 	if err != nil {
-		customErr := &utils.SystemInternalError{
+		return nil, err
+	}
+	s.publishAnalyticsEvent(models.NewUserAnalyticsEvent(models.AnalyticsEventUserLoggedIn, user.ID))
+	return tokens, nil
+}
+
+// failLogin records a failed login and returns the error for it, unknown
+// emails and wrong passwords look the same.
+func (s *serviceClient) failLogin(ctx context.Context, dbUser *models.User, email, clientIp string) error {
+	if err := s.recordLoginFailure(ctx, dbUser, email, clientIp); err != nil {
+		return err
+	}
+
+	return &utils.UnAuthenticatedError{
+		GeneralError: &utils.GeneralError{
+			DevInfo: "login of " + email + " failed",
+			Msg:     "invalid email or password",
+		},
+	}
+}
+
+// recordLoginFailure counts a failed password or code, the owner of the
+// account is told when it gets locked out.
+func (s *serviceClient) recordLoginFailure(ctx context.Context, dbUser *models.User, email, clientIp string) error {
+	lockedOut, err := s.loginAttemptService.RecordFailure(ctx, email, clientIp)
+	if err != nil {
+		return err
+	}
+
+	if lockedOut && dbUser != nil {
+		go func() {
+			if err := s.sendLockoutEmail(dbUser); err != nil {
+				s.logger.Error(err, "unable to send lockout email")
+			}
+		}()
+	}
+	return nil
+}
+
+func (s *serviceClient) Logout(
+	ctx context.Context, claims *models.UserClaims, refreshToken string, everywhere bool,
+) error {
+	userId, err := utils.ParseObjectId(claims.UserID, "user id")
+	if err != nil {
+		return err
+	}
+
+	if everywhere {
+		return s.RevokeSessions(ctx, userId)
+	}
+
+	if refreshToken != "" {
+		if err = s.refreshTokenService.RevokeRefreshToken(ctx, userId, refreshToken); err != nil {
+			return err
+		}
+	}
+	return s.revocationService.RevokeToken(ctx, claims)
+}
+
+func (s *serviceClient) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	nextRefreshToken, userId, err := s.refreshTokenService.RotateRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, userId, nextRefreshToken)
+}
+
+// issueTokens creates an access token of the current token generation of the
+// user. It comes with the given refresh token, or one of a new family when
+// there is none.
+func (s *serviceClient) issueTokens(
+	ctx context.Context, userId primitive.ObjectID, refreshToken string,
+) (*models.AuthTokens, error) {
+	generation, err := s.revocationService.FetchTokenGeneration(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &models.AuthTokens{
+		RefreshToken: refreshToken,
+	}
+	// only a new session means the user just authenticated
+	var authTime time.Time
+	if refreshToken == "" {
+		authTime = time.Now()
+	}
+	tokens.AccessToken, tokens.AccessExpireTime, err = utils.GenerateToken(
+		userId.Hex(), generation, authTime, s.config,
+	)
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create token",
+			},
+		}
+	}
+
+	if tokens.RefreshToken == "" {
+		tokens.RefreshToken, err = s.refreshTokenService.IssueRefreshToken(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+func (s *serviceClient) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := utils.ValidateEmailVerificationToken(token, s.config)
+	if err != nil {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid or expired verification token",
+			},
+		}
+	}
+	userId, err := utils.ParseObjectId(claims.UserID, "user id")
+	if err != nil {
+		return err
+	}
+
+	verified, err := s.userRepo.verifyEmail(ctx, userId, claims.Email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "the verification token is for another email address",
+			},
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) ResendVerification(ctx context.Context, userId primitive.ObjectID) error {
+	now := time.Now()
+	user, err := s.userRepo.claimVerificationSend(ctx, userId, now, now.Add(-verificationResendInterval))
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		user, err = s.userRepo.fetchUserById(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if user.EmailVerified {
+			return &utils.FailedPreconditionError{
+				GeneralError: &utils.GeneralError{
+					Msg: "email is already verified",
+				},
+			}
+		}
+		return &utils.ResourceExhaustedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "a verification email was sent recently, try again later",
+			},
+		}
+	}
+
+	if err = s.sendVerificationEmail(user, now); err != nil {
+		return &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to send verification email",
+			},
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) CheckEmailVerified(ctx context.Context, userId primitive.ObjectID) error {
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if !user.EmailVerified {
+		return &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "verify your email address first",
+			},
+		}
+	}
+	return nil
+}
+
+func (s *serviceClient) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.fetchUserByEmail(ctx, email)
+	if err != nil {
+		// unknown emails succeed as well, not to tell which accounts exist
+		if _, ok := err.(*utils.ReqInvalidArgumentError); ok {
+			return nil
+		}
+		return err
+	}
+
+	token, err := utils.GenerateSecretToken()
+	if err != nil {
+		return &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create password reset",
+			},
+		}
+	}
+	now := time.Now()
+	reset := &models.PasswordReset{
+		TokenHash:  utils.HashSecretToken(token),
+		SentAt:     primitive.NewDateTimeFromTime(now),
+		ExpireTime: primitive.NewDateTimeFromTime(now.Add(passwordResetTTL)),
+	}
+	set, err := s.userRepo.setPasswordReset(ctx, user.ID, reset, now.Add(-passwordResetInterval))
+	if err != nil || !set {
+		return err
+	}
+
+	// sent in the background so the response time doesn't tell whether the
+	// account exists either
+	go func() {
+		err := s.emailClient.SendEmail(
+			user.Email,
+			fmt.Sprintf(
+				`<p>Hi %s, a password reset was requested for your account.</p>`+
+					`<p><a href="%s/reset-password?token=%s">Choose a new password</a></p>`+
+					`<p>The link expires on %s. Ignore this email if you didn't request it.</p>`,
+				html.EscapeString(user.Name),
+				s.config.GetAppBaseUrl(),
+				url.QueryEscape(token),
+				reset.ExpireTime.Time().Format(time.RFC1123),
+			),
+			"Reset your password",
+		)
+		if err != nil {
+			s.logger.Error(err, "unable to send password reset email")
+		}
+	}()
+	return nil
+}
+
+func (s *serviceClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	password, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return &utils.SystemInternalError{
 			GeneralError: &utils.GeneralError{
 				DevInfo: err.Error(),
-				Msg:     "invalid todo id",
+				Msg:     "unable to reset password",
+			},
+		}
+	}
+
+	user, err := s.userRepo.resetPassword(ctx, utils.HashSecretToken(token), password, time.Now())
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invalid or expired password reset token",
 			},
 		}
-		return "", customErr
 	}
-	return token, nil
+
+	// whoever knew the old password is logged out everywhere
+	return s.RevokeSessions(ctx, user.ID)
+}
+
+func (s *serviceClient) UpdateProfile(
+	ctx context.Context, userId primitive.ObjectID, user *models.User, fieldMasks []string,
+	currentPassword string, authTime time.Time,
+) (*models.User, error) {
+	current, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	set := bson.M{}
+	unset := bson.M{}
+	for _, field := range fieldMasks {
+		switch field {
+		case "name":
+			set["name"] = user.Name
+		case "email":
+			if user.Email == current.Email {
+				continue
+			}
+			// password resets go to the email, so changing it needs the same
+			// confirmation as changing the password
+			if current.Password == "" {
+				if err = utils.CheckRecentAuth(authTime); err != nil {
+					return nil, err
+				}
+			} else if !utils.CheckPassword(currentPassword, current.Password) {
+				return nil, &utils.ReqInvalidArgumentError{
+					GeneralError: &utils.GeneralError{
+						Msg: "current password is incorrect",
+					},
+				}
+			}
+			// the new email is unverified and resets sent to the old one
+			// can't be used anymore
+			set["email"] = user.Email
+			set["email_verified"] = false
+			set["verification_sent_at"] = primitive.NewDateTimeFromTime(now)
+			unset["password_reset"] = ""
+		}
+	}
+	if len(set) == 0 {
+		return current, nil
+	}
+
+	update := bson.M{
+		"$set": set,
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	updated, err := s.userRepo.updateUser(ctx, userId, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Email != current.Email {
+		if err = s.sendVerificationEmail(updated, now); err != nil {
+			s.logger.Error(err, "unable to send verification email")
+		}
+		if err = s.sendEmailChangedEmail(current, updated.Email); err != nil {
+			s.logger.Error(err, "unable to send email changed email")
+		}
+	}
+	return updated, nil
+}
+
+func (s *serviceClient) ChangePassword(
+	ctx context.Context, userId primitive.ObjectID, currentPassword, newPassword string, authTime time.Time,
+) (*models.AuthTokens, error) {
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	// users who only log in through a provider set their first password
+	if user.Password == "" {
+		if err = utils.CheckRecentAuth(authTime); err != nil {
+			return nil, err
+		}
+	} else if !utils.CheckPassword(currentPassword, user.Password) {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "current password is incorrect",
+			},
+		}
+	}
+
+	password, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to change password",
+			},
+		}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"password": password,
+		},
+		"$unset": bson.M{
+			"password_reset": "",
+		},
+	}
+	if _, err = s.userRepo.updateUser(ctx, userId, update); err != nil {
+		return nil, err
+	}
+
+	if err = s.RevokeSessions(ctx, userId); err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, userId, "")
+}
+
+func (s *serviceClient) Enable2FA(ctx context.Context, userId primitive.ObjectID) (*models.TwoFactorSetup, error) {
+	key, err := s.twoFactorKey()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateTotpSecret()
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create two factor secret",
+			},
+		}
+	}
+	encryptedSecret, err := utils.EncryptSecret(secret, key)
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create two factor secret",
+			},
+		}
+	}
+
+	// a pending setup is replaced, an enabled one has to be disabled first
+	precondition := bson.M{
+		"two_factor.enabled": bson.M{"$ne": true},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"two_factor": &models.TwoFactor{
+				EncryptedSecret: encryptedSecret,
+				CreateTime:      primitive.NewDateTimeFromTime(time.Now()),
+			},
+		},
+	}
+	updated, err := s.userRepo.updateUserWhere(ctx, userId, precondition, update)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "two factor authentication is already enabled",
+			},
+		}
+	}
+
+	return &models.TwoFactorSetup{
+		Secret:     secret,
+		OtpAuthUri: utils.TotpUri(secret, user.Email),
+	}, nil
+}
+
+func (s *serviceClient) Confirm2FA(ctx context.Context, userId primitive.ObjectID, code string) ([]string, error) {
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor == nil || user.TwoFactor.Enabled {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "there is no two factor setup to confirm",
+			},
+		}
+	}
+
+	secret, err := s.decryptTotpSecret(user.TwoFactor)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := utils.ValidateTotpCode(secret, utils.NormalizeTwoFactorCode(code), time.Now())
+	if !ok {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invalid two factor code",
+			},
+		}
+	}
+
+	backupCodes, err := utils.GenerateBackupCodes()
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create backup codes",
+			},
+		}
+	}
+	backupCodeHashes := make([]string, 0, len(backupCodes))
+	for _, backupCode := range backupCodes {
+		backupCodeHashes = append(backupCodeHashes, utils.HashSecretToken(utils.NormalizeTwoFactorCode(backupCode)))
+	}
+
+	// the setup that was confirmed mustn't have been replaced meanwhile
+	precondition := bson.M{
+		"two_factor.enabled":          false,
+		"two_factor.encrypted_secret": user.TwoFactor.EncryptedSecret,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"two_factor.enabled":            true,
+			"two_factor.confirm_time":       primitive.NewDateTimeFromTime(time.Now()),
+			"two_factor.backup_code_hashes": backupCodeHashes,
+			"two_factor.last_used_step":     step,
+		},
+	}
+	updated, err := s.userRepo.updateUserWhere(ctx, userId, precondition, update)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, &utils.AbortedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "two factor setup changed meanwhile, try again",
+			},
+		}
+	}
+
+	return backupCodes, nil
+}
+
+func (s *serviceClient) Disable2FA(ctx context.Context, userId primitive.ObjectID, code, clientIp string) error {
+	user, err := s.userRepo.fetchUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactor == nil || !user.TwoFactor.Enabled {
+		return &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				Msg: "two factor authentication isn't enabled",
+			},
+		}
+	}
+
+	if err = s.verifySecondFactor(ctx, user, code, clientIp); err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$unset": bson.M{
+			"two_factor": "",
+		},
+	}
+	_, err = s.userRepo.updateUser(ctx, userId, update)
+	return err
+}
+
+// verifySecondFactor checks a TOTP or backup code of the user. Wrong codes
+// count as failed logins, so guessing them backs off like guessing
+// passwords.
+func (s *serviceClient) verifySecondFactor(ctx context.Context, user *models.User, code, clientIp string) error {
+	if user.TwoFactor == nil || !user.TwoFactor.Enabled {
+		return &utils.UnAuthenticatedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "two factor authentication isn't enabled, log in again",
+			},
+		}
+	}
+	if err := s.loginAttemptService.CheckLogin(ctx, user.Email, clientIp); err != nil {
+		return err
+	}
+
+	used, err := s.useSecondFactor(ctx, user, utils.NormalizeTwoFactorCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		if err = s.recordLoginFailure(ctx, user, user.Email, clientIp); err != nil {
+			return err
+		}
+		return &utils.UnAuthenticatedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invalid two factor code",
+			},
+		}
+	}
+	return nil
+}
+
+// useSecondFactor consumes a valid code: TOTP codes can't be used again and
+// backup codes are removed.
+func (s *serviceClient) useSecondFactor(ctx context.Context, user *models.User, code string) (bool, error) {
+	if !utils.IsTotpCode(code) {
+		codeHash := utils.HashSecretToken(code)
+		precondition := bson.M{
+			"two_factor.enabled":            true,
+			"two_factor.backup_code_hashes": codeHash,
+		}
+		update := bson.M{
+			"$pull": bson.M{
+				"two_factor.backup_code_hashes": codeHash,
+			},
+		}
+		return s.userRepo.updateUserWhere(ctx, user.ID, precondition, update)
+	}
+
+	secret, err := s.decryptTotpSecret(user.TwoFactor)
+	if err != nil {
+		return false, err
+	}
+	step, ok := utils.ValidateTotpCode(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	precondition := bson.M{
+		"two_factor.enabled":        true,
+		"two_factor.last_used_step": bson.M{"$lt": step},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"two_factor.last_used_step": step,
+		},
+	}
+	return s.userRepo.updateUserWhere(ctx, user.ID, precondition, update)
+}
+
+func (s *serviceClient) twoFactorKey() ([]byte, error) {
+	key, err := utils.ParseEncryptionKey(s.config.GetTwoFactorEncryptionKey())
+	if err != nil {
+		return nil, &utils.FailedPreconditionError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "two factor authentication isn't available",
+			},
+		}
+	}
+	return key, nil
+}
+
+func (s *serviceClient) decryptTotpSecret(twoFactor *models.TwoFactor) (string, error) {
+	key, err := s.twoFactorKey()
+	if err != nil {
+		return "", err
+	}
+	secret, err := utils.DecryptSecret(twoFactor.EncryptedSecret, key)
+	if err != nil {
+		return "", &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to read two factor secret",
+			},
+		}
+	}
+	return secret, nil
+}
+
+func (s *serviceClient) ListUsers(
+	ctx context.Context, filter *models.ListUsersFilter,
+) (*models.ListUsersRes, error) {
+	var usersRes models.ListUsersRes
+	erg, _ := errgroup.WithContext(ctx)
+	erg.Go(
+		func() error {
+			var usersErr error
+			usersRes.Users, usersErr = s.userRepo.fetchUsers(ctx, filter)
+			return usersErr
+		},
+	)
+	erg.Go(
+		func() error {
+			var countErr error
+			usersRes.Count, countErr = s.userRepo.countUsers(ctx, filter)
+			return countErr
+		},
+	)
+	if err := erg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &usersRes, nil
+}
+
+func (s *serviceClient) SetUserDisabled(
+	ctx context.Context, userId primitive.ObjectID, disabled bool,
+) (*models.User, error) {
+	if _, err := s.userRepo.fetchUserById(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	if !disabled {
+		update := bson.M{
+			"$unset": bson.M{
+				"disabled":     "",
+				"disable_time": "",
+			},
+		}
+		user, err := s.userRepo.updateUser(ctx, userId, update)
+		if err != nil {
+			return nil, err
+		}
+		return user, s.revocationService.SetUserDisabled(ctx, userId, false)
+	}
+
+	// the tokens are rejected first, so the user can't act anymore while
+	// the rest is updated
+	if err := s.revocationService.SetUserDisabled(ctx, userId, true); err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"disabled":     true,
+			"disable_time": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+	user, err := s.userRepo.updateUser(ctx, userId, update)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.RevokeSessions(ctx, userId); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *serviceClient) SetUserPremium(
+	ctx context.Context, userId primitive.ObjectID, isPremium bool, expireTime time.Time,
+) (*models.User, error) {
+	set := bson.M{
+		"is_premium": isPremium,
+	}
+	// a new expire time is notified again
+	unset := bson.M{
+		"premium_ending_notified": "",
+	}
+	if isPremium && !expireTime.IsZero() {
+		set["premium_expire_time"] = primitive.NewDateTimeFromTime(expireTime)
+	} else {
+		unset["premium_expire_time"] = ""
+	}
+	update := bson.M{
+		"$set":   set,
+		"$unset": unset,
+	}
+	return s.userRepo.updateUser(ctx, userId, update)
+}
+
+func (s *serviceClient) ClaimPremiumEndingUsers(ctx context.Context, notice time.Duration) ([]models.User, error) {
+	now := time.Now()
+	users := make([]models.User, 0)
+	for len(users) < maxClaimedPremiumEndings {
+		user, err := s.userRepo.claimPremiumEnding(ctx, now, now.Add(notice))
+		if err != nil {
+			return users, err
+		}
+		if user == nil {
+			break
+		}
+		users = append(users, *user)
+	}
+
+	return users, nil
+}
+
+func (s *serviceClient) ReleasePremiumEndingClaims(ctx context.Context, userIds []primitive.ObjectID) error {
+	return s.userRepo.releasePremiumEndingClaims(ctx, userIds)
+}
+
+func (s *serviceClient) RevokeSessions(ctx context.Context, userId primitive.ObjectID) error {
+	if _, err := s.revocationService.RevokeAllTokens(ctx, userId); err != nil {
+		return err
+	}
+	if err := s.refreshTokenService.RevokeUserRefreshTokens(ctx, userId); err != nil {
+		return err
+	}
+	return s.accessTokenService.DeleteUserAccessTokens(ctx, userId)
+}
+
+func (s *serviceClient) DeleteUser(ctx context.Context, userId primitive.ObjectID) error {
+	return s.userRepo.deleteUser(ctx, userId)
+}
+
+func (s *serviceClient) sendLockoutEmail(user *models.User) error {
+	return s.emailClient.SendEmail(
+		user.Email,
+		fmt.Sprintf(
+			`<p>Hi %s, logging in to your account was blocked for %s after too many failed attempts.</p>`+
+				`<p>If this wasn't you, somebody may be guessing your password. `+
+				`<a href="%s/reset-password">Reset your password</a> to be safe.</p>`,
+			html.EscapeString(user.Name),
+			s.config.GetLoginLockoutDuration(),
+			s.config.GetAppBaseUrl(),
+		),
+		"Your account was locked",
+	)
+}
+
+// sendEmailChangedEmail tells the previous address of the user that their
+// email changed, in case somebody else changed it.
+func (s *serviceClient) sendEmailChangedEmail(user *models.User, newEmail string) error {
+	return s.emailClient.SendEmail(
+		user.Email,
+		fmt.Sprintf(
+			`<p>Hi %s, the email of your account was changed to %s.</p>`+
+				`<p>If this wasn't you, contact us to get your account back.</p>`,
+			html.EscapeString(user.Name),
+			html.EscapeString(newEmail),
+		),
+		"Your email was changed",
+	)
+}
+
+func (s *serviceClient) sendVerificationEmail(user *models.User, now time.Time) error {
+	expireTime := now.Add(emailVerificationTTL)
+	token, err := utils.GenerateEmailVerificationToken(user.ID, user.Email, expireTime, s.config)
+	if err != nil {
+		return err
+	}
+
+	return s.emailClient.SendEmail(
+		user.Email,
+		fmt.Sprintf(
+			`<p>Hi %s, please confirm your email address.</p>`+
+				`<p><a href="%s/verify-email?token=%s">Verify your email</a></p>`+
+				`<p>The link expires on %s.</p>`,
+			html.EscapeString(user.Name),
+			s.config.GetAppBaseUrl(),
+			url.QueryEscape(token),
+			expireTime.Format(time.RFC1123),
+		),
+		"Verify your email address",
+	)
 }
 
 func (s *serviceClient) AddTodoIdToUser(ctx context.Context, userId, todoId primitive.ObjectID) error {
 	return s.userRepo.addTodoIdToUser(ctx, todoId, userId)
 }
+
+func (s *serviceClient) RemoveTodoIdFromUser(ctx context.Context, userId, todoId primitive.ObjectID) error {
+	return s.userRepo.removeTodoIdFromUser(ctx, todoId, userId)
+}
+
+func (s *serviceClient) FetchUser(ctx context.Context, userId primitive.ObjectID) (*models.User, error) {
+	return s.userRepo.fetchUserById(ctx, userId)
+}
+
+func (s *serviceClient) publishAnalyticsEvent(event *models.AnalyticsEvent) {
+	// nothing consumes the events without an analytics store
+	if s.config.GetClickHouseDsn() == "" {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error(err, "unable to marshal analytics event")
+		return
+	}
+	s.kafkaProvider.Publish(models.TopicAnalyticsEvents, data)
+}
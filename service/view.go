@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"todo-grpc/models"
+)
+
+type ViewService interface {
+	CreateView(ctx context.Context, scope *models.Scope, view *models.SavedView) (*models.SavedView, error)
+	ListViews(ctx context.Context, scope *models.Scope) ([]models.SavedView, error)
+	// FetchView resolves a view id, either of a stored view visible in the
+	// scope or of a system view
+	FetchView(ctx context.Context, scope *models.Scope, viewId string) (*models.SavedView, error)
+	UpdateView(ctx context.Context, scope *models.Scope, view *models.SavedView) (*models.SavedView, error)
+	DeleteView(ctx context.Context, scope *models.Scope, viewId primitive.ObjectID) error
+	// DeleteUserViews deletes the views of the user in every scope, shared
+	// ones included
+	DeleteUserViews(ctx context.Context, userId primitive.ObjectID) error
+}
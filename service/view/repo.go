@@ -0,0 +1,200 @@
+package view
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db     *mongo.Client
+	viewsC *mongo.Collection
+	logger *utils.Logger
+}
+
+type viewRepo interface {
+	insertView(ctx context.Context, view *models.SavedView) (primitive.ObjectID, error)
+	fetchView(ctx context.Context, scope *models.Scope, viewId primitive.ObjectID) (*models.SavedView, error)
+	fetchViews(ctx context.Context, scope *models.Scope) ([]models.SavedView, error)
+	updateView(ctx context.Context, scope *models.Scope, view *models.SavedView) (*models.SavedView, error)
+	deleteView(ctx context.Context, scope *models.Scope, viewId primitive.ObjectID) error
+	deleteViewsOfUser(ctx context.Context, userId primitive.ObjectID) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) viewRepo {
+	return &repoClient{
+		db:     db,
+		viewsC: utils.GetCollection(db, "saved_views"),
+		logger: logger,
+	}
+}
+
+// visibleFilter matches the views a user can see in the scope: their own
+// personal views outside of workspaces, and their own or shared views in a
+// workspace.
+func visibleFilter(scope *models.Scope) bson.M {
+	if !scope.IsWorkspace() {
+		return bson.M{
+			"user_id":      scope.UserID,
+			"workspace_id": bson.M{"$exists": false},
+		}
+	}
+	return bson.M{
+		"workspace_id": scope.WorkspaceID,
+		"$or": bson.A{
+			bson.M{"user_id": scope.UserID},
+			bson.M{"shared": true},
+		},
+	}
+}
+
+// ownedFilter matches the views of the user in the scope.
+func ownedFilter(scope *models.Scope) bson.M {
+	filter := bson.M{
+		"user_id": scope.UserID,
+	}
+	if scope.IsWorkspace() {
+		filter["workspace_id"] = scope.WorkspaceID
+	} else {
+		filter["workspace_id"] = bson.M{"$exists": false}
+	}
+	return filter
+}
+
+func (r *repoClient) insertView(ctx context.Context, view *models.SavedView) (primitive.ObjectID, error) {
+	insertedResp, err := r.viewsC.InsertOne(ctx, view)
+	if err != nil {
+		return primitive.NilObjectID, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create view",
+			},
+		}
+	}
+	return insertedResp.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *repoClient) fetchView(
+	ctx context.Context, scope *models.Scope, viewId primitive.ObjectID,
+) (*models.SavedView, error) {
+	filter := visibleFilter(scope)
+	filter["_id"] = viewId
+
+	var view models.SavedView
+	err := r.viewsC.FindOne(ctx, filter).Decode(&view)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "view not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch view",
+			},
+		}
+	}
+
+	return &view, nil
+}
+
+func (r *repoClient) fetchViews(ctx context.Context, scope *models.Scope) ([]models.SavedView, error) {
+	opns := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.viewsC.Find(ctx, visibleFilter(scope), opns)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]models.SavedView, 0)
+	if err = cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (r *repoClient) updateView(
+	ctx context.Context, scope *models.Scope, view *models.SavedView,
+) (*models.SavedView, error) {
+	filter := ownedFilter(scope)
+	filter["_id"] = view.ID
+	update := bson.M{
+		"$set": bson.M{
+			"name":        view.Name,
+			"query":       view.Query,
+			"shared":      view.Shared,
+			"update_time": view.UpdateTime,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.SavedView
+	err := r.viewsC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "view not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update view",
+			},
+		}
+	}
+
+	return &updated, nil
+}
+
+func (r *repoClient) deleteView(ctx context.Context, scope *models.Scope, viewId primitive.ObjectID) error {
+	filter := ownedFilter(scope)
+	filter["_id"] = viewId
+
+	res, err := r.viewsC.DeleteOne(ctx, filter)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete view",
+			},
+		}
+	}
+	if res.DeletedCount == 0 {
+		return &utils.DbNotFoundError{
+			GeneralError: &utils.GeneralError{
+				Msg: "view not found",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) deleteViewsOfUser(ctx context.Context, userId primitive.ObjectID) error {
+	filter := bson.M{
+		"user_id": userId,
+	}
+	if _, err := r.viewsC.DeleteMany(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete views",
+			},
+		}
+	}
+	return nil
+}
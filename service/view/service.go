@@ -0,0 +1,112 @@
+package view
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+type serviceClient struct {
+	viewRepo    viewRepo
+	logger      *utils.Logger
+	userService service.UserService
+}
+
+func NewViewService(db *mongo.Client, logger *utils.Logger, userService service.UserService) service.ViewService {
+	return &serviceClient{
+		viewRepo:    newRepoClient(db, logger),
+		logger:      logger,
+		userService: userService,
+	}
+}
+
+func (s *serviceClient) CreateView(
+	ctx context.Context, scope *models.Scope, view *models.SavedView,
+) (*models.SavedView, error) {
+	if err := s.checkCanShare(ctx, scope, view); err != nil {
+		return nil, err
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	view.ID = primitive.NewObjectID()
+	view.UserID = scope.UserID
+	view.WorkspaceID = scope.WorkspaceID
+	view.CreateTime = now
+	view.UpdateTime = now
+	if _, err := s.viewRepo.insertView(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *serviceClient) ListViews(ctx context.Context, scope *models.Scope) ([]models.SavedView, error) {
+	views, err := s.viewRepo.fetchViews(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]models.SavedView{}, models.SystemViews...), views...), nil
+}
+
+func (s *serviceClient) FetchView(
+	ctx context.Context, scope *models.Scope, viewId string,
+) (*models.SavedView, error) {
+	if strings.HasPrefix(viewId, models.SystemViewPrefix) {
+		view, ok := models.FindSystemView(viewId)
+		if !ok {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					Msg: "view not found",
+				},
+			}
+		}
+		return view, nil
+	}
+
+	id, err := utils.ParseObjectId(viewId, "view id")
+	if err != nil {
+		return nil, err
+	}
+	return s.viewRepo.fetchView(ctx, scope, id)
+}
+
+func (s *serviceClient) UpdateView(
+	ctx context.Context, scope *models.Scope, view *models.SavedView,
+) (*models.SavedView, error) {
+	if err := s.checkCanShare(ctx, scope, view); err != nil {
+		return nil, err
+	}
+
+	view.UpdateTime = primitive.NewDateTimeFromTime(time.Now())
+	return s.viewRepo.updateView(ctx, scope, view)
+}
+
+func (s *serviceClient) DeleteView(ctx context.Context, scope *models.Scope, viewId primitive.ObjectID) error {
+	return s.viewRepo.deleteView(ctx, scope, viewId)
+}
+
+func (s *serviceClient) DeleteUserViews(ctx context.Context, userId primitive.ObjectID) error {
+	return s.viewRepo.deleteViewsOfUser(ctx, userId)
+}
+
+// checkCanShare rejects sharing personal views, they have nobody to be
+// shared with, and sharing by users who didn't verify their email.
+func (s *serviceClient) checkCanShare(ctx context.Context, scope *models.Scope, view *models.SavedView) error {
+	if !view.Shared {
+		return nil
+	}
+	if !scope.IsWorkspace() {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "only views of a workspace can be shared",
+			},
+		}
+	}
+	return s.userService.CheckEmailVerified(ctx, scope.UserID)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"todo-grpc/models"
+)
+
+type WorkspaceService interface {
+	CreateWorkspace(ctx context.Context, workspace *models.Workspace) (*models.Workspace, error)
+	FetchWorkspace(ctx context.Context, workspaceId, userId primitive.ObjectID) (*models.Workspace, error)
+	ListWorkspaces(ctx context.Context, userId primitive.ObjectID) ([]models.Workspace, error)
+	FetchMember(ctx context.Context, workspaceId, userId primitive.ObjectID) (*models.WorkspaceMember, error)
+	ListMembers(ctx context.Context, workspaceId, userId primitive.ObjectID) ([]models.WorkspaceMember, error)
+	InviteMember(ctx context.Context, invitation *models.WorkspaceInvitation) (*models.WorkspaceInvitation, error)
+	AcceptInvitation(ctx context.Context, userId primitive.ObjectID, token string) (*models.WorkspaceMember, error)
+	UpdateMemberRole(
+		ctx context.Context, workspaceId, actorId, userId primitive.ObjectID, role models.WorkspaceRole,
+	) (*models.WorkspaceMember, error)
+	RemoveMember(ctx context.Context, workspaceId, actorId, userId primitive.ObjectID) error
+	// LeaveAllWorkspaces removes the user from their workspaces. Workspaces
+	// they own are deleted when they are the only member, their ids are
+	// returned.
+	LeaveAllWorkspaces(ctx context.Context, userId primitive.ObjectID) ([]primitive.ObjectID, error)
+}
@@ -0,0 +1,357 @@
+package workspace
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"todo-grpc/models"
+	"todo-grpc/utils"
+)
+
+type repoClient struct {
+	db           *mongo.Client
+	workspacesC  *mongo.Collection
+	membersC     *mongo.Collection
+	invitationsC *mongo.Collection
+	logger       *utils.Logger
+}
+
+type workspaceRepo interface {
+	startSession() (mongo.Session, error)
+	insertWorkspace(ctx context.Context, workspace *models.Workspace) (primitive.ObjectID, error)
+	fetchWorkspace(ctx context.Context, workspaceId primitive.ObjectID) (*models.Workspace, error)
+	fetchWorkspaces(ctx context.Context, workspaceIds []primitive.ObjectID) ([]models.Workspace, error)
+	insertMember(ctx context.Context, member *models.WorkspaceMember) (primitive.ObjectID, error)
+	fetchMember(ctx context.Context, workspaceId, userId primitive.ObjectID) (*models.WorkspaceMember, error)
+	fetchMembers(ctx context.Context, workspaceId primitive.ObjectID) ([]models.WorkspaceMember, error)
+	fetchMembershipsOfUser(ctx context.Context, userId primitive.ObjectID) ([]models.WorkspaceMember, error)
+	updateMemberRole(
+		ctx context.Context, workspaceId, userId primitive.ObjectID, role models.WorkspaceRole,
+	) (*models.WorkspaceMember, error)
+	deleteMember(ctx context.Context, workspaceId, userId primitive.ObjectID) error
+	deleteWorkspace(ctx context.Context, workspaceId primitive.ObjectID) error
+	insertInvitation(ctx context.Context, invitation *models.WorkspaceInvitation) (primitive.ObjectID, error)
+	fetchInvitation(ctx context.Context, invitationId primitive.ObjectID) (*models.WorkspaceInvitation, error)
+	updateInvitationStatus(
+		ctx context.Context, invitationId primitive.ObjectID, from, to models.InvitationStatus,
+	) error
+}
+
+func newRepoClient(
+	db *mongo.Client, logger *utils.Logger,
+) workspaceRepo {
+	return &repoClient{
+		db:           db,
+		workspacesC:  utils.GetCollection(db, "workspaces"),
+		membersC:     utils.GetCollection(db, "workspace_members"),
+		invitationsC: utils.GetCollection(db, "workspace_invitations"),
+		logger:       logger,
+	}
+}
+
+func (r *repoClient) startSession() (mongo.Session, error) {
+	return r.db.StartSession()
+}
+
+func (r *repoClient) insertWorkspace(ctx context.Context, workspace *models.Workspace) (primitive.ObjectID, error) {
+	insertedResp, err := r.workspacesC.InsertOne(ctx, workspace)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return insertedResp.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *repoClient) fetchWorkspace(ctx context.Context, workspaceId primitive.ObjectID) (*models.Workspace, error) {
+	filter := bson.M{
+		"_id": workspaceId,
+	}
+
+	var workspace models.Workspace
+	err := r.workspacesC.FindOne(ctx, filter).Decode(&workspace)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "workspace not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch workspace",
+			},
+		}
+	}
+
+	return &workspace, nil
+}
+
+func (r *repoClient) fetchWorkspaces(
+	ctx context.Context, workspaceIds []primitive.ObjectID,
+) ([]models.Workspace, error) {
+	filter := bson.M{
+		"_id": bson.M{
+			"$in": workspaceIds,
+		},
+	}
+	opns := options.Find().SetSort(bson.M{"create_time": -1})
+
+	cursor, err := r.workspacesC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]models.Workspace, 0)
+	if err = cursor.All(ctx, &workspaces); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+func (r *repoClient) insertMember(ctx context.Context, member *models.WorkspaceMember) (primitive.ObjectID, error) {
+	insertedResp, err := r.membersC.InsertOne(ctx, member)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return insertedResp.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *repoClient) fetchMember(
+	ctx context.Context, workspaceId, userId primitive.ObjectID,
+) (*models.WorkspaceMember, error) {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+		"user_id":      userId,
+	}
+
+	var member models.WorkspaceMember
+	err := r.membersC.FindOne(ctx, filter).Decode(&member)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.ResourcePermissionDeniedError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "not a member of the workspace",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch workspace member",
+			},
+		}
+	}
+
+	return &member, nil
+}
+
+func (r *repoClient) fetchMembers(
+	ctx context.Context, workspaceId primitive.ObjectID,
+) ([]models.WorkspaceMember, error) {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+	}
+	opns := options.Find().SetSort(bson.M{"join_time": 1})
+
+	cursor, err := r.membersC.Find(ctx, filter, opns)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]models.WorkspaceMember, 0)
+	if err = cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *repoClient) fetchMembershipsOfUser(
+	ctx context.Context, userId primitive.ObjectID,
+) ([]models.WorkspaceMember, error) {
+	filter := bson.M{
+		"user_id": userId,
+	}
+
+	cursor, err := r.membersC.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]models.WorkspaceMember, 0)
+	if err = cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *repoClient) updateMemberRole(
+	ctx context.Context, workspaceId, userId primitive.ObjectID, role models.WorkspaceRole,
+) (*models.WorkspaceMember, error) {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+		"user_id":      userId,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"role": role,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var member models.WorkspaceMember
+	err := r.membersC.FindOneAndUpdate(ctx, filter, update, opts).Decode(&member)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "member not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update member",
+			},
+		}
+	}
+
+	return &member, nil
+}
+
+func (r *repoClient) deleteMember(ctx context.Context, workspaceId, userId primitive.ObjectID) error {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+		"user_id":      userId,
+	}
+
+	res, err := r.membersC.DeleteOne(ctx, filter)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to remove member",
+			},
+		}
+	}
+	if res.DeletedCount == 0 {
+		return &utils.DbNotFoundError{
+			GeneralError: &utils.GeneralError{
+				Msg: "member not found",
+			},
+		}
+	}
+
+	return nil
+}
+
+func (r *repoClient) insertInvitation(
+	ctx context.Context, invitation *models.WorkspaceInvitation,
+) (primitive.ObjectID, error) {
+	insertedResp, err := r.invitationsC.InsertOne(ctx, invitation)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return insertedResp.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *repoClient) fetchInvitation(
+	ctx context.Context, invitationId primitive.ObjectID,
+) (*models.WorkspaceInvitation, error) {
+	filter := bson.M{
+		"_id": invitationId,
+	}
+
+	var invitation models.WorkspaceInvitation
+	err := r.invitationsC.FindOne(ctx, filter).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &utils.DbNotFoundError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invitation not found",
+				},
+			}
+		}
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to fetch invitation",
+			},
+		}
+	}
+
+	return &invitation, nil
+}
+
+func (r *repoClient) updateInvitationStatus(
+	ctx context.Context, invitationId primitive.ObjectID, from, to models.InvitationStatus,
+) error {
+	filter := bson.M{
+		"_id":    invitationId,
+		"status": from,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status": to,
+		},
+	}
+
+	res, err := r.invitationsC.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to update invitation",
+			},
+		}
+	}
+	if res.ModifiedCount == 0 {
+		return &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invitation is no longer " + string(from),
+			},
+		}
+	}
+
+	return nil
+}
+
+// deleteWorkspace deletes the workspace with its members and invitations.
+func (r *repoClient) deleteWorkspace(ctx context.Context, workspaceId primitive.ObjectID) error {
+	filter := bson.M{
+		"workspace_id": workspaceId,
+	}
+	if _, err := r.membersC.DeleteMany(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete workspace members",
+			},
+		}
+	}
+	if _, err := r.invitationsC.DeleteMany(ctx, filter); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete workspace invitations",
+			},
+		}
+	}
+
+	if _, err := r.workspacesC.DeleteOne(ctx, bson.M{"_id": workspaceId}); err != nil {
+		return &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to delete workspace",
+			},
+		}
+	}
+	return nil
+}
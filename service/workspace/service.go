@@ -0,0 +1,395 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"html"
+	"net/url"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/providers/mail"
+	"todo-grpc/service"
+	"todo-grpc/utils"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+type serviceClient struct {
+	workspaceRepo workspaceRepo
+	logger        *utils.Logger
+	config        utils.EnvConfig
+	userService   service.UserService
+	emailClient   *mail.EmailClient
+}
+
+func NewWorkspaceService(
+	db *mongo.Client,
+	logger *utils.Logger,
+	config utils.EnvConfig,
+	userService service.UserService,
+	emailClient *mail.EmailClient,
+) service.WorkspaceService {
+	return &serviceClient{
+		workspaceRepo: newRepoClient(db, logger),
+		logger:        logger,
+		config:        config,
+		userService:   userService,
+		emailClient:   emailClient,
+	}
+}
+
+func (s *serviceClient) CreateWorkspace(
+	ctx context.Context, workspace *models.Workspace,
+) (*models.Workspace, error) {
+	session, err := s.workspaceRepo.startSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	wc := writeconcern.New(writeconcern.WMajority())
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		now := primitive.NewDateTimeFromTime(time.Now())
+		workspace.ID = primitive.NewObjectID()
+		workspace.CreateTime = now
+		if _, err := s.workspaceRepo.insertWorkspace(ctx, workspace); err != nil {
+			return nil, err
+		}
+
+		owner := &models.WorkspaceMember{
+			ID:          primitive.NewObjectID(),
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        models.WorkspaceRoleOwner,
+			JoinTime:    now,
+		}
+		if _, err := s.workspaceRepo.insertMember(ctx, owner); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	_, err = session.WithTransaction(ctx, callback, txnOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+func (s *serviceClient) FetchWorkspace(
+	ctx context.Context, workspaceId, userId primitive.ObjectID,
+) (*models.Workspace, error) {
+	if _, err := s.workspaceRepo.fetchMember(ctx, workspaceId, userId); err != nil {
+		return nil, err
+	}
+
+	return s.workspaceRepo.fetchWorkspace(ctx, workspaceId)
+}
+
+func (s *serviceClient) ListWorkspaces(ctx context.Context, userId primitive.ObjectID) ([]models.Workspace, error) {
+	memberships, err := s.workspaceRepo.fetchMembershipsOfUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return []models.Workspace{}, nil
+	}
+
+	workspaceIds := make([]primitive.ObjectID, 0, len(memberships))
+	for _, membership := range memberships {
+		workspaceIds = append(workspaceIds, membership.WorkspaceID)
+	}
+
+	return s.workspaceRepo.fetchWorkspaces(ctx, workspaceIds)
+}
+
+func (s *serviceClient) FetchMember(
+	ctx context.Context, workspaceId, userId primitive.ObjectID,
+) (*models.WorkspaceMember, error) {
+	return s.workspaceRepo.fetchMember(ctx, workspaceId, userId)
+}
+
+func (s *serviceClient) ListMembers(
+	ctx context.Context, workspaceId, userId primitive.ObjectID,
+) ([]models.WorkspaceMember, error) {
+	if _, err := s.workspaceRepo.fetchMember(ctx, workspaceId, userId); err != nil {
+		return nil, err
+	}
+
+	return s.workspaceRepo.fetchMembers(ctx, workspaceId)
+}
+
+func (s *serviceClient) InviteMember(
+	ctx context.Context, invitation *models.WorkspaceInvitation,
+) (*models.WorkspaceInvitation, error) {
+	inviter, err := s.workspaceRepo.fetchMember(ctx, invitation.WorkspaceID, invitation.InvitedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCanManage(inviter, invitation.Role); err != nil {
+		return nil, err
+	}
+	if err = s.userService.CheckEmailVerified(ctx, invitation.InvitedBy); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.fetchWorkspace(ctx, invitation.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation.ID = primitive.NewObjectID()
+	invitation.Email = utils.NormalizeEmail(invitation.Email)
+	invitation.Status = models.InvitationStatusPending
+	invitation.CreateTime = primitive.NewDateTimeFromTime(now)
+	invitation.ExpireTime = primitive.NewDateTimeFromTime(now.Add(invitationTTL))
+
+	token, err := utils.GenerateInvitationToken(invitation, s.config)
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create invitation",
+			},
+		}
+	}
+
+	if _, err = s.workspaceRepo.insertInvitation(ctx, invitation); err != nil {
+		return nil, &utils.DBInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to create invitation",
+			},
+		}
+	}
+
+	err = s.emailClient.SendEmail(
+		invitation.Email,
+		fmt.Sprintf(
+			`<p>You have been invited to join the workspace <b>%s</b>.</p>`+
+				`<p><a href="%s/invitations/accept?token=%s">Accept the invitation</a></p>`+
+				`<p>The invitation expires on %s.</p>`,
+			html.EscapeString(workspace.Name),
+			s.config.GetAppBaseUrl(),
+			url.QueryEscape(token),
+			invitation.ExpireTime.Time().Format(time.RFC1123),
+		),
+		fmt.Sprintf("Invitation to join %s", workspace.Name),
+	)
+	if err != nil {
+		return nil, &utils.SystemInternalError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "unable to send invitation email",
+			},
+		}
+	}
+
+	return invitation, nil
+}
+
+func (s *serviceClient) AcceptInvitation(
+	ctx context.Context, userId primitive.ObjectID, token string,
+) (*models.WorkspaceMember, error) {
+	claims, err := utils.ValidateInvitationToken(token, s.config)
+	if err != nil {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid invitation token",
+			},
+		}
+	}
+	invitationId, err := primitive.ObjectIDFromHex(claims.InvitationID)
+	if err != nil {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid invitation token",
+			},
+		}
+	}
+
+	invitation, err := s.workspaceRepo.fetchInvitation(ctx, invitationId)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != models.InvitationStatusPending ||
+		invitation.ExpireTime.Time().Before(time.Now()) {
+		return nil, &utils.ReqInvalidArgumentError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invitation is no longer valid",
+			},
+		}
+	}
+
+	user, err := s.userService.FetchUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if utils.NormalizeEmail(user.Email) != utils.NormalizeEmail(invitation.Email) {
+		return nil, &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "invitation was sent to a different email",
+			},
+		}
+	}
+
+	if member, err := s.workspaceRepo.fetchMember(ctx, invitation.WorkspaceID, userId); err == nil {
+		return member, nil
+	}
+
+	session, err := s.workspaceRepo.startSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	member := &models.WorkspaceMember{
+		ID:          primitive.NewObjectID(),
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userId,
+		Role:        invitation.Role,
+		JoinTime:    primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	wc := writeconcern.New(writeconcern.WMajority())
+	rc := readconcern.Snapshot()
+	txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
+	callback := func(ctx mongo.SessionContext) (interface{}, error) {
+		err := s.workspaceRepo.updateInvitationStatus(
+			ctx, invitation.ID, models.InvitationStatusPending, models.InvitationStatusAccepted,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = s.workspaceRepo.insertMember(ctx, member); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	_, err = session.WithTransaction(ctx, callback, txnOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *serviceClient) UpdateMemberRole(
+	ctx context.Context, workspaceId, actorId, userId primitive.ObjectID, role models.WorkspaceRole,
+) (*models.WorkspaceMember, error) {
+	actor, err := s.workspaceRepo.fetchMember(ctx, workspaceId, actorId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.workspaceRepo.fetchMember(ctx, workspaceId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCanManage(actor, target.Role); err != nil {
+		return nil, err
+	}
+	if err = checkCanManage(actor, role); err != nil {
+		return nil, err
+	}
+
+	return s.workspaceRepo.updateMemberRole(ctx, workspaceId, userId, role)
+}
+
+func (s *serviceClient) RemoveMember(ctx context.Context, workspaceId, actorId, userId primitive.ObjectID) error {
+	target, err := s.workspaceRepo.fetchMember(ctx, workspaceId, userId)
+	if err != nil {
+		return err
+	}
+	if target.Role == models.WorkspaceRoleOwner {
+		return &utils.ResourcePermissionDeniedError{
+			GeneralError: &utils.GeneralError{
+				Msg: "the workspace owner can't be removed",
+			},
+		}
+	}
+
+	// members are always allowed to leave a workspace on their own
+	if actorId != userId {
+		actor, err := s.workspaceRepo.fetchMember(ctx, workspaceId, actorId)
+		if err != nil {
+			return err
+		}
+		if err = checkCanManage(actor, target.Role); err != nil {
+			return err
+		}
+	}
+
+	return s.workspaceRepo.deleteMember(ctx, workspaceId, userId)
+}
+
+func (s *serviceClient) LeaveAllWorkspaces(
+	ctx context.Context, userId primitive.ObjectID,
+) ([]primitive.ObjectID, error) {
+	memberships, err := s.workspaceRepo.fetchMembershipsOfUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedIds := make([]primitive.ObjectID, 0)
+	for _, membership := range memberships {
+		if membership.Role != models.WorkspaceRoleOwner {
+			if err = s.workspaceRepo.deleteMember(ctx, membership.WorkspaceID, userId); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// owners can't be replaced, so only workspaces without anybody else
+		// go away with their owner
+		members, err := s.workspaceRepo.fetchMembers(ctx, membership.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 1 {
+			return nil, &utils.FailedPreconditionError{
+				GeneralError: &utils.GeneralError{
+					DevInfo: "workspace " + membership.WorkspaceID.Hex(),
+					Msg:     "remove the other members of the workspaces you own first",
+				},
+			}
+		}
+		if err = s.workspaceRepo.deleteWorkspace(ctx, membership.WorkspaceID); err != nil {
+			return nil, err
+		}
+		deletedIds = append(deletedIds, membership.WorkspaceID)
+	}
+
+	return deletedIds, nil
+}
+
+// checkCanManage reports whether actor may grant, change or revoke the given
+// role. Owners manage everyone, admins only manage plain members.
+func checkCanManage(actor *models.WorkspaceMember, role models.WorkspaceRole) error {
+	switch {
+	case role == models.WorkspaceRoleOwner:
+	case actor.Role == models.WorkspaceRoleOwner:
+		return nil
+	case actor.Role == models.WorkspaceRoleAdmin && role == models.WorkspaceRoleMember:
+		return nil
+	}
+
+	return &utils.ResourcePermissionDeniedError{
+		GeneralError: &utils.GeneralError{
+			Msg: fmt.Sprintf("%s of the workspace can't manage %s role", actor.Role, role),
+		},
+	}
+}
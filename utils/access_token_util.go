@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"slices"
+	"strings"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+)
+
+const maxAccessTokenNameLength = 100
+
+func ParseCreateAccessTokenReq(req *pb.CreateAccessTokenRequest) (*models.AccessToken, error) {
+	if req == nil {
+		return nil, errors.New("req not present")
+	}
+
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, errors.New("name can't be empty")
+	}
+	if len(name) > maxAccessTokenNameLength {
+		return nil, errors.New("name cannot exceed 100 characters")
+	}
+
+	if len(req.GetScopes()) == 0 {
+		return nil, errors.New("scopes can't be empty")
+	}
+	scopes := make([]string, 0, len(req.GetScopes()))
+	for _, scope := range req.GetScopes() {
+		if !slices.Contains(models.AccessTokenScopes, scope) {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	token := &models.AccessToken{
+		Name:   name,
+		Scopes: scopes,
+	}
+	if req.GetExpiresAt() != nil {
+		if err := req.GetExpiresAt().CheckValid(); err != nil {
+			return nil, errors.New("invalid expires at")
+		}
+		expireTime := req.GetExpiresAt().AsTime()
+		if !expireTime.After(time.Now()) {
+			return nil, errors.New("expires at must be in the future")
+		}
+		token.ExpireTime = primitive.NewDateTimeFromTime(expireTime)
+	}
+	return token, nil
+}
+
+func ConvertDbAccessTokenToApi(dbToken *models.AccessToken) *pb.PersonalAccessToken {
+	if dbToken == nil {
+		return nil
+	}
+
+	apiToken := &pb.PersonalAccessToken{
+		Id:         dbToken.ID.Hex(),
+		Name:       dbToken.Name,
+		Scopes:     dbToken.Scopes,
+		CreateTime: timestamppb.New(dbToken.CreateTime.Time()),
+	}
+	if dbToken.ExpireTime != 0 {
+		apiToken.ExpireTime = timestamppb.New(dbToken.ExpireTime.Time())
+	}
+	if dbToken.LastUsedTime != 0 {
+		apiToken.LastUsedTime = timestamppb.New(dbToken.LastUsedTime.Time())
+	}
+	return apiToken
+}
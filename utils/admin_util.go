@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+)
+
+const (
+	maxAuditReasonLength = 500
+	// DefaultImpersonationTTL is how long impersonation tokens are valid
+	// unless the admin asks for another duration up to the max
+	DefaultImpersonationTTL = 15 * time.Minute
+	MaxImpersonationTTL     = time.Hour
+)
+
+// GetAuditActorFromContext is the admin of an authenticated request
+func GetAuditActorFromContext(ctx context.Context) (*models.AuditActor, error) {
+	scope, err := GetScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditActor{
+		UserID:   scope.UserID,
+		ClientIp: GetClientIpFromContext(ctx),
+	}, nil
+}
+
+func ParseListUsersReq(req *pb.ListUsersReq) (*models.ListUsersFilter, error) {
+	if req.GetLimit() > 100 {
+		return nil, errors.New("limit cannot exceed 100")
+	}
+	filter := &models.ListUsersFilter{
+		Query: strings.TrimSpace(req.GetQuery()),
+		Limit: req.GetLimit(),
+		Page:  max(req.GetPage(), 1),
+	}
+	if filter.Limit < 1 {
+		filter.Limit = 20
+	}
+	return filter, nil
+}
+
+// ParseAdminUserChange parses the user an admin changes and the reason they
+// give for the audit log
+func ParseAdminUserChange(userId, reason string) (primitive.ObjectID, string, error) {
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return primitive.NilObjectID, "", errors.New("invalid user id")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return primitive.NilObjectID, "", errors.New("reason can't be empty")
+	}
+	if len(reason) > maxAuditReasonLength {
+		return primitive.NilObjectID, "", errors.New("reason cannot exceed 500 characters")
+	}
+	return id, reason, nil
+}
+
+func ParseSetPremiumReq(req *pb.SetPremiumReq) (primitive.ObjectID, string, time.Time, error) {
+	userId, reason, err := ParseAdminUserChange(req.GetUserId(), req.GetReason())
+	if err != nil {
+		return primitive.NilObjectID, "", time.Time{}, err
+	}
+	if req.GetExpireTime() == nil {
+		return userId, reason, time.Time{}, nil
+	}
+
+	if !req.GetIsPremium() {
+		return primitive.NilObjectID, "", time.Time{}, errors.New("expire time needs is premium")
+	}
+	if err = req.GetExpireTime().CheckValid(); err != nil {
+		return primitive.NilObjectID, "", time.Time{}, errors.New("invalid expire time")
+	}
+	expireTime := req.GetExpireTime().AsTime()
+	if !expireTime.After(time.Now()) {
+		return primitive.NilObjectID, "", time.Time{}, errors.New("expire time must be in the future")
+	}
+	return userId, reason, expireTime, nil
+}
+
+func ParseImpersonateReq(req *pb.ImpersonateReq) (primitive.ObjectID, string, time.Duration, error) {
+	userId, reason, err := ParseAdminUserChange(req.GetUserId(), req.GetReason())
+	if err != nil {
+		return primitive.NilObjectID, "", 0, err
+	}
+
+	ttl := DefaultImpersonationTTL
+	if req.GetTtlMinutes() < 0 {
+		return primitive.NilObjectID, "", 0, errors.New("ttl minutes can't be negative")
+	}
+	if req.GetTtlMinutes() > 0 {
+		ttl = time.Duration(req.GetTtlMinutes()) * time.Minute
+	}
+	if ttl > MaxImpersonationTTL {
+		return primitive.NilObjectID, "", 0, errors.New("ttl minutes cannot exceed 60")
+	}
+	return userId, reason, ttl, nil
+}
+
+func ConvertDbUserToApiAdminUser(user *models.User) *pb.AdminUser {
+	apiUser := &pb.AdminUser{
+		Id:               user.ID.Hex(),
+		Name:             user.Name,
+		Email:            user.Email,
+		EmailVerified:    user.EmailVerified,
+		IsPremium:        user.Plan(time.Now()) == models.PlanPremium,
+		Disabled:         user.Disabled,
+		Roles:            user.Roles,
+		TwoFactorEnabled: user.TwoFactor != nil && user.TwoFactor.Enabled,
+		CreateTime:       timestamppb.New(user.CreatedAt.Time()),
+	}
+	if user.DisableTime != 0 {
+		apiUser.DisableTime = timestamppb.New(user.DisableTime.Time())
+	}
+	if user.IsPremium && user.PremiumExpireTime != 0 {
+		apiUser.PremiumExpireTime = timestamppb.New(user.PremiumExpireTime.Time())
+	}
+	return apiUser
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"todo-grpc/models"
+	"todo-grpc/pb"
+)
+
+func ParseAnalyticsRangeReq(req *pb.AnalyticsRangeReq) (*models.AnalyticsFilter, error) {
+	start, end, location, err := parseTimeRange(req.GetStartTime(), req.GetEndTime(), req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+	return &models.AnalyticsFilter{
+		Start:    start,
+		End:      end,
+		Location: location,
+	}, nil
+}
+
+func ConvertDbDailyActiveUsersToApi(dailyActiveUsers []models.DailyActiveUsers) *pb.DailyActiveUsersRes {
+	days := make([]*pb.DailyActiveUsers, 0, len(dailyActiveUsers))
+	for _, day := range dailyActiveUsers {
+		days = append(
+			days, &pb.DailyActiveUsers{
+				Date:  day.Date,
+				Users: day.Users,
+			},
+		)
+	}
+	return &pb.DailyActiveUsersRes{
+		Days: days,
+	}
+}
+
+func ConvertDbCompletionFunnelToApi(funnel *models.CompletionFunnel) *pb.CompletionFunnelRes {
+	return &pb.CompletionFunnelRes{
+		Created:   funnel.Created,
+		Updated:   funnel.Updated,
+		Completed: funnel.Completed,
+	}
+}
@@ -2,12 +2,21 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"github.com/golang-jwt/jwt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"todo-grpc/models"
@@ -15,55 +24,269 @@ import (
 
 const AuthorizationKey = "authorization"
 
-func ValidateJwtToken(md metadata.MD, config EnvConfig) (*models.UserClaims, error) {
+// AccessTokenTTL is how long access tokens are valid, clients renew them with
+// their refresh token
+const AccessTokenTTL = 15 * time.Minute
+
+// TokenRevocationChecker tells whether a token was revoked, e.g. by logging
+// out, before it expired.
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, claims *models.UserClaims) (bool, error)
+}
+
+// AccessTokenPrefix tells personal access tokens apart from JWTs, it also
+// lets secret scanners find leaked ones
+const AccessTokenPrefix = "tgp_"
+
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
+
+// GetBearerToken returns the bearer token of the authorization header
+func GetBearerToken(md metadata.MD) (string, error) {
 	authHeaders, ok := md[AuthorizationKey]
 	if !ok || len(authHeaders) == 0 {
-		return nil, grpc.Errorf(codes.Unauthenticated, "token not present in headers")
+		return "", grpc.Errorf(codes.Unauthenticated, "token not present in headers")
 	}
 
 	authToken := strings.Split(authHeaders[0], " ")
 	if len(authToken) != 2 || authToken[0] != "Bearer" {
-		return nil, grpc.Errorf(codes.Unauthenticated, "token not bearer")
+		return "", grpc.Errorf(codes.Unauthenticated, "token not bearer")
+	}
+
+	return authToken[1], nil
+}
+
+func ValidateJwtToken(
+	ctx context.Context, md metadata.MD, config EnvConfig, revocations TokenRevocationChecker,
+) (*models.UserClaims, error) {
+	token, err := GetBearerToken(md)
+	if err != nil {
+		return nil, err
 	}
 
-	token := authToken[1]
 	claims := &models.UserClaims{}
-	parseToken, err := jwt.ParseWithClaims(
-		token, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(config.GetJwtSecret()), nil
-		},
-	)
+	parseToken, err := jwt.ParseWithClaims(token, claims, accessTokenKeyFunc(config))
 	if err != nil {
 		if err == jwt.ErrSignatureInvalid {
 			return nil, grpc.Errorf(codes.Unauthenticated, "invalid token signature")
 		}
 		return nil, grpc.Errorf(codes.Unauthenticated, "token is expired")
 	}
-	if !parseToken.Valid || claims.UserID == "" {
+	// tokens without an id can't be revoked and aren't accepted
+	if !parseToken.Valid || claims.UserID == "" || claims.Id == "" {
 		return nil, grpc.Errorf(codes.Unauthenticated, "invalid token")
 	}
+	if !claims.VerifyIssuer(config.GetJwtIssuer(), true) || !claims.VerifyAudience(config.GetJwtAudience(), true) {
+		return nil, grpc.Errorf(codes.Unauthenticated, "token isn't meant for us")
+	}
+
+	revoked, err := revocations.IsTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unavailable, "unable to check token")
+	}
+	if revoked {
+		return nil, grpc.Errorf(codes.Unauthenticated, "token is revoked")
+	}
 	return claims, nil
 }
 
-func GenerateToken(userID string, config EnvConfig) (string, error) {
-	// Create token
-	jwtExpirationTime := time.Now().Add(time.Hour * 24).Unix()
+// accessTokenKeyFunc verifies access tokens with the key of their kid out of
+// the key set, or with the jwt secret when there is none. Tokens signed with
+// the secret aren't accepted once there is a key set.
+func accessTokenKeyFunc(config EnvConfig) jwt.Keyfunc {
+	keySet := config.GetJwtKeySet()
+	return func(token *jwt.Token) (interface{}, error) {
+		if keySet == nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(config.GetJwtSecret()), nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := keySet.VerificationKey(kid)
+		if !ok {
+			return nil, errors.New("unknown key id")
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.PublicKey, nil
+	}
+}
+
+// GenerateToken issues an access token of the given token generation of the
+// user and returns it with its expiry, every token gets a random id. A zero
+// authTime leaves it out of the token, e.g. when a session is refreshed.
+func GenerateToken(userID string, generation int64, authTime time.Time, config EnvConfig) (string, time.Time, error) {
 	claims := &models.UserClaims{
-		UserID: userID,
+		UserID:     userID,
+		Generation: generation,
+	}
+	if !authTime.IsZero() {
+		claims.AuthTime = authTime.Unix()
+	}
+	return generateAccessToken(claims, AccessTokenTTL, config)
+}
+
+// GenerateImpersonationToken issues an access token of the user to the admin
+// acting as them, the admin is named in its claims. It is revoked together
+// with the other tokens of the user.
+func GenerateImpersonationToken(
+	userID string, generation int64, impersonatorID string, ttl time.Duration, config EnvConfig,
+) (string, time.Time, error) {
+	claims := &models.UserClaims{
+		UserID:         userID,
+		Generation:     generation,
+		ImpersonatorID: impersonatorID,
+	}
+	return generateAccessToken(claims, ttl, config)
+}
+
+func generateAccessToken(claims *models.UserClaims, ttl time.Duration, config EnvConfig) (string, time.Time, error) {
+	tokenId := make([]byte, 16)
+	if _, err := rand.Read(tokenId); err != nil {
+		return "", time.Time{}, err
+	}
+
+	// Create token
+	now := time.Now()
+	expireTime := now.Add(ttl)
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        hex.EncodeToString(tokenId),
+		Issuer:    config.GetJwtIssuer(),
+		Audience:  config.GetJwtAudience(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expireTime.Unix(),
+	}
+
+	var t string
+	var err error
+	if keySet := config.GetJwtKeySet(); keySet != nil {
+		t, err = keySet.Sign(claims)
+	} else {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		t, err = token.SignedString([]byte(config.GetJwtSecret()))
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return t, expireTime, nil
+}
+
+func GenerateInvitationToken(invitation *models.WorkspaceInvitation, config EnvConfig) (string, error) {
+	claims := &models.InvitationClaims{
+		InvitationID: invitation.ID.Hex(),
+		WorkspaceID:  invitation.WorkspaceID.Hex(),
+		Email:        invitation.Email,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: jwtExpirationTime,
+			ExpiresAt: invitation.ExpireTime.Time().Unix(),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	// Generate encoded token and send it as response.
-	// The signing string should be secret (a generated UUID works too)
-	t, err := token.SignedString([]byte(config.GetJwtSecret()))
+	return token.SignedString([]byte(config.GetJwtSecret()))
+}
+
+func ValidateInvitationToken(token string, config EnvConfig) (*models.InvitationClaims, error) {
+	claims := &models.InvitationClaims{}
+	parseToken, err := jwt.ParseWithClaims(
+		token, claims, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(config.GetJwtSecret()), nil
+		},
+	)
 	if err != nil {
+		return nil, err
+	}
+	if !parseToken.Valid || claims.InvitationID == "" {
+		return nil, errors.New("invalid invitation token")
+	}
+	return claims, nil
+}
+
+func GenerateTwoFactorChallengeToken(userId primitive.ObjectID, config EnvConfig) (string, error) {
+	claims := &models.TwoFactorChallengeClaims{
+		UserID: userId.Hex(),
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(TwoFactorChallengeTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(config.GetJwtSecret()))
+}
+
+func ValidateTwoFactorChallengeToken(token string, config EnvConfig) (*models.TwoFactorChallengeClaims, error) {
+	claims := &models.TwoFactorChallengeClaims{}
+	parseToken, err := jwt.ParseWithClaims(
+		token, claims, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(config.GetJwtSecret()), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !parseToken.Valid || claims.UserID == "" {
+		return nil, errors.New("invalid two factor challenge token")
+	}
+	return claims, nil
+}
+
+func GenerateEmailVerificationToken(
+	userId primitive.ObjectID, email string, expireTime time.Time, config EnvConfig,
+) (string, error) {
+	claims := &models.EmailVerificationClaims{
+		UserID: userId.Hex(),
+		Email:  email,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expireTime.Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(config.GetJwtSecret()))
+}
+
+func ValidateEmailVerificationToken(token string, config EnvConfig) (*models.EmailVerificationClaims, error) {
+	claims := &models.EmailVerificationClaims{}
+	parseToken, err := jwt.ParseWithClaims(
+		token, claims, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(config.GetJwtSecret()), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !parseToken.Valid || claims.UserID == "" || claims.Email == "" {
+		return nil, errors.New("invalid email verification token")
+	}
+	return claims, nil
+}
+
+// GenerateSecretToken creates an opaque random token. Only its hash made by
+// HashSecretToken is stored.
+func GenerateSecretToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
 
-	return t, nil
+func HashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func HashPassword(password string) (string, error) {
@@ -71,7 +294,18 @@ func HashPassword(password string) (string, error) {
 	return string(bytes), err
 }
 
+// dummyPasswordHash hashes a random password with the cost of HashPassword,
+// nothing matches it
+const dummyPasswordHash = "$2a$14$5E/3VaDnoUxlgjqeuHAEeOO40oDlhGy9CiM4SiZ/6UykCO1mk2ZMC"
+
+// CheckPassword never matches an empty hash, e.g. of an unknown user or of a
+// user without a password. It is compared against a dummy hash all the same,
+// so that the time taken doesn't tell which users exist.
 func CheckPassword(password, hash string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		return false
+	}
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
@@ -85,6 +319,98 @@ func GetUserNameFromContext(ctx context.Context) string {
 	return ""
 }
 
+// GetImpersonatorIdFromContext returns the admin impersonating the user of
+// the request, or an empty string when the user acts themselves.
+func GetImpersonatorIdFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if impersonatorIds := md.Get(string(AuthedImpersonatorIdHex)); len(impersonatorIds) > 0 {
+			return impersonatorIds[0]
+		}
+	}
+	return ""
+}
+
+// GetAuthTimeFromContext returns when the user of the request authenticated,
+// zero unless the token was issued for a new session.
+func GetAuthTimeFromContext(ctx context.Context) time.Time {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if authTimes := md.Get(string(AuthedAuthTime)); len(authTimes) > 0 {
+			if authTime, err := strconv.ParseInt(authTimes[0], 10, 64); err == nil {
+				return time.Unix(authTime, 0)
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// RecentAuthWindow is how long after authenticating users without a password
+// may confirm sensitive changes with their session instead
+const RecentAuthWindow = 10 * time.Minute
+
+// CheckRecentAuth fails unless the user authenticated within RecentAuthWindow.
+func CheckRecentAuth(authTime time.Time) error {
+	if authTime.IsZero() || time.Since(authTime) > RecentAuthWindow {
+		return &FailedPreconditionError{
+			GeneralError: &GeneralError{
+				DevInfo: "last authenticated at " + authTime.String(),
+				Msg:     "log in again to confirm",
+			},
+		}
+	}
+	return nil
+}
+
+// GetClientIpFromContext returns the ip of the peer of the request, or an
+// empty string when it isn't known.
+func GetClientIpFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func GetWorkspaceIdFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if workspaceIds := md.Get(string(AuthedWorkspaceIdHex)); len(workspaceIds) > 0 {
+			return workspaceIds[0]
+		}
+	}
+	return ""
+}
+
+// GetScopeFromContext builds the data scope of an authenticated request out of
+// the user and workspace ids put into the metadata by the auth middleware.
+func GetScopeFromContext(ctx context.Context) (*models.Scope, error) {
+	userId, err := primitive.ObjectIDFromHex(GetUserNameFromContext(ctx))
+	if err != nil {
+		return nil, &UnAuthenticatedError{
+			GeneralError: &GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "not authenticated",
+			},
+		}
+	}
+
+	scope := &models.Scope{UserID: userId}
+	if workspaceId := GetWorkspaceIdFromContext(ctx); workspaceId != "" {
+		scope.WorkspaceID, err = primitive.ObjectIDFromHex(workspaceId)
+		if err != nil {
+			return nil, &ReqInvalidArgumentError{
+				GeneralError: &GeneralError{
+					DevInfo: err.Error(),
+					Msg:     "invalid workspace id",
+				},
+			}
+		}
+	}
+	return scope, nil
+}
+
 func validatePassword(password string) bool {
 	hasUppercase := regexp.MustCompile(`[A-Z]`).MatchString(password)
 	// Check for at least one digit
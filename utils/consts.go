@@ -6,4 +6,18 @@ const (
 	AuthedUsername  contextKey = "authedUsername"
 	AuthedUserIdHex contextKey = "authedUserIdHex"
 	RequestIdKey    contextKey = "requestIdKey"
+
+	AuthedWorkspaceIdHex contextKey = "authedWorkspaceIdHex"
+	// AuthedImpersonatorIdHex is the admin acting as the user of the token
+	AuthedImpersonatorIdHex contextKey = "authedImpersonatorIdHex"
+	// AuthedAuthTime is the unix time the user authenticated at, only set
+	// for the tokens of a new session
+	AuthedAuthTime contextKey = "authedAuthTime"
 )
+
+// WorkspaceKey is the metadata header carrying the caller's active workspace.
+const WorkspaceKey = "x-workspace-id"
+
+// IdempotencyKey is the metadata header carrying the client chosen key that
+// deduplicates retries of a mutating request.
+const IdempotencyKey = "idempotency-key"
@@ -2,10 +2,16 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/driver/clickhouse"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func ConnectMongoDB(mongoDbURI string) (*mongo.Client, error) {
@@ -26,8 +32,67 @@ func ConnectMongoDB(mongoDbURI string) (*mongo.Client, error) {
 	return client, nil
 }
 
+func ConnectClickHouse(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(
+		clickhouse.Open(dsn), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Warn),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDb, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err = sqlDb.PingContext(ctx); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func ConnectRedis(url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err = client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
 func GetCollection(db *mongo.Client, collectionName string) *mongo.Collection {
 	// In our system mongo db uses "dev" as the namespace for all environment's collection so hard coding this value here
 	collection := db.Database("dev").Collection(collectionName)
 	return collection
 }
+
+// EnableChangeStreamPreImages makes mongo keep the pre-image of changed
+// documents so that change streams can filter deletions by their content.
+// The collection gets created when it doesn't exist yet.
+func EnableChangeStreamPreImages(ctx context.Context, db *mongo.Client, collectionName string) error {
+	database := GetCollection(db, collectionName).Database()
+	err := database.CreateCollection(ctx, collectionName)
+	if err != nil {
+		var cmdErr mongo.CommandError
+		// NamespaceExists
+		if !errors.As(err, &cmdErr) || cmdErr.Code != 48 {
+			return err
+		}
+	}
+
+	return database.RunCommand(
+		ctx, bson.D{
+			{Key: "collMod", Value: collectionName},
+			{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}},
+		},
+	).Err()
+}
@@ -3,6 +3,10 @@ package utils
 import (
 	"fmt"
 	"github.com/caarlos0/env/v6"
+	"os"
+	"strings"
+	"time"
+	"todo-grpc/models"
 )
 
 type EnvConfig interface {
@@ -12,6 +16,37 @@ type EnvConfig interface {
 	GetKafkaHost() string
 	GetMailChimpApiKey() string
 	GetSenderEmailAddress() string
+	GetAppBaseUrl() string
+	GetSyncTombstoneRetentionDays() int
+	GetClickHouseDsn() string
+	GetRedisUrl() string
+	GetLoginBackoffThreshold() int
+	GetLoginBackoffBase() time.Duration
+	GetLoginLockoutThreshold() int
+	GetLoginLockoutDuration() time.Duration
+	GetLoginIpBackoffThreshold() int
+	GetLoginIpLockoutThreshold() int
+	GetLoginFailureWindow() time.Duration
+	GetTwoFactorEncryptionKey() string
+	GetOidcProviders() []OidcProviderConfig
+	GetOidcRedirectUrl() string
+	GetJwtKeySet() *JwtKeySet
+	GetJwtIssuer() string
+	GetJwtAudience() string
+	GetHttpPort() string
+	GetPlanQuota(plan models.Plan) models.PlanQuota
+	GetPremiumEndingNoticeDays() int
+}
+
+// OidcProviderConfig is an OpenID Connect provider users can log in with,
+// configured through OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID and
+// OIDC_<NAME>_CLIENT_SECRET for every name in OIDC_PROVIDERS.
+type OidcProviderConfig struct {
+	Name     string
+	Issuer   string
+	ClientID string
+	// ClientSecret is empty for public clients, which only rely on PKCE
+	ClientSecret string
 }
 
 type config struct {
@@ -21,6 +56,53 @@ type config struct {
 	KafKaHost          string `env:"KAFKA_HOST"`
 	MailChimpApiKey    string `env:"MAIL_CHIMP_API_KEY"`
 	SenderEmailAddress string `env:"SENDER_EMAIL_ADDRESS"`
+	AppBaseUrl         string `env:"APP_BASE_URL"`
+	// SyncTombstoneRetentionDays is the longest offline window supported by
+	// delta sync, tombstones older than it are compacted
+	SyncTombstoneRetentionDays int `env:"SYNC_TOMBSTONE_RETENTION_DAYS"`
+	// ClickHouseDsn enables analytics, events are dropped when it isn't set
+	ClickHouseDsn string `env:"CLICKHOUSE_DSN"`
+	// RedisUrl selects redis to store revoked tokens, they are kept in mongo
+	// when it isn't set
+	RedisUrl string `env:"REDIS_URL"`
+	// Failed logins of an account or client ip beyond the backoff threshold
+	// delay the next attempt by the backoff base, doubled with every further
+	// failure. Beyond the lockout threshold attempts are refused for the
+	// lockout duration. Failures are forgotten after the failure window.
+	LoginBackoffThreshold   int           `env:"LOGIN_BACKOFF_THRESHOLD"`
+	LoginBackoffBase        time.Duration `env:"LOGIN_BACKOFF_BASE"`
+	LoginLockoutThreshold   int           `env:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutDuration    time.Duration `env:"LOGIN_LOCKOUT_DURATION"`
+	LoginIpBackoffThreshold int           `env:"LOGIN_IP_BACKOFF_THRESHOLD"`
+	LoginIpLockoutThreshold int           `env:"LOGIN_IP_LOCKOUT_THRESHOLD"`
+	LoginFailureWindow      time.Duration `env:"LOGIN_FAILURE_WINDOW"`
+	// TwoFactorEncryptionKey is the base64 AES-256 key TOTP secrets are
+	// encrypted with, two factor authentication can't be enabled without it
+	TwoFactorEncryptionKey string `env:"TWO_FACTOR_ENCRYPTION_KEY"`
+	// OidcProviderNames lists the OpenID Connect providers, the settings of
+	// each are read from variables named after it
+	OidcProviderNames []string `env:"OIDC_PROVIDERS" envSeparator:","`
+	// OidcRedirectUrl is where providers send users back to with the code,
+	// the app passes the code on to CompleteOidcLogin
+	OidcRedirectUrl string `env:"OIDC_REDIRECT_URL"`
+	oidcProviders   []OidcProviderConfig
+	// Access tokens are signed with the RS256 or ES256 key of the PEM file
+	// JwtSigningKeyFile when it is set, and verified with it or any key of
+	// JwtVerificationKeyFiles. They are signed with JwtSecret otherwise.
+	JwtSigningKeyFile       string   `env:"JWT_SIGNING_KEY_FILE"`
+	JwtVerificationKeyFiles []string `env:"JWT_VERIFICATION_KEY_FILES" envSeparator:","`
+	JwtIssuer               string   `env:"JWT_ISSUER"`
+	JwtAudience             string   `env:"JWT_AUDIENCE"`
+	// HttpPort serves the JWKS of the access token keys
+	HttpPort  string `env:"HTTP_PORT"`
+	jwtKeySet *JwtKeySet
+	// The quotas of the plans fall back to their defaults when unset or zero,
+	// a negative quota is unlimited
+	FreePlanMaxTodos    int64 `env:"FREE_PLAN_MAX_TODOS"`
+	PremiumPlanMaxTodos int64 `env:"PREMIUM_PLAN_MAX_TODOS"`
+	// PremiumEndingNoticeDays is how many days before their premium plan
+	// expires users are told about it
+	PremiumEndingNoticeDays int `env:"PREMIUM_ENDING_NOTICE_DAYS"`
 }
 
 func NewEnvConfig() (EnvConfig, error) {
@@ -31,9 +113,88 @@ func NewEnvConfig() (EnvConfig, error) {
 	if envConfig.ServerPort == "" {
 		envConfig.ServerPort = ":8080"
 	}
+	if envConfig.SyncTombstoneRetentionDays <= 0 {
+		envConfig.SyncTombstoneRetentionDays = 30
+	}
+	if envConfig.LoginBackoffThreshold <= 0 {
+		envConfig.LoginBackoffThreshold = 3
+	}
+	if envConfig.LoginBackoffBase <= 0 {
+		envConfig.LoginBackoffBase = time.Second
+	}
+	if envConfig.LoginLockoutThreshold <= 0 {
+		envConfig.LoginLockoutThreshold = 10
+	}
+	if envConfig.LoginLockoutDuration <= 0 {
+		envConfig.LoginLockoutDuration = 15 * time.Minute
+	}
+	// clients behind the same ip share the budget, so it is a lot larger
+	if envConfig.LoginIpBackoffThreshold <= 0 {
+		envConfig.LoginIpBackoffThreshold = 20
+	}
+	if envConfig.LoginIpLockoutThreshold <= 0 {
+		envConfig.LoginIpLockoutThreshold = 100
+	}
+	if envConfig.LoginFailureWindow <= 0 {
+		envConfig.LoginFailureWindow = time.Hour
+	}
+	if envConfig.OidcRedirectUrl == "" {
+		envConfig.OidcRedirectUrl = strings.TrimSuffix(envConfig.AppBaseUrl, "/") + "/oidc/callback"
+	}
+	providers, err := loadOidcProviders(envConfig.OidcProviderNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env config with error: %+v", err)
+	}
+	envConfig.oidcProviders = providers
+	if envConfig.JwtIssuer == "" {
+		envConfig.JwtIssuer = "todo-grpc"
+	}
+	if envConfig.JwtAudience == "" {
+		envConfig.JwtAudience = "todo-grpc"
+	}
+	if envConfig.HttpPort == "" {
+		envConfig.HttpPort = ":8081"
+	}
+	if envConfig.FreePlanMaxTodos == 0 {
+		envConfig.FreePlanMaxTodos = 500
+	}
+	if envConfig.PremiumPlanMaxTodos == 0 {
+		envConfig.PremiumPlanMaxTodos = 50000
+	}
+	if envConfig.PremiumEndingNoticeDays <= 0 {
+		envConfig.PremiumEndingNoticeDays = 7
+	}
+	if envConfig.JwtSigningKeyFile != "" {
+		envConfig.jwtKeySet, err = NewJwtKeySet(envConfig.JwtSigningKeyFile, envConfig.JwtVerificationKeyFiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load jwt keys with error: %+v", err)
+		}
+	}
 	return &envConfig, nil
 }
 
+func loadOidcProviders(names []string) ([]OidcProviderConfig, error) {
+	providers := make([]OidcProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider := OidcProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("oidc provider %s needs %sISSUER and %sCLIENT_ID", name, prefix, prefix)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
 func (e *config) GetJwtSecret() string {
 	if e == nil {
 		return ""
@@ -75,3 +236,147 @@ func (e *config) GetSenderEmailAddress() string {
 	}
 	return e.SenderEmailAddress
 }
+
+func (e *config) GetAppBaseUrl() string {
+	if e == nil {
+		return ""
+	}
+	return e.AppBaseUrl
+}
+
+func (e *config) GetSyncTombstoneRetentionDays() int {
+	if e == nil {
+		return 0
+	}
+	return e.SyncTombstoneRetentionDays
+}
+
+func (e *config) GetClickHouseDsn() string {
+	if e == nil {
+		return ""
+	}
+	return e.ClickHouseDsn
+}
+
+func (e *config) GetRedisUrl() string {
+	if e == nil {
+		return ""
+	}
+	return e.RedisUrl
+}
+
+func (e *config) GetLoginBackoffThreshold() int {
+	if e == nil {
+		return 0
+	}
+	return e.LoginBackoffThreshold
+}
+
+func (e *config) GetLoginBackoffBase() time.Duration {
+	if e == nil {
+		return 0
+	}
+	return e.LoginBackoffBase
+}
+
+func (e *config) GetLoginLockoutThreshold() int {
+	if e == nil {
+		return 0
+	}
+	return e.LoginLockoutThreshold
+}
+
+func (e *config) GetLoginLockoutDuration() time.Duration {
+	if e == nil {
+		return 0
+	}
+	return e.LoginLockoutDuration
+}
+
+func (e *config) GetLoginIpBackoffThreshold() int {
+	if e == nil {
+		return 0
+	}
+	return e.LoginIpBackoffThreshold
+}
+
+func (e *config) GetLoginIpLockoutThreshold() int {
+	if e == nil {
+		return 0
+	}
+	return e.LoginIpLockoutThreshold
+}
+
+func (e *config) GetLoginFailureWindow() time.Duration {
+	if e == nil {
+		return 0
+	}
+	return e.LoginFailureWindow
+}
+
+func (e *config) GetTwoFactorEncryptionKey() string {
+	if e == nil {
+		return ""
+	}
+	return e.TwoFactorEncryptionKey
+}
+
+func (e *config) GetOidcProviders() []OidcProviderConfig {
+	if e == nil {
+		return nil
+	}
+	return e.oidcProviders
+}
+
+func (e *config) GetOidcRedirectUrl() string {
+	if e == nil {
+		return ""
+	}
+	return e.OidcRedirectUrl
+}
+
+// GetJwtKeySet is nil when access tokens are signed with the jwt secret
+func (e *config) GetJwtKeySet() *JwtKeySet {
+	if e == nil {
+		return nil
+	}
+	return e.jwtKeySet
+}
+
+func (e *config) GetJwtIssuer() string {
+	if e == nil {
+		return ""
+	}
+	return e.JwtIssuer
+}
+
+func (e *config) GetJwtAudience() string {
+	if e == nil {
+		return ""
+	}
+	return e.JwtAudience
+}
+
+func (e *config) GetHttpPort() string {
+	if e == nil {
+		return ""
+	}
+	return e.HttpPort
+}
+
+func (e *config) GetPlanQuota(plan models.Plan) models.PlanQuota {
+	if e == nil {
+		return models.PlanQuota{MaxTodos: -1}
+	}
+	if plan == models.PlanPremium {
+		return models.PlanQuota{MaxTodos: e.PremiumPlanMaxTodos}
+	}
+	return models.PlanQuota{MaxTodos: e.FreePlanMaxTodos}
+}
+
+func (e *config) GetPremiumEndingNoticeDays() int {
+	if e == nil {
+		return 0
+	}
+	return e.PremiumEndingNoticeDays
+}
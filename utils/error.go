@@ -2,8 +2,12 @@ package utils
 
 import (
 	"fmt"
+	"github.com/gogo/googleapis/google/rpc"
+	"github.com/gogo/protobuf/types"
 	"github.com/gogo/status"
 	"google.golang.org/grpc/codes"
+	"strconv"
+	"time"
 )
 
 type GeneralError struct {
@@ -39,13 +43,32 @@ type ResourcePermissionDeniedError struct {
 	*GeneralError
 }
 
+// ResourceExhaustedError is a request refused for now, when RetryAfter is
+// set clients are told to wait that long before trying again.
 type ResourceExhaustedError struct {
 	*GeneralError
+	RetryAfter time.Duration
+}
+
+type FailedPreconditionError struct {
+	*GeneralError
+}
+
+type AbortedError struct {
+	*GeneralError
+}
+
+// QuerySyntaxError is an invalid todo query, Position is where in the query
+// it was found, counted in characters from 1.
+type QuerySyntaxError struct {
+	*GeneralError
+	Position int
 }
 
 func GetDebugMessageFromGeneralError(e *GeneralError) string {
 	return fmt.Sprintf(
-		" occurred at %s - More Info: %s",
+		"%s - More Info: %s",
+		e.Msg,
 		e.DevInfo,
 	)
 }
@@ -90,12 +113,55 @@ func CreateStatusErrorFromError(err error, logger *Logger) error {
 		logError(
 			logger, e, GetDebugMessageFromGeneralError(e.GeneralError),
 		)
-		return status.Error(codes.ResourceExhausted, err.Error())
+		if e.RetryAfter <= 0 {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(
+			&rpc.RetryInfo{
+				RetryDelay: types.DurationProto(e.RetryAfter),
+			},
+		)
+		if detailsErr != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return st.Err()
 	case *AlreadyExists:
 		logError(
 			logger, e, GetDebugMessageFromGeneralError(e.GeneralError),
 		)
 		return status.Error(codes.AlreadyExists, err.Error())
+	case *FailedPreconditionError:
+		logError(
+			logger, e, GetDebugMessageFromGeneralError(e.GeneralError),
+		)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case *AbortedError:
+		logError(
+			logger, e, GetDebugMessageFromGeneralError(e.GeneralError),
+		)
+		return status.Error(codes.Aborted, err.Error())
+	case *QuerySyntaxError:
+		logError(
+			logger, e, GetDebugMessageFromGeneralError(e.GeneralError),
+		)
+		st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(
+			&rpc.BadRequest{
+				FieldViolations: []*rpc.BadRequest_FieldViolation{
+					{Field: "query", Description: e.DevInfo},
+				},
+			},
+			&rpc.ErrorInfo{
+				Reason: "INVALID_QUERY",
+				Domain: "todo-grpc",
+				Metadata: map[string]string{
+					"position": strconv.Itoa(e.Position),
+				},
+			},
+		)
+		if detailsErr != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return st.Err()
 	default:
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"os"
+	"sync"
+	"time"
+)
+
+const minRsaKeyBits = 2048
+
+// JwtKey is a key access tokens are signed or verified with. Its Kid is the
+// JWK thumbprint of RFC 7638, so it stays the same wherever the key is
+// loaded.
+type JwtKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	PublicKey  crypto.PublicKey
+	privateKey crypto.Signer
+}
+
+// JwtKeySet holds the signing key of access tokens and every key tokens are
+// verified with, loaded from PEM files. Keys are rotated without downtime in
+// three steps, waiting for every instance to reload and for cached JWKS of
+// verifiers to expire in between:
+//  1. add the new key to the verification keys, so it is published
+//  2. make it the signing key and keep the old one as verification key
+//  3. drop the old key once the tokens signed with it expired
+type JwtKeySet struct {
+	signingKeyFile       string
+	verificationKeyFiles []string
+
+	mu         sync.RWMutex
+	signingKey *JwtKey
+	keys       map[string]*JwtKey
+	modTimes   map[string]time.Time
+}
+
+func NewJwtKeySet(signingKeyFile string, verificationKeyFiles []string) (*JwtKeySet, error) {
+	k := &JwtKeySet{
+		signingKeyFile:       signingKeyFile,
+		verificationKeyFiles: verificationKeyFiles,
+	}
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload reads the key files again, the keys stay as they are when any of
+// them can't be read.
+func (k *JwtKeySet) Reload() error {
+	signingKey, err := loadJwtKey(k.signingKeyFile)
+	if err != nil {
+		return err
+	}
+	if signingKey.privateKey == nil {
+		return fmt.Errorf("signing key %s has no private key", k.signingKeyFile)
+	}
+
+	keys := map[string]*JwtKey{signingKey.Kid: signingKey}
+	modTimes := make(map[string]time.Time)
+	for _, file := range append([]string{k.signingKeyFile}, k.verificationKeyFiles...) {
+		if file != k.signingKeyFile {
+			key, err := loadJwtKey(file)
+			if err != nil {
+				return err
+			}
+			if _, ok := keys[key.Kid]; !ok {
+				keys[key.Kid] = key
+			}
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.signingKey = signingKey
+	k.keys = keys
+	k.modTimes = modTimes
+	return nil
+}
+
+// Watch reloads the keys whenever one of their files changed until the
+// context is done.
+func (k *JwtKeySet) Watch(ctx context.Context, interval time.Duration, logger *Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !k.changed() {
+			continue
+		}
+		if err := k.Reload(); err != nil {
+			logger.Error(err, "unable to reload jwt keys, keeping the current ones")
+			continue
+		}
+		logger.Info("reloaded jwt keys, signing with %s", k.SigningKey().Kid)
+	}
+}
+
+func (k *JwtKeySet) changed() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for file, modTime := range k.modTimes {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *JwtKeySet) SigningKey() *JwtKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.signingKey
+}
+
+// VerificationKey returns the active key with the kid
+func (k *JwtKeySet) VerificationKey(kid string) (*JwtKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// Sign signs the claims with the signing key and sets its kid
+func (k *JwtKeySet) Sign(claims jwt.Claims) (string, error) {
+	key := k.SigningKey()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.privateKey)
+}
+
+// Jwks is the JSON Web Key Set of the active keys, for other services to
+// verify our tokens.
+func (k *JwtKeySet) Jwks() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	keys := make([]map[string]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		jwk := publicJwk(key.PublicKey)
+		jwk["kid"] = key.Kid
+		jwk["use"] = "sig"
+		jwk["alg"] = key.Method.Alg()
+		keys = append(keys, jwk)
+	}
+	return json.Marshal(map[string]interface{}{"keys": keys})
+}
+
+func loadJwtKey(file string) (*JwtKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s has no PEM key", file)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s has an unsupported %s", file, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in %s: %w", file, err)
+	}
+
+	key := &JwtKey{}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.privateKey = signer
+		key.PublicKey = signer.Public()
+	} else {
+		key.PublicKey = parsed
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.N.BitLen() < minRsaKeyBits {
+			return nil, fmt.Errorf("rsa key in %s has less than %d bits", file, minRsaKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if publicKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ec key in %s isn't on P-256", file)
+		}
+		key.Method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("%s has neither an rsa nor an ec key", file)
+	}
+
+	key.Kid, err = jwkThumbprint(key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func publicJwk(publicKey crypto.PublicKey) map[string]string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   b64(k.N.Bytes()),
+			"e":   b64(bigEndian(k.E)),
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC",
+			"crv": k.Curve.Params().Name,
+			"x":   b64(k.X.FillBytes(make([]byte, size))),
+			"y":   b64(k.Y.FillBytes(make([]byte, size))),
+		}
+	}
+	return nil
+}
+
+// jwkThumbprint hashes the required members of the JWK in lexicographic
+// order, which json.Marshal uses for maps
+func jwkThumbprint(publicKey crypto.PublicKey) (string, error) {
+	jwk := publicJwk(publicKey)
+	if jwk == nil {
+		return "", errors.New("unsupported public key")
+	}
+	data, err := json.Marshal(jwk)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func bigEndian(value int) []byte {
+	var out []byte
+	for ; value > 0; value >>= 8 {
+		out = append([]byte{byte(value)}, out...)
+	}
+	return out
+}
@@ -0,0 +1,459 @@
+package utils
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+	"unicode"
+)
+
+// A todo query combines comparisons of fields with AND, OR, NOT and
+// parentheses, juxtaposed terms are AND-ed:
+//
+//	priority>=medium AND (due<7d OR label:urgent) NOT status:done
+//
+// Values with spaces or reserved characters have to be quoted.
+const (
+	maxQueryLength = 1000
+	maxQueryTerms  = 50
+	maxQueryDepth  = 16
+)
+
+type queryTokenKind int
+
+const (
+	queryTokenEOF queryTokenKind = iota
+	queryTokenWord
+	queryTokenString
+	queryTokenOperator
+	queryTokenLParen
+	queryTokenRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	// pos is where the token starts, counted in characters from 1
+	pos int
+}
+
+// queryOperatorNegation is parsed into a not node of the equality
+const queryOperatorNegation = "!="
+
+var queryRelativeTime = regexp.MustCompile(`^([+-]?)(\d{1,4})([hdw])$`)
+
+// queryPriorities orders the priorities from the least to the most urgent
+var queryPriorities = []pb.Todo_Priority{pb.Todo_LOW, pb.Todo_MEDIUM, pb.Todo_HIGH}
+
+var queryFields = []models.QueryField{
+	models.QueryFieldStatus,
+	models.QueryFieldPriority,
+	models.QueryFieldLabel,
+	models.QueryFieldAssignee,
+	models.QueryFieldName,
+	models.QueryFieldDue,
+	models.QueryFieldCreated,
+	models.QueryFieldUpdated,
+}
+
+func newQuerySyntaxError(pos int, format string, args ...interface{}) *QuerySyntaxError {
+	msg := fmt.Sprintf(format, args...)
+	return &QuerySyntaxError{
+		GeneralError: &GeneralError{
+			DevInfo: msg,
+			Msg:     fmt.Sprintf("invalid query at position %d: %s", pos, msg),
+		},
+		Position: pos,
+	}
+}
+
+func isQueryWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.+/@#", r)
+}
+
+func lexQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	tokens := make([]queryToken, 0)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: queryTokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: queryTokenRParen, text: ")", pos: pos})
+			i++
+		case r == ':' || r == '=':
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, text: string(models.QueryOpEqual), pos: pos})
+			i++
+		case r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, newQuerySyntaxError(pos, "expected != operator")
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, text: queryOperatorNegation, pos: pos})
+			i += 2
+		case r == '<' || r == '>':
+			text := string(r)
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				text += "="
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, text: text, pos: pos})
+		case r == '"':
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, newQuerySyntaxError(pos, "unterminated string")
+			}
+			i++
+			tokens = append(tokens, queryToken{kind: queryTokenString, text: value.String(), pos: pos})
+		case isQueryWordRune(r):
+			start := i
+			for i < len(runes) && isQueryWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenWord, text: string(runes[start:i]), pos: pos})
+		default:
+			return nil, newQuerySyntaxError(pos, "unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, queryToken{kind: queryTokenEOF, pos: len(runes) + 1}), nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	next   int
+	depth  int
+	terms  int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) advance() queryToken {
+	token := p.tokens[p.next]
+	if token.kind != queryTokenEOF {
+		p.next++
+	}
+	return token
+}
+
+func (p *queryParser) peekKeyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == queryTokenWord && strings.EqualFold(token.text, keyword)
+}
+
+func (p *queryParser) descend(pos int) error {
+	p.depth++
+	if p.depth > maxQueryDepth {
+		return newQuerySyntaxError(pos, "query is nested more than %d levels", maxQueryDepth)
+	}
+	return nil
+}
+
+func (p *queryParser) parseOr() (*models.QueryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*models.QueryNode{node}
+	for p.peekKeyword("OR") {
+		p.advance()
+		if node, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &models.QueryNode{Kind: models.QueryNodeOr, Children: children}, nil
+}
+
+func (p *queryParser) parseAnd() (*models.QueryNode, error) {
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*models.QueryNode{node}
+	for {
+		if p.peekKeyword("AND") {
+			p.advance()
+		} else if token := p.peek(); token.kind == queryTokenEOF || token.kind == queryTokenRParen ||
+			p.peekKeyword("OR") {
+			break
+		}
+
+		if node, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &models.QueryNode{Kind: models.QueryNodeAnd, Children: children}, nil
+}
+
+func (p *queryParser) parseNot() (*models.QueryNode, error) {
+	if !p.peekKeyword("NOT") {
+		return p.parsePrimary()
+	}
+
+	token := p.advance()
+	if err := p.descend(token.pos); err != nil {
+		return nil, err
+	}
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	p.depth--
+	return &models.QueryNode{Kind: models.QueryNodeNot, Children: []*models.QueryNode{node}}, nil
+}
+
+func (p *queryParser) parsePrimary() (*models.QueryNode, error) {
+	token := p.advance()
+	switch {
+	case token.kind == queryTokenLParen:
+		if err := p.descend(token.pos); err != nil {
+			return nil, err
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != queryTokenRParen {
+			return nil, newQuerySyntaxError(closing.pos, "expected ) to close ( at position %d", token.pos)
+		}
+		p.depth--
+		return node, nil
+	case token.kind == queryTokenEOF:
+		return nil, newQuerySyntaxError(token.pos, "unexpected end of query")
+	case token.kind != queryTokenWord || strings.EqualFold(token.text, "AND") || strings.EqualFold(token.text, "OR"):
+		return nil, newQuerySyntaxError(token.pos, "unexpected %q, expected a field", token.text)
+	}
+
+	operator := p.advance()
+	if operator.kind != queryTokenOperator {
+		return nil, newQuerySyntaxError(operator.pos, "expected an operator after %s", token.text)
+	}
+	value := p.advance()
+	if value.kind != queryTokenWord && value.kind != queryTokenString {
+		return nil, newQuerySyntaxError(value.pos, "expected a value after %s", operator.text)
+	}
+
+	p.terms++
+	if p.terms > maxQueryTerms {
+		return nil, newQuerySyntaxError(token.pos, "query cannot have more than %d terms", maxQueryTerms)
+	}
+	return parseQueryTerm(token, operator, value)
+}
+
+// parseQueryTerm validates a comparison against the allowed fields and
+// normalizes its value.
+func parseQueryTerm(field, operator, value queryToken) (*models.QueryNode, error) {
+	term := &models.QueryTerm{
+		Field:    models.QueryField(strings.ToLower(field.text)),
+		Operator: models.QueryOperator(operator.text),
+		Value:    strings.TrimSpace(value.text),
+		Position: field.pos,
+	}
+	negate := operator.text == queryOperatorNegation
+	if negate {
+		term.Operator = models.QueryOpEqual
+	}
+	if term.Value == "" {
+		return nil, newQuerySyntaxError(value.pos, "value can't be empty")
+	}
+
+	switch term.Field {
+	case models.QueryFieldStatus, models.QueryFieldLabel, models.QueryFieldAssignee, models.QueryFieldName:
+		if term.Operator != models.QueryOpEqual {
+			return nil, newQuerySyntaxError(operator.pos, "%s can't be compared with %s", term.Field, operator.text)
+		}
+	}
+
+	var node *models.QueryNode
+	switch term.Field {
+	case models.QueryFieldStatus:
+		term.Value = strings.ToLower(term.Value)
+		if term.Value != models.QueryValueOpen && term.Value != models.QueryValueDone {
+			return nil, newQuerySyntaxError(value.pos, "status has to be open or done")
+		}
+	case models.QueryFieldPriority:
+		priority, ok := pb.Todo_Priority_value[strings.ToUpper(term.Value)]
+		if !ok {
+			return nil, newQuerySyntaxError(value.pos, "priority has to be low, medium or high")
+		}
+		node = expandPriorityTerm(term, pb.Todo_Priority(priority))
+	case models.QueryFieldLabel:
+		if len(term.Value) > maxTodoLabelSize {
+			return nil, newQuerySyntaxError(value.pos, "label can't be longer than %d characters", maxTodoLabelSize)
+		}
+	case models.QueryFieldAssignee:
+		term.Value = strings.ToLower(term.Value)
+		if term.Value != models.QueryValueMe && term.Value != models.QueryValueNone {
+			if _, err := primitive.ObjectIDFromHex(term.Value); err != nil {
+				return nil, newQuerySyntaxError(value.pos, "assignee has to be me, none or a user id")
+			}
+		}
+	case models.QueryFieldName:
+	case models.QueryFieldDue, models.QueryFieldCreated, models.QueryFieldUpdated:
+		if strings.EqualFold(term.Value, models.QueryValueNone) {
+			if term.Field != models.QueryFieldDue || term.Operator != models.QueryOpEqual {
+				return nil, newQuerySyntaxError(value.pos, "none can only be matched by due")
+			}
+			term.Value = models.QueryValueNone
+			break
+		}
+		queryTime, err := parseQueryTime(term.Value)
+		if err != nil {
+			return nil, newQuerySyntaxError(value.pos, "%s", err.Error())
+		}
+		term.Time = queryTime
+	default:
+		fields := make([]string, 0, len(queryFields))
+		for _, queryField := range queryFields {
+			fields = append(fields, string(queryField))
+		}
+		return nil, newQuerySyntaxError(
+			field.pos, "unknown field %q, expected one of %s", field.text, strings.Join(fields, ", "),
+		)
+	}
+
+	if node == nil {
+		node = &models.QueryNode{Kind: models.QueryNodeTerm, Term: term}
+	}
+	if negate {
+		node = &models.QueryNode{Kind: models.QueryNodeNot, Children: []*models.QueryNode{node}}
+	}
+	return node, nil
+}
+
+// expandPriorityTerm turns a priority comparison into the priorities that
+// satisfy it, priorities compare by urgency.
+func expandPriorityTerm(term *models.QueryTerm, priority pb.Todo_Priority) *models.QueryNode {
+	rank := 0
+	for i, queryPriority := range queryPriorities {
+		if queryPriority == priority {
+			rank = i
+		}
+	}
+
+	children := make([]*models.QueryNode, 0, len(queryPriorities))
+	for i, queryPriority := range queryPriorities {
+		matches := false
+		switch term.Operator {
+		case models.QueryOpEqual:
+			matches = i == rank
+		case models.QueryOpLess:
+			matches = i < rank
+		case models.QueryOpLessEqual:
+			matches = i <= rank
+		case models.QueryOpGreater:
+			matches = i > rank
+		case models.QueryOpGreaterEqual:
+			matches = i >= rank
+		}
+		if !matches {
+			continue
+		}
+		children = append(children, &models.QueryNode{
+			Kind: models.QueryNodeTerm,
+			Term: &models.QueryTerm{
+				Field:    models.QueryFieldPriority,
+				Operator: models.QueryOpEqual,
+				Value:    queryPriority.String(),
+				Position: term.Position,
+			},
+		})
+	}
+
+	switch len(children) {
+	case 0:
+		// nothing is more urgent than high, match no priority at all
+		return &models.QueryNode{Kind: models.QueryNodeOr, Children: children}
+	case 1:
+		return children[0]
+	}
+	return &models.QueryNode{Kind: models.QueryNodeOr, Children: children}
+}
+
+// parseQueryTime parses today, tomorrow, yesterday, a date like 2024-01-31
+// or an offset from now like 7d, -12h or 2w.
+func parseQueryTime(value string) (*models.QueryTime, error) {
+	switch strings.ToLower(value) {
+	case "today":
+		return &models.QueryTime{IsDay: true}, nil
+	case "tomorrow":
+		return &models.QueryTime{IsDay: true, Days: 1}, nil
+	case "yesterday":
+		return &models.QueryTime{IsDay: true, Days: -1}, nil
+	}
+
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return &models.QueryTime{IsDay: true, Date: date}, nil
+	}
+
+	match := queryRelativeTime.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return nil, fmt.Errorf("invalid time %q, expected e.g. today, 2024-01-31 or 7d", value)
+	}
+	amount, _ := strconv.Atoi(match[2])
+	offset := time.Duration(amount) * time.Hour
+	switch match[3] {
+	case "d":
+		offset *= 24
+	case "w":
+		offset *= 7 * 24
+	}
+	if match[1] == "-" {
+		offset = -offset
+	}
+	return &models.QueryTime{Offset: offset}, nil
+}
+
+// ParseTodoQueryString parses a todo query into its syntax tree, an empty
+// query returns nil. Errors are QuerySyntaxError.
+func ParseTodoQueryString(query string) (*models.QueryNode, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	if length := len([]rune(query)); length > maxQueryLength {
+		return nil, newQuerySyntaxError(maxQueryLength+1, "query cannot exceed %d characters", maxQueryLength)
+	}
+
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	parser := &queryParser{tokens: tokens}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != queryTokenEOF {
+		return nil, newQuerySyntaxError(token.pos, "unexpected %q", token.text)
+	}
+	return node, nil
+}
@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"todo-grpc/models"
+)
+
+// formatQueryNode renders a parsed query compactly, e.g.
+// AND(priority:HIGH NOT(label:someday)), to compare trees in tests.
+func formatQueryNode(node *models.QueryNode) string {
+	if node == nil {
+		return ""
+	}
+	if node.Kind == models.QueryNodeTerm {
+		return string(node.Term.Field) + string(node.Term.Operator) + node.Term.Value
+	}
+
+	children := make([]string, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, formatQueryNode(child))
+	}
+	return strings.ToUpper(string(node.Kind)) + "(" + strings.Join(children, " ") + ")"
+}
+
+func TestParseTodoQueryString(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "empty",
+			query: "  ",
+			want:  "",
+		},
+		{
+			name:  "single term",
+			query: "status:open",
+			want:  "status:open",
+		},
+		{
+			name:  "and with not",
+			query: "priority:high AND due<7d AND NOT label:someday",
+			want:  "AND(priority:HIGH due<7d NOT(label:someday))",
+		},
+		{
+			name:  "juxtaposed terms are and-ed",
+			query: "status:open label:work",
+			want:  "AND(status:open label:work)",
+		},
+		{
+			name:  "and binds tighter than or",
+			query: "status:open OR priority:high label:work",
+			want:  "OR(status:open AND(priority:HIGH label:work))",
+		},
+		{
+			name:  "not binds tighter than or",
+			query: "NOT status:done OR label:work",
+			want:  "OR(NOT(status:done) label:work)",
+		},
+		{
+			name:  "parentheses group",
+			query: "(status:open OR label:a) label:b",
+			want:  "AND(OR(status:open label:a) label:b)",
+		},
+		{
+			name:  "keywords are case insensitive",
+			query: "status:open or not label:a",
+			want:  "OR(status:open NOT(label:a))",
+		},
+		{
+			name:  "not equal is a negated equality",
+			query: "label!=someday",
+			want:  "NOT(label:someday)",
+		},
+		{
+			name:  "equals sign is an equality",
+			query: "assignee=ME",
+			want:  "assignee:me",
+		},
+		{
+			name:  "quoted value",
+			query: `name:"two \"words\""`,
+			want:  `name:two "words"`,
+		},
+		{
+			name:  "priority range",
+			query: "priority>=medium",
+			want:  "OR(priority:MEDIUM priority:HIGH)",
+		},
+		{
+			name:  "nothing is more urgent than high",
+			query: "priority>high",
+			want:  "OR()",
+		},
+		{
+			name:  "due none",
+			query: "due:NONE",
+			want:  "due:none",
+		},
+		{
+			name:  "as many terms as allowed",
+			query: strings.Repeat("label:a ", maxQueryTerms),
+			want:  "AND(" + strings.TrimSpace(strings.Repeat("label:a ", maxQueryTerms)) + ")",
+		},
+		{
+			name:  "as deep as allowed",
+			query: strings.Repeat("(", maxQueryDepth) + "label:a" + strings.Repeat(")", maxQueryDepth),
+			want:  "label:a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseTodoQueryString(tt.query)
+			if err != nil {
+				t.Fatalf("ParseTodoQueryString(%q) returned error: %v", tt.query, err)
+			}
+			if got := formatQueryNode(node); got != tt.want {
+				t.Errorf("ParseTodoQueryString(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTodoQueryStringErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		position int
+	}{
+		{
+			name:     "unterminated string",
+			query:    `name:"two words`,
+			position: 6,
+		},
+		{
+			name:     "bang without equals",
+			query:    "label:a !label:b",
+			position: 9,
+		},
+		{
+			name:     "unexpected character",
+			query:    "label:a & label:b",
+			position: 9,
+		},
+		{
+			name:     "unclosed parenthesis",
+			query:    "(status:open",
+			position: 13,
+		},
+		{
+			name:     "unopened parenthesis",
+			query:    "status:open)",
+			position: 12,
+		},
+		{
+			name:     "missing value",
+			query:    "status:",
+			position: 8,
+		},
+		{
+			name:     "missing operator",
+			query:    "status open",
+			position: 8,
+		},
+		{
+			name:     "dangling or",
+			query:    "status:open OR",
+			position: 15,
+		},
+		{
+			name:     "leading and",
+			query:    "AND status:open",
+			position: 1,
+		},
+		{
+			name:     "unknown field",
+			query:    "status:open foo:bar",
+			position: 13,
+		},
+		{
+			name:     "ordering a text field",
+			query:    "status<open",
+			position: 7,
+		},
+		{
+			name:     "unknown priority",
+			query:    "priority:urgent",
+			position: 10,
+		},
+		{
+			name:     "invalid time",
+			query:    "due<soon",
+			position: 5,
+		},
+		{
+			name:     "none compared by order",
+			query:    "due<none",
+			position: 5,
+		},
+		{
+			name:     "too many terms",
+			query:    strings.Repeat("label:a ", maxQueryTerms+1),
+			position: maxQueryTerms*len("label:a ") + 1,
+		},
+		{
+			name:     "too deeply parenthesized",
+			query:    strings.Repeat("(", maxQueryDepth+1) + "label:a" + strings.Repeat(")", maxQueryDepth+1),
+			position: maxQueryDepth + 1,
+		},
+		{
+			name:     "too deeply negated",
+			query:    strings.Repeat("NOT ", maxQueryDepth+1) + "label:a",
+			position: maxQueryDepth*len("NOT ") + 1,
+		},
+		{
+			name:     "too long",
+			query:    "name:" + strings.Repeat("a", maxQueryLength),
+			position: maxQueryLength + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTodoQueryString(tt.query)
+			var syntaxErr *QuerySyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseTodoQueryString(%q) error = %v, want a QuerySyntaxError", tt.query, err)
+			}
+			if syntaxErr.Position != tt.position {
+				t.Errorf(
+					"ParseTodoQueryString(%q) error at position %d, want %d: %v",
+					tt.query, syntaxErr.Position, tt.position, err,
+				)
+			}
+		})
+	}
+}
+
+func TestParseQueryTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  models.QueryTime
+	}{
+		{value: "today", want: models.QueryTime{IsDay: true}},
+		{value: "tomorrow", want: models.QueryTime{IsDay: true, Days: 1}},
+		{value: "yesterday", want: models.QueryTime{IsDay: true, Days: -1}},
+		{value: "2024-01-31", want: models.QueryTime{IsDay: true, Date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}},
+		{value: "7d", want: models.QueryTime{Offset: 7 * 24 * time.Hour}},
+		{value: "-12h", want: models.QueryTime{Offset: -12 * time.Hour}},
+		{value: "+2W", want: models.QueryTime{Offset: 2 * 7 * 24 * time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseQueryTime(tt.value)
+			if err != nil {
+				t.Fatalf("parseQueryTime(%q) returned error: %v", tt.value, err)
+			}
+			if *got != tt.want {
+				t.Errorf("parseQueryTime(%q) = %+v, want %+v", tt.value, *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are the digits of ranks, in ascending order. Ranks compare as
+// plain strings and never end with the lowest digit, so that there is always
+// room for another rank in front of them.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankBetween returns a rank that sorts after prev and before next. An empty
+// prev stands for the start and an empty next for the end of the order.
+func RankBetween(prev, next string) (string, error) {
+	if !validRank(prev) || !validRank(next) {
+		return "", errors.New("invalid rank")
+	}
+	if next != "" && prev >= next {
+		return "", errors.New("ranks are out of order")
+	}
+	return midpointRank(prev, next), nil
+}
+
+func midpointRank(prev, next string) string {
+	if next != "" {
+		// skip the digits both ranks share
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == next[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(prev) {
+				rest = prev[n:]
+			}
+			return next[:n] + midpointRank(rest, next[n:])
+		}
+	}
+
+	digitPrev := 0
+	if prev != "" {
+		digitPrev = strings.IndexByte(rankDigits, prev[0])
+	}
+	digitNext := len(rankDigits)
+	if next != "" {
+		digitNext = strings.IndexByte(rankDigits, next[0])
+	}
+	if digitNext-digitPrev > 1 {
+		return string(rankDigits[(digitPrev+digitNext+1)/2])
+	}
+	if len(next) > 1 {
+		return next[:1]
+	}
+
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(rankDigits[digitPrev]) + midpointRank(rest, "")
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+func validRank(rank string) bool {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(rank, rankDigits[:1])
+}
@@ -1,21 +1,36 @@
 package utils
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/pb"
 )
 
 var todoUpdatableFields = []string{
+	"name", "description", "status", "priority", "assignee_id", "deadline", "recurrence", "labels", "hide_until",
+}
+
+// defaultTodoUpdateFields are updated when no field mask is given. Fields
+// added later stay out of it, so older clients don't clear them.
+var defaultTodoUpdateFields = []string{
 	"name", "description", "status", "priority",
 }
 
+const (
+	maxTodoLabels    = 20
+	maxTodoLabelSize = 50
+)
+
 func ValidateCreateTodoReq(req *pb.CreateTodoReq) error {
 	if req == nil {
 		return errors.New("req not present")
@@ -30,7 +45,7 @@ func ValidateCreateTodoReq(req *pb.CreateTodoReq) error {
 
 func ValidateUpdateTodoFieldMask(fm *fieldmaskpb.FieldMask) ([]string, error) {
 	if fm == nil || len(fm.Paths) == 0 {
-		return todoUpdatableFields, nil
+		return defaultTodoUpdateFields, nil
 	}
 
 	for _, path := range fm.Paths {
@@ -74,9 +89,88 @@ func ConvertApiTodoDbToto(apiTodo *pb.Todo) (*models.Todo, error) {
 		}
 		dbTodo.UserID = userId
 	}
+
+	if apiTodo.AssigneeId != "" {
+		assigneeId, err := primitive.ObjectIDFromHex(apiTodo.AssigneeId)
+		if err != nil {
+			return nil, err
+		}
+		dbTodo.AssigneeID = assigneeId
+	}
+
+	dbTodo.Recurrence = convertApiRecurrenceToDb(apiTodo.Recurrence)
+	if apiTodo.HideUntil != nil {
+		dbTodo.HideUntil = primitive.NewDateTimeFromTime(apiTodo.HideUntil.AsTime())
+	}
+
+	labels, err := parseTodoLabels(apiTodo.Labels)
+	if err != nil {
+		return nil, err
+	}
+	dbTodo.Labels = labels
 	return dbTodo, nil
 }
 
+// parseTodoLabels trims the labels and drops empty and repeated ones.
+func parseTodoLabels(apiLabels []string) ([]string, error) {
+	var labels []string
+	for _, label := range apiLabels {
+		label = strings.TrimSpace(label)
+		if label == "" || slices.Contains(labels, label) {
+			continue
+		}
+		if len(label) > maxTodoLabelSize {
+			return nil, fmt.Errorf("label can't be longer than %d characters", maxTodoLabelSize)
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) > maxTodoLabels {
+		return nil, fmt.Errorf("todo can't have more than %d labels", maxTodoLabels)
+	}
+	return labels, nil
+}
+
+var apiToDbRecurrenceFrequency = map[pb.Recurrence_Frequency]models.RecurrenceFrequency{
+	pb.Recurrence_DAILY:   models.RecurrenceDaily,
+	pb.Recurrence_WEEKLY:  models.RecurrenceWeekly,
+	pb.Recurrence_MONTHLY: models.RecurrenceMonthly,
+}
+
+func convertApiRecurrenceToDb(apiRecurrence *pb.Recurrence) *models.Recurrence {
+	frequency, ok := apiToDbRecurrenceFrequency[apiRecurrence.GetFrequency()]
+	if !ok {
+		return nil
+	}
+
+	recurrence := &models.Recurrence{
+		Frequency: frequency,
+		Interval:  max(apiRecurrence.GetInterval(), 1),
+	}
+	if apiRecurrence.GetUntil() != nil {
+		recurrence.Until = primitive.NewDateTimeFromTime(apiRecurrence.GetUntil().AsTime())
+	}
+	return recurrence
+}
+
+func convertDbRecurrenceToApi(recurrence *models.Recurrence) *pb.Recurrence {
+	if recurrence == nil {
+		return nil
+	}
+
+	apiRecurrence := &pb.Recurrence{
+		Interval: recurrence.Interval,
+	}
+	for apiFrequency, frequency := range apiToDbRecurrenceFrequency {
+		if frequency == recurrence.Frequency {
+			apiRecurrence.Frequency = apiFrequency
+		}
+	}
+	if recurrence.Until != 0 {
+		apiRecurrence.Until = timestamppb.New(recurrence.Until.Time())
+	}
+	return apiRecurrence
+}
+
 func ConvertDbTodoApiToto(dbTodo *models.Todo) *pb.Todo {
 	if dbTodo == nil {
 		return nil
@@ -91,6 +185,13 @@ func ConvertDbTodoApiToto(dbTodo *models.Todo) *pb.Todo {
 		Priority:    pb.Todo_Priority(pb.Todo_Priority_value[dbTodo.Priority]),
 	}
 
+	if !dbTodo.WorkspaceID.IsZero() {
+		apiTodo.WorkspaceId = dbTodo.WorkspaceID.Hex()
+	}
+	if !dbTodo.AssigneeID.IsZero() {
+		apiTodo.AssigneeId = dbTodo.AssigneeID.Hex()
+	}
+
 	if dbTodo.CreateTime != 0 {
 		apiTodo.CreatedAt = timestamppb.New(dbTodo.CreateTime.Time())
 	}
@@ -100,6 +201,16 @@ func ConvertDbTodoApiToto(dbTodo *models.Todo) *pb.Todo {
 	if dbTodo.UpdateTime != 0 {
 		apiTodo.UpdatedAt = timestamppb.New(dbTodo.UpdateTime.Time())
 	}
+	if dbTodo.CompleteTime != 0 {
+		apiTodo.CompletedAt = timestamppb.New(dbTodo.CompleteTime.Time())
+	}
+	apiTodo.Recurrence = convertDbRecurrenceToApi(dbTodo.Recurrence)
+	apiTodo.Labels = dbTodo.Labels
+	apiTodo.Rank = dbTodo.Rank
+	if dbTodo.HideUntil != 0 {
+		apiTodo.HideUntil = timestamppb.New(dbTodo.HideUntil.Time())
+	}
+	apiTodo.SyncSeq = dbTodo.SyncSeq
 	return apiTodo
 }
 
@@ -110,7 +221,49 @@ func ParseListTodoReq(req *pb.ListTodoReq, logger *Logger) (*models.ListTodoFilt
 		}
 		req.Limit = 20
 	}
-	if req.GetPage() == 0 {
+	if req.GetPage() < 1 {
+		req.Page = 1
+	}
+
+	location, expr, err := parseTodoQuery(req.GetTimeZone(), req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	return &models.ListTodoFilter{
+		Limit:          req.GetLimit(),
+		Page:           req.GetPage(),
+		Expr:           expr,
+		Location:       location,
+		IncludeSnoozed: req.GetIncludeSnoozed(),
+	}, nil
+}
+
+// parseTodoQuery resolves the time zone and parses the query string shared by
+// the requests listing todos.
+func parseTodoQuery(timeZone, query string) (*time.Location, *models.QueryNode, error) {
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unknown time zone: %s", timeZone)
+		}
+	}
+	expr, err := ParseTodoQueryString(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return location, expr, nil
+}
+
+func ParseListAssignedTodosReq(req *pb.ListAssignedTodosReq) (*models.ListTodoFilter, error) {
+	if req.GetLimit() > 20 {
+		return nil, errors.New("limit cannot exceed 20")
+	}
+	if req.GetLimit() < 1 {
+		req.Limit = 20
+	}
+	if req.GetPage() < 1 {
 		req.Page = 1
 	}
 	return &models.ListTodoFilter{
@@ -118,3 +271,472 @@ func ParseListTodoReq(req *pb.ListTodoReq, logger *Logger) (*models.ListTodoFilt
 		Page:  req.GetPage(),
 	}, nil
 }
+
+var dbToApiTodoEventType = map[models.TodoEventType]pb.StreamTodoRes_EventType{
+	models.TodoEventSnapshot:         pb.StreamTodoRes_SNAPSHOT,
+	models.TodoEventSnapshotComplete: pb.StreamTodoRes_SNAPSHOT_COMPLETE,
+	models.TodoEventCreated:          pb.StreamTodoRes_CREATED,
+	models.TodoEventUpdated:          pb.StreamTodoRes_UPDATED,
+	models.TodoEventDeleted:          pb.StreamTodoRes_DELETED,
+}
+
+func ParseStreamTodoReq(req *pb.StreamTodoReq) *models.WatchTodoFilter {
+	filter := &models.WatchTodoFilter{}
+	switch req.GetStatus() {
+	case pb.StreamTodoReq_OPEN:
+		status := false
+		filter.Status = &status
+	case pb.StreamTodoReq_DONE:
+		status := true
+		filter.Status = &status
+	}
+
+	for _, priority := range req.GetPriorities() {
+		if !slices.Contains(filter.Priorities, priority.String()) {
+			filter.Priorities = append(filter.Priorities, priority.String())
+		}
+	}
+	return filter
+}
+
+func ConvertDbTodoEventToApi(event *models.TodoEvent) *pb.StreamTodoRes {
+	apiEvent := &pb.StreamTodoRes{
+		Type:        dbToApiTodoEventType[event.Type],
+		Todo:        ConvertDbTodoApiToto(event.Todo),
+		Count:       int32(event.Count),
+		ResumeToken: event.ResumeToken,
+	}
+	if !event.TodoID.IsZero() {
+		apiEvent.TodoId = event.TodoID.Hex()
+	}
+	return apiEvent
+}
+
+const syncTokenVersion = "v1"
+
+func EncodeSyncToken(position models.SyncPosition) string {
+	afterId := ""
+	if !position.AfterID.IsZero() {
+		afterId = position.AfterID.Hex()
+	}
+	token := strings.Join(
+		[]string{
+			syncTokenVersion,
+			strconv.FormatInt(position.Seq, 10),
+			afterId,
+			strconv.FormatBool(position.Resync),
+		}, ":",
+	)
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func DecodeSyncToken(token string) (models.SyncPosition, error) {
+	var position models.SyncPosition
+	if token == "" {
+		return position, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return position, errors.New("invalid sync token")
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 4 || parts[0] != syncTokenVersion {
+		return position, errors.New("invalid sync token")
+	}
+
+	position.Seq, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || position.Seq < 0 {
+		return position, errors.New("invalid sync token")
+	}
+	if parts[2] != "" {
+		position.AfterID, err = primitive.ObjectIDFromHex(parts[2])
+		if err != nil {
+			return position, errors.New("invalid sync token")
+		}
+	}
+	position.Resync, err = strconv.ParseBool(parts[3])
+	if err != nil {
+		return position, errors.New("invalid sync token")
+	}
+	return position, nil
+}
+
+func ParseSyncTodosReq(req *pb.SyncTodosReq) (models.SyncPosition, int64, error) {
+	position, err := DecodeSyncToken(req.GetSyncToken())
+	if err != nil {
+		return position, 0, err
+	}
+
+	limit := int64(req.GetLimit())
+	if limit > 500 {
+		return position, 0, errors.New("limit cannot exceed 500")
+	}
+	if limit < 1 {
+		limit = 100
+	}
+	return position, limit, nil
+}
+
+func ConvertDbTombstoneToApi(tombstone *models.TodoTombstone) *pb.TodoTombstone {
+	return &pb.TodoTombstone{
+		TodoId:    tombstone.ID.Hex(),
+		DeletedAt: timestamppb.New(tombstone.DeleteTime.Time()),
+	}
+}
+
+func ConvertApiTodoChangeToDb(change *pb.TodoChange, userId string) (*models.TodoChange, error) {
+	if change.GetTodo() == nil {
+		return nil, errors.New("todo not present")
+	}
+
+	change.Todo.UserId = userId
+	todo, err := ConvertApiTodoDbToto(change.GetTodo())
+	if err != nil {
+		return nil, err
+	}
+	fieldMaskPaths, err := ValidateUpdateTodoFieldMask(change.GetFieldMask())
+	if err != nil {
+		return nil, err
+	}
+	setsName := todo.ID.IsZero() || slices.Contains(fieldMaskPaths, "name")
+	if change.GetOperation() == pb.TodoChange_UPSERT && setsName && todo.Name == "" {
+		return nil, errors.New("name can't be empty")
+	}
+
+	return &models.TodoChange{
+		ClientChangeID: change.GetClientChangeId(),
+		Delete:         change.GetOperation() == pb.TodoChange_DELETE,
+		Todo:           todo,
+		FieldMasks:     fieldMaskPaths,
+		BaseSyncSeq:    change.GetBaseSyncSeq(),
+	}, nil
+}
+
+var dbToApiTodoChangeStatus = map[models.TodoChangeStatus]pb.TodoChangeResult_Status{
+	models.TodoChangeApplied:  pb.TodoChangeResult_APPLIED,
+	models.TodoChangeConflict: pb.TodoChangeResult_CONFLICT,
+	models.TodoChangeNotFound: pb.TodoChangeResult_NOT_FOUND,
+	models.TodoChangeInvalid:  pb.TodoChangeResult_INVALID,
+}
+
+func ConvertDbTodoChangeResultToApi(result *models.TodoChangeResult) *pb.TodoChangeResult {
+	return &pb.TodoChangeResult{
+		ClientChangeId: result.ClientChangeID,
+		Status:         dbToApiTodoChangeStatus[result.Status],
+		Todo:           ConvertDbTodoApiToto(result.Todo),
+		Message:        result.Msg,
+	}
+}
+
+const (
+	defaultStatsRange = 30 * 24 * time.Hour
+	maxStatsRange     = 366 * 24 * time.Hour
+)
+
+// ParseGetStatsReq resolves the range of the request. Without an end the range
+// ends with the current day, so that repeated requests share a cache entry.
+func ParseGetStatsReq(req *pb.GetStatsReq) (*models.StatsFilter, error) {
+	start, end, location, err := parseTimeRange(req.GetStartTime(), req.GetEndTime(), req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+	return &models.StatsFilter{
+		Start:    start,
+		End:      end,
+		Location: location,
+	}, nil
+}
+
+// parseTimeRange resolves a reporting range, it defaults to the 30 days up to
+// the end of the current day in the given zone.
+func parseTimeRange(
+	startTime, endTime *timestamppb.Timestamp, timeZone string,
+) (time.Time, time.Time, *time.Location, error) {
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, fmt.Errorf("unknown time zone: %s", timeZone)
+		}
+	}
+
+	var end time.Time
+	if endTime != nil {
+		end = endTime.AsTime()
+	} else {
+		now := time.Now().In(location)
+		end = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	start := end.Add(-defaultStatsRange)
+	if startTime != nil {
+		start = startTime.AsTime()
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, nil, errors.New("start time has to be before end time")
+	}
+	if end.Sub(start) > maxStatsRange {
+		return time.Time{}, time.Time{}, nil, errors.New("range cannot exceed 366 days")
+	}
+	return start, end, location, nil
+}
+
+func ConvertDbTodoStatsToApi(stats *models.TodoStats) *pb.GetStatsRes {
+	apiStats := &pb.GetStatsRes{
+		Days:                  make([]*pb.DailyStats, 0, len(stats.Days)),
+		Created:               stats.Created,
+		Completed:             stats.Completed,
+		CompletionRate:        stats.CompletionRate,
+		AverageTimeToComplete: durationpb.New(stats.AverageTimeToComplete),
+		CurrentStreak:         int32(stats.CurrentStreak),
+		LongestStreak:         int32(stats.LongestStreak),
+		Priorities:            make([]*pb.PriorityStats, 0, len(stats.Priorities)),
+	}
+	for _, day := range stats.Days {
+		apiStats.Days = append(
+			apiStats.Days, &pb.DailyStats{
+				Date:      day.Date,
+				Created:   day.Created,
+				Completed: day.Completed,
+				Overdue:   day.Overdue,
+			},
+		)
+	}
+	for _, priority := range stats.Priorities {
+		apiStats.Priorities = append(
+			apiStats.Priorities, &pb.PriorityStats{
+				Priority:  pb.Todo_Priority(pb.Todo_Priority_value[priority.Priority]),
+				Created:   priority.Created,
+				Completed: priority.Completed,
+			},
+		)
+	}
+	return apiStats
+}
+
+const (
+	defaultAgendaRange = 7 * 24 * time.Hour
+	maxAgendaRange     = 92 * 24 * time.Hour
+)
+
+// ParseGetAgendaReq resolves the range of the request, it defaults to the
+// week starting with the current day in the requested zone.
+func ParseGetAgendaReq(req *pb.GetAgendaReq) (*models.AgendaFilter, error) {
+	location := time.UTC
+	if req.GetTimeZone() != "" {
+		var err error
+		location, err = time.LoadLocation(req.GetTimeZone())
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone: %s", req.GetTimeZone())
+		}
+	}
+
+	var start time.Time
+	if req.GetStartTime() != nil {
+		start = req.GetStartTime().AsTime()
+	} else {
+		now := time.Now().In(location)
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	}
+	end := start.Add(defaultAgendaRange)
+	if req.GetEndTime() != nil {
+		end = req.GetEndTime().AsTime()
+	}
+
+	if !start.Before(end) {
+		return nil, errors.New("start time has to be before end time")
+	}
+	if end.Sub(start) > maxAgendaRange {
+		return nil, errors.New("range cannot exceed 92 days")
+	}
+	return &models.AgendaFilter{
+		Start:    start,
+		End:      end,
+		Location: location,
+	}, nil
+}
+
+func convertDbAgendaBucketToApi(bucket *models.AgendaBucket) *pb.AgendaBucket {
+	apiBucket := &pb.AgendaBucket{
+		Date:   bucket.Date,
+		Items:  make([]*pb.AgendaItem, 0, len(bucket.Items)),
+		Totals: make([]*pb.PriorityCount, 0, len(bucket.PriorityTotals)),
+	}
+	for _, item := range bucket.Items {
+		apiBucket.Items = append(
+			apiBucket.Items, &pb.AgendaItem{
+				Todo:      ConvertDbTodoApiToto(item.Todo),
+				OccursAt:  timestamppb.New(item.OccursAt),
+				Recurring: item.Recurring,
+			},
+		)
+	}
+	for priority, count := range bucket.PriorityTotals {
+		apiBucket.Totals = append(
+			apiBucket.Totals, &pb.PriorityCount{
+				Priority: pb.Todo_Priority(pb.Todo_Priority_value[priority]),
+				Count:    count,
+			},
+		)
+	}
+	slices.SortFunc(
+		apiBucket.Totals, func(a, b *pb.PriorityCount) int {
+			return int(a.Priority) - int(b.Priority)
+		},
+	)
+	return apiBucket
+}
+
+func ConvertDbAgendaToApi(agenda *models.Agenda) *pb.GetAgendaRes {
+	apiAgenda := &pb.GetAgendaRes{
+		Days:    make([]*pb.AgendaBucket, 0, len(agenda.Days)),
+		Overdue: convertDbAgendaBucketToApi(&agenda.Overdue),
+	}
+	for _, day := range agenda.Days {
+		apiAgenda.Days = append(apiAgenda.Days, convertDbAgendaBucketToApi(&day))
+	}
+	return apiAgenda
+}
+
+var apiToDbBoardGroupBy = map[pb.BoardGroupBy]models.BoardGroupBy{
+	pb.BoardGroupBy_STATUS:   models.BoardGroupByStatus,
+	pb.BoardGroupBy_PRIORITY: models.BoardGroupByPriority,
+	pb.BoardGroupBy_ASSIGNEE: models.BoardGroupByAssignee,
+	pb.BoardGroupBy_LABEL:    models.BoardGroupByLabel,
+}
+
+// validateBoardColumn checks that the column exists on boards grouped by
+// groupBy. Assignee and label columns exist for any user id or label.
+func validateBoardColumn(groupBy models.BoardGroupBy, column string) error {
+	switch groupBy {
+	case models.BoardGroupByStatus:
+		if column != models.BoardColumnOpen && column != models.BoardColumnDone {
+			return fmt.Errorf("invalid status column: %s", column)
+		}
+	case models.BoardGroupByPriority:
+		if _, ok := pb.Todo_Priority_value[column]; !ok {
+			return fmt.Errorf("invalid priority column: %s", column)
+		}
+	case models.BoardGroupByAssignee:
+		if column != "" && !primitive.IsValidObjectID(column) {
+			return fmt.Errorf("invalid assignee column: %s", column)
+		}
+	}
+	return nil
+}
+
+func ParseGetBoardReq(req *pb.GetBoardReq) (*models.BoardFilter, error) {
+	groupBy, ok := apiToDbBoardGroupBy[req.GetGroupBy()]
+	if !ok {
+		return nil, errors.New("invalid group by")
+	}
+	if req.GetLimit() > 100 {
+		return nil, errors.New("limit cannot exceed 100")
+	}
+
+	location, expr, err := parseTodoQuery(req.GetTimeZone(), req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	filter := &models.BoardFilter{
+		GroupBy: groupBy,
+		Todos: &models.ListTodoFilter{
+			Limit:          req.GetLimit(),
+			Page:           max(req.GetPage(), 1),
+			Expr:           expr,
+			Location:       location,
+			IncludeSnoozed: req.GetIncludeSnoozed(),
+		},
+	}
+	if filter.Todos.Limit < 1 {
+		filter.Todos.Limit = 20
+	}
+	if req.Column != nil {
+		if err := validateBoardColumn(groupBy, req.GetColumn()); err != nil {
+			return nil, err
+		}
+		column := req.GetColumn()
+		filter.Column = &column
+	}
+	return filter, nil
+}
+
+func ParseMoveCardReq(req *pb.MoveCardReq) (*models.CardMove, error) {
+	groupBy, ok := apiToDbBoardGroupBy[req.GetGroupBy()]
+	if !ok {
+		return nil, errors.New("invalid group by")
+	}
+	toColumn := strings.TrimSpace(req.GetToColumn())
+	if err := validateBoardColumn(groupBy, toColumn); err != nil {
+		return nil, err
+	}
+	if len(toColumn) > maxTodoLabelSize {
+		return nil, fmt.Errorf("label can't be longer than %d characters", maxTodoLabelSize)
+	}
+
+	move := &models.CardMove{
+		GroupBy:    groupBy,
+		FromColumn: strings.TrimSpace(req.GetFromColumn()),
+		ToColumn:   toColumn,
+	}
+	var err error
+	move.TodoID, err = primitive.ObjectIDFromHex(req.GetTodoId())
+	if err != nil {
+		return nil, errors.New("invalid todo id")
+	}
+	if req.GetPreviousTodoId() != "" {
+		move.PreviousID, err = primitive.ObjectIDFromHex(req.GetPreviousTodoId())
+		if err != nil {
+			return nil, errors.New("invalid previous todo id")
+		}
+	}
+	if req.GetNextTodoId() != "" {
+		move.NextID, err = primitive.ObjectIDFromHex(req.GetNextTodoId())
+		if err != nil {
+			return nil, errors.New("invalid next todo id")
+		}
+	}
+	return move, nil
+}
+
+func ConvertDbBoardToApi(board *models.Board) *pb.GetBoardRes {
+	apiBoard := &pb.GetBoardRes{
+		Columns: make([]*pb.BoardColumn, 0, len(board.Columns)),
+	}
+	for apiGroupBy, groupBy := range apiToDbBoardGroupBy {
+		if groupBy == board.GroupBy {
+			apiBoard.GroupBy = apiGroupBy
+		}
+	}
+	for _, column := range board.Columns {
+		cards := make([]*pb.Todo, 0, len(column.Cards))
+		for _, card := range column.Cards {
+			cards = append(cards, ConvertDbTodoApiToto(&card))
+		}
+		apiBoard.Columns = append(
+			apiBoard.Columns, &pb.BoardColumn{
+				Key:   column.Key,
+				Count: column.Count,
+				Cards: cards,
+			},
+		)
+	}
+	return apiBoard
+}
+
+func ParseSnoozeTodoReq(req *pb.SnoozeTodoReq) (string, time.Time, error) {
+	if req == nil {
+		return "", time.Time{}, errors.New("req not present")
+	}
+
+	var until time.Time
+	if req.GetUntil() != nil {
+		until = req.GetUntil().AsTime()
+		if !until.After(time.Now()) {
+			return "", time.Time{}, errors.New("until has to be in the future")
+		}
+	}
+	return req.GetTodoId(), until, nil
+}
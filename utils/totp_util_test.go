@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 key of the RFC 6238 test vectors,
+// "12345678901234567890" base32 encoded
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTotpCodeRfc6238(t *testing.T) {
+	// the RFC lists 8 digit codes, 6 digit codes are their last 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			now := time.Unix(tt.unix, 0)
+			step, ok := ValidateTotpCode(rfc6238Secret, tt.code, now)
+			if !ok {
+				t.Fatalf("ValidateTotpCode(%s) at %d = false, want true", tt.code, tt.unix)
+			}
+			if want := tt.unix / 30; step != want {
+				t.Errorf("ValidateTotpCode(%s) at %d matched step %d, want %d", tt.code, tt.unix, step, want)
+			}
+		})
+	}
+}
+
+func TestValidateTotpCodeSkew(t *testing.T) {
+	const unix, code = 1111111109, "081804"
+	tests := []struct {
+		name   string
+		offset time.Duration
+		valid  bool
+	}{
+		{name: "same step", offset: 0, valid: true},
+		{name: "one step behind", offset: -30 * time.Second, valid: true},
+		{name: "one step ahead", offset: 30 * time.Second, valid: true},
+		{name: "two steps behind", offset: -60 * time.Second, valid: false},
+		{name: "two steps ahead", offset: 60 * time.Second, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(unix, 0).Add(tt.offset)
+			step, ok := ValidateTotpCode(rfc6238Secret, code, now)
+			if ok != tt.valid {
+				t.Fatalf("ValidateTotpCode at %s offset = %t, want %t", tt.offset, ok, tt.valid)
+			}
+			if ok && step != unix/30 {
+				t.Errorf("ValidateTotpCode matched step %d, want %d", step, unix/30)
+			}
+		})
+	}
+}
+
+func TestValidateTotpCodeRejects(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{name: "wrong code", secret: rfc6238Secret, code: "287083"},
+		{name: "8 digit code", secret: rfc6238Secret, code: "94287082"},
+		{name: "invalid secret", secret: "not base32!", code: "287082"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTotpCode(tt.secret, tt.code, now); ok {
+				t.Errorf("ValidateTotpCode(%q, %q) = true, want false", tt.secret, tt.code)
+			}
+		})
+	}
+
+	// secrets are typed in lower case as well
+	if _, ok := ValidateTotpCode("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", now); !ok {
+		t.Errorf("ValidateTotpCode with a lower case secret = false, want true")
+	}
+}
+
+func TestEncryptSecretRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	const secret = "JBSWY3DPEHPK3PXP"
+
+	encrypted, err := EncryptSecret(secret, key)
+	if err != nil {
+		t.Fatalf("EncryptSecret returned error: %v", err)
+	}
+	again, err := EncryptSecret(secret, key)
+	if err != nil {
+		t.Fatalf("EncryptSecret returned error: %v", err)
+	}
+	if encrypted == again {
+		t.Errorf("EncryptSecret returned the same ciphertext twice, nonces aren't random")
+	}
+
+	decrypted, err := DecryptSecret(encrypted, key)
+	if err != nil {
+		t.Fatalf("DecryptSecret returned error: %v", err)
+	}
+	if decrypted != secret {
+		t.Errorf("DecryptSecret = %q, want %q", decrypted, secret)
+	}
+}
+
+func TestDecryptSecretRejectsTampering(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	encrypted, err := EncryptSecret("JBSWY3DPEHPK3PXP", key)
+	if err != nil {
+		t.Fatalf("EncryptSecret returned error: %v", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("EncryptSecret returned invalid base64: %v", err)
+	}
+
+	flip := func(i int) string {
+		tampered := bytes.Clone(sealed)
+		tampered[i] ^= 1
+		return base64.StdEncoding.EncodeToString(tampered)
+	}
+	tests := []struct {
+		name      string
+		encrypted string
+		key       []byte
+	}{
+		{name: "flipped nonce", encrypted: flip(0), key: key},
+		{name: "flipped ciphertext", encrypted: flip(len(sealed) / 2), key: key},
+		{name: "flipped tag", encrypted: flip(len(sealed) - 1), key: key},
+		{name: "truncated", encrypted: base64.StdEncoding.EncodeToString(sealed[:8]), key: key},
+		{name: "not base64", encrypted: "%%%", key: key},
+		{name: "wrong key", encrypted: encrypted, key: bytes.Repeat([]byte{8}, 32)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if decrypted, err := DecryptSecret(tt.encrypted, tt.key); err == nil {
+				t.Errorf("DecryptSecret = %q, want an error", decrypted)
+			}
+		})
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	if key, err := ParseEncryptionKey(valid); err != nil || len(key) != 32 {
+		t.Errorf("ParseEncryptionKey(valid) = %d bytes, %v, want 32 bytes", len(key), err)
+	}
+
+	for _, encoded := range []string{
+		"",
+		"not base64",
+		base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 16)),
+	} {
+		if _, err := ParseEncryptionKey(encoded); err == nil {
+			t.Errorf("ParseEncryptionKey(%q) returned no error", encoded)
+		}
+	}
+}
@@ -2,16 +2,34 @@ package utils
 
 import (
 	"errors"
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"slices"
 	"strings"
+	"time"
 	"todo-grpc/models"
 	"todo-grpc/pb"
 )
 
+var profileUpdatableFields = []string{"name", "email"}
+
+var profileReadableFields = []string{
+	"id", "name", "email", "email_verified", "is_premium", "create_time", "plan", "premium_expire_time",
+}
+
+// NormalizeEmail brings an email into the form it is stored and looked up
+// in, so that the same address always matches the same user.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(norm.NFC.String(strings.TrimSpace(email)))
+}
+
 func ConvertApiUserToDbUser(apiUser *pb.User) *models.User {
 	return &models.User{
 		Name:     strings.TrimSpace(apiUser.Name),
 		Password: strings.TrimSpace(apiUser.Password),
-		Email:    strings.TrimSpace(apiUser.Email),
+		Email:    NormalizeEmail(apiUser.Email),
 	}
 }
 
@@ -54,3 +72,208 @@ func ValidateLoginReq(req *pb.LoginRequest) error {
 
 	return nil
 }
+
+func ValidateRequestPasswordResetReq(req *pb.RequestPasswordResetRequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if strings.TrimSpace(req.GetEmail()) == "" {
+		return errors.New("email can't be empty")
+	}
+
+	return nil
+}
+
+func ValidateResetPasswordReq(req *pb.ResetPasswordRequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if req.GetToken() == "" {
+		return errors.New("token can't be empty")
+	}
+
+	if strings.TrimSpace(req.GetNewPassword()) == "" {
+		return errors.New("password can't be empty")
+	}
+
+	if !validatePassword(strings.TrimSpace(req.GetNewPassword())) {
+		return errors.New("invalid password")
+	}
+
+	return nil
+}
+
+func ValidateUpdateProfileFieldMask(fm *fieldmaskpb.FieldMask) ([]string, error) {
+	if fm == nil || len(fm.Paths) == 0 {
+		return profileUpdatableFields, nil
+	}
+
+	for _, path := range fm.Paths {
+		if !slices.Contains(profileUpdatableFields, path) {
+			return nil, fmt.Errorf(
+				"invalid field mask: only support updating the following fields: %s, found: %s",
+				strings.Join(profileUpdatableFields, ","),
+				fm.String(),
+			)
+		}
+	}
+
+	return fm.Paths, nil
+}
+
+func ValidateGetProfileReadMask(fm *fieldmaskpb.FieldMask) ([]string, error) {
+	if fm == nil || len(fm.Paths) == 0 {
+		return profileReadableFields, nil
+	}
+
+	for _, path := range fm.Paths {
+		if !slices.Contains(profileReadableFields, path) {
+			return nil, fmt.Errorf(
+				"invalid read mask: only support reading the following fields: %s, found: %s",
+				strings.Join(profileReadableFields, ","),
+				fm.String(),
+			)
+		}
+	}
+
+	return fm.Paths, nil
+}
+
+func ValidateUpdateProfileReq(req *pb.UpdateProfileRequest, fieldMasks []string) error {
+	if req == nil || req.GetProfile() == nil {
+		return errors.New("profile not present")
+	}
+
+	if slices.Contains(fieldMasks, "name") && strings.TrimSpace(req.GetProfile().GetName()) == "" {
+		return errors.New("name can't be empty")
+	}
+
+	if slices.Contains(fieldMasks, "email") && strings.TrimSpace(req.GetProfile().GetEmail()) == "" {
+		return errors.New("email can't be empty")
+	}
+
+	return nil
+}
+
+func ConvertApiProfileToDbUser(profile *pb.Profile) *models.User {
+	return &models.User{
+		Name:  strings.TrimSpace(profile.GetName()),
+		Email: NormalizeEmail(profile.GetEmail()),
+	}
+}
+
+func ConvertDbUserToApiProfile(user *models.User) *pb.Profile {
+	plan := user.Plan(time.Now())
+	profile := &pb.Profile{
+		Id:            user.ID.Hex(),
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		IsPremium:     plan == models.PlanPremium,
+		CreateTime:    timestamppb.New(user.CreatedAt.Time()),
+		Plan:          string(plan),
+	}
+	if plan == models.PlanPremium && user.PremiumExpireTime != 0 {
+		profile.PremiumExpireTime = timestamppb.New(user.PremiumExpireTime.Time())
+	}
+	return profile
+}
+
+// ApplyProfileReadMask clears the fields of the profile that aren't in the
+// read mask.
+func ApplyProfileReadMask(profile *pb.Profile, readMask []string) *pb.Profile {
+	msg := profile.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if !slices.Contains(readMask, string(fields.Get(i).Name())) {
+			msg.Clear(fields.Get(i))
+		}
+	}
+	return profile
+}
+
+func ValidateChangePasswordReq(req *pb.ChangePasswordRequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if strings.TrimSpace(req.GetNewPassword()) == "" {
+		return errors.New("password can't be empty")
+	}
+
+	if !validatePassword(strings.TrimSpace(req.GetNewPassword())) {
+		return errors.New("invalid password")
+	}
+
+	return nil
+}
+
+func ValidateConfirm2FAReq(req *pb.Confirm2FARequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if !IsTotpCode(NormalizeTwoFactorCode(req.GetCode())) {
+		return errors.New("code must have 6 digits")
+	}
+
+	return nil
+}
+
+func ValidateDisable2FAReq(req *pb.Disable2FARequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if NormalizeTwoFactorCode(req.GetCode()) == "" {
+		return errors.New("code can't be empty")
+	}
+
+	return nil
+}
+
+func ValidateVerify2FAReq(req *pb.Verify2FARequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if req.GetChallengeToken() == "" {
+		return errors.New("challenge token can't be empty")
+	}
+
+	if NormalizeTwoFactorCode(req.GetCode()) == "" {
+		return errors.New("code can't be empty")
+	}
+
+	return nil
+}
+
+func ValidateStartOidcLoginReq(req *pb.StartOidcLoginRequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if req.GetProvider() == "" {
+		return errors.New("provider can't be empty")
+	}
+
+	return nil
+}
+
+func ValidateCompleteOidcLoginReq(req *pb.CompleteOidcLoginRequest) error {
+	if req == nil {
+		return errors.New("empty request")
+	}
+
+	if req.GetState() == "" {
+		return errors.New("state can't be empty")
+	}
+
+	if req.GetCode() == "" {
+		return errors.New("code can't be empty")
+	}
+
+	return nil
+}
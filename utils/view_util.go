@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"slices"
+	"strings"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+)
+
+const maxViewNameLength = 100
+
+var apiToDbAssigneeFilter = map[pb.TodoQuery_AssigneeFilter]models.AssigneeFilter{
+	pb.TodoQuery_ANY_ASSIGNEE: models.AssigneeAny,
+	pb.TodoQuery_ME:           models.AssigneeMe,
+	pb.TodoQuery_OTHERS:       models.AssigneeOthers,
+	pb.TodoQuery_UNASSIGNED:   models.AssigneeUnassigned,
+}
+
+var apiToDbDeadlineWindow = map[pb.TodoQuery_DeadlineWindow]models.DeadlineWindow{
+	pb.TodoQuery_ANY_DEADLINE: models.DeadlineAny,
+	pb.TodoQuery_TODAY:        models.DeadlineToday,
+	pb.TodoQuery_THIS_WEEK:    models.DeadlineThisWeek,
+	pb.TodoQuery_UPCOMING:     models.DeadlineUpcoming,
+	pb.TodoQuery_OVERDUE:      models.DeadlineOverdue,
+	pb.TodoQuery_NO_DEADLINE:  models.DeadlineNone,
+}
+
+var apiToDbSortField = map[pb.TodoQuery_SortField]models.TodoSortField{
+	pb.TodoQuery_CREATED_AT: models.SortByCreateTime,
+	pb.TodoQuery_UPDATED_AT: models.SortByUpdateTime,
+	pb.TodoQuery_DEADLINE:   models.SortByDeadline,
+	pb.TodoQuery_NAME:       models.SortByName,
+	pb.TodoQuery_RANK:       models.SortByRank,
+}
+
+var apiToDbSortOrder = map[pb.TodoQuery_SortOrder]models.SortOrder{
+	pb.TodoQuery_DEFAULT_ORDER: models.SortDefault,
+	pb.TodoQuery_ASCENDING:     models.SortAscending,
+	pb.TodoQuery_DESCENDING:    models.SortDescending,
+}
+
+func ParseCreateViewReq(req *pb.CreateViewReq) (*models.SavedView, error) {
+	if req == nil {
+		return nil, errors.New("req not present")
+	}
+
+	return parseSavedView(req.GetName(), req.GetQuery(), req.GetShared())
+}
+
+func ParseUpdateViewReq(req *pb.UpdateViewReq) (*models.SavedView, error) {
+	if req == nil {
+		return nil, errors.New("req not present")
+	}
+
+	viewId, err := ParseObjectId(req.GetViewId(), "view id")
+	if err != nil {
+		return nil, err
+	}
+	view, err := parseSavedView(req.GetName(), req.GetQuery(), req.GetShared())
+	if err != nil {
+		return nil, err
+	}
+	view.ID = viewId
+	return view, nil
+}
+
+func parseSavedView(name string, apiQuery *pb.TodoQuery, shared bool) (*models.SavedView, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name can't be empty")
+	}
+	if len(name) > maxViewNameLength {
+		return nil, errors.New("name cannot exceed 100 characters")
+	}
+
+	query, err := ConvertApiTodoQueryToDb(apiQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SavedView{
+		Name:   name,
+		Query:  *query,
+		Shared: shared,
+	}, nil
+}
+
+func ConvertApiTodoQueryToDb(apiQuery *pb.TodoQuery) (*models.TodoQuery, error) {
+	query := &models.TodoQuery{}
+	if apiQuery == nil {
+		return query, nil
+	}
+
+	switch apiQuery.GetStatus() {
+	case pb.TodoQuery_ALL:
+	case pb.TodoQuery_OPEN:
+		status := false
+		query.Status = &status
+	case pb.TodoQuery_DONE:
+		status := true
+		query.Status = &status
+	default:
+		return nil, errors.New("unknown status filter")
+	}
+
+	for _, priority := range apiQuery.GetPriorities() {
+		if _, ok := pb.Todo_Priority_name[int32(priority)]; !ok {
+			return nil, errors.New("unknown priority")
+		}
+		if !slices.Contains(query.Priorities, priority.String()) {
+			query.Priorities = append(query.Priorities, priority.String())
+		}
+	}
+
+	labels, err := parseTodoLabels(apiQuery.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	query.Labels = labels
+
+	var ok bool
+	if query.Assignee, ok = apiToDbAssigneeFilter[apiQuery.GetAssignee()]; !ok {
+		return nil, errors.New("unknown assignee filter")
+	}
+	if query.Deadline, ok = apiToDbDeadlineWindow[apiQuery.GetDeadline()]; !ok {
+		return nil, errors.New("unknown deadline window")
+	}
+	if query.SortBy, ok = apiToDbSortField[apiQuery.GetSortBy()]; !ok {
+		return nil, errors.New("unknown sort field")
+	}
+	if query.SortOrder, ok = apiToDbSortOrder[apiQuery.GetSortOrder()]; !ok {
+		return nil, errors.New("unknown sort order")
+	}
+
+	return query, nil
+}
+
+func ConvertDbTodoQueryToApi(query *models.TodoQuery) *pb.TodoQuery {
+	apiQuery := &pb.TodoQuery{
+		Labels: query.Labels,
+	}
+
+	if query.Status != nil {
+		apiQuery.Status = pb.TodoQuery_OPEN
+		if *query.Status {
+			apiQuery.Status = pb.TodoQuery_DONE
+		}
+	}
+	for _, priority := range query.Priorities {
+		apiQuery.Priorities = append(apiQuery.Priorities, pb.Todo_Priority(pb.Todo_Priority_value[priority]))
+	}
+	for apiAssignee, assignee := range apiToDbAssigneeFilter {
+		if assignee == query.Assignee {
+			apiQuery.Assignee = apiAssignee
+		}
+	}
+	for apiDeadline, deadline := range apiToDbDeadlineWindow {
+		if deadline == query.Deadline {
+			apiQuery.Deadline = apiDeadline
+		}
+	}
+	for apiSortBy, sortBy := range apiToDbSortField {
+		if sortBy == query.SortBy {
+			apiQuery.SortBy = apiSortBy
+		}
+	}
+	for apiSortOrder, sortOrder := range apiToDbSortOrder {
+		if sortOrder == query.SortOrder {
+			apiQuery.SortOrder = apiSortOrder
+		}
+	}
+
+	return apiQuery
+}
+
+func ConvertDbSavedViewToApi(dbView *models.SavedView) *pb.SavedView {
+	if dbView == nil {
+		return nil
+	}
+
+	apiView := &pb.SavedView{
+		Name:   dbView.Name,
+		Query:  ConvertDbTodoQueryToApi(&dbView.Query),
+		Shared: dbView.Shared,
+	}
+
+	if dbView.SystemID != "" {
+		apiView.Id = dbView.SystemID
+		apiView.System = true
+		return apiView
+	}
+
+	apiView.Id = dbView.ID.Hex()
+	apiView.OwnerId = dbView.UserID.Hex()
+	if !dbView.WorkspaceID.IsZero() {
+		apiView.WorkspaceId = dbView.WorkspaceID.Hex()
+	}
+	if dbView.CreateTime != 0 {
+		apiView.CreatedAt = timestamppb.New(dbView.CreateTime.Time())
+	}
+	if dbView.UpdateTime != 0 {
+		apiView.UpdatedAt = timestamppb.New(dbView.UpdateTime.Time())
+	}
+	return apiView
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/mail"
+	"strings"
+	"todo-grpc/models"
+	"todo-grpc/pb"
+)
+
+var apiToDbWorkspaceRole = map[pb.WorkspaceRole]models.WorkspaceRole{
+	pb.WorkspaceRole_MEMBER: models.WorkspaceRoleMember,
+	pb.WorkspaceRole_ADMIN:  models.WorkspaceRoleAdmin,
+	pb.WorkspaceRole_OWNER:  models.WorkspaceRoleOwner,
+}
+
+func ParseObjectId(id string, name string) (primitive.ObjectID, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, &ReqInvalidArgumentError{
+			GeneralError: &GeneralError{
+				DevInfo: err.Error(),
+				Msg:     "invalid " + name,
+			},
+		}
+	}
+	return objectId, nil
+}
+
+func ConvertApiWorkspaceRoleToDb(role pb.WorkspaceRole) (models.WorkspaceRole, error) {
+	dbRole, ok := apiToDbWorkspaceRole[role]
+	if !ok {
+		return "", errors.New("unknown workspace role")
+	}
+	return dbRole, nil
+}
+
+func ConvertDbWorkspaceRoleToApi(role models.WorkspaceRole) pb.WorkspaceRole {
+	for apiRole, dbRole := range apiToDbWorkspaceRole {
+		if dbRole == role {
+			return apiRole
+		}
+	}
+	return pb.WorkspaceRole_MEMBER
+}
+
+func ValidateCreateWorkspaceReq(req *pb.CreateWorkspaceReq) error {
+	if req == nil {
+		return errors.New("req not present")
+	}
+
+	if strings.TrimSpace(req.GetName()) == "" {
+		return errors.New("name can't be empty")
+	}
+
+	return nil
+}
+
+func ValidateInviteMemberReq(req *pb.InviteMemberReq) error {
+	if req == nil {
+		return errors.New("req not present")
+	}
+
+	if _, err := mail.ParseAddress(strings.TrimSpace(req.GetEmail())); err != nil {
+		return errors.New("invalid email")
+	}
+
+	if req.GetRole() == pb.WorkspaceRole_OWNER {
+		return errors.New("owner role can't be granted through invitations")
+	}
+
+	return nil
+}
+
+func ConvertDbWorkspaceToApi(dbWorkspace *models.Workspace) *pb.Workspace {
+	if dbWorkspace == nil {
+		return nil
+	}
+
+	apiWorkspace := &pb.Workspace{
+		Id:      dbWorkspace.ID.Hex(),
+		Name:    dbWorkspace.Name,
+		OwnerId: dbWorkspace.OwnerID.Hex(),
+	}
+
+	if dbWorkspace.CreateTime != 0 {
+		apiWorkspace.CreatedAt = timestamppb.New(dbWorkspace.CreateTime.Time())
+	}
+	if dbWorkspace.UpdateTime != 0 {
+		apiWorkspace.UpdatedAt = timestamppb.New(dbWorkspace.UpdateTime.Time())
+	}
+	return apiWorkspace
+}
+
+func ConvertDbWorkspaceMemberToApi(dbMember *models.WorkspaceMember) *pb.WorkspaceMember {
+	if dbMember == nil {
+		return nil
+	}
+
+	apiMember := &pb.WorkspaceMember{
+		Id:          dbMember.ID.Hex(),
+		WorkspaceId: dbMember.WorkspaceID.Hex(),
+		UserId:      dbMember.UserID.Hex(),
+		Role:        ConvertDbWorkspaceRoleToApi(dbMember.Role),
+	}
+
+	if dbMember.JoinTime != 0 {
+		apiMember.JoinedAt = timestamppb.New(dbMember.JoinTime.Time())
+	}
+	return apiMember
+}